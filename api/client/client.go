@@ -2,6 +2,7 @@ package client
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/types"
@@ -20,6 +21,11 @@ type client struct {
 	logRequests  bool
 	logResponses bool
 	serverName   string
+	authToken    string
+	tenant       string
+	signer       types.HeaderSigner
+	maxRetries   int
+	maxRetryWait time.Duration
 }
 
 // New returns a new API client.
@@ -28,7 +34,9 @@ func New(host string, transport *http.Transport) types.APIClient {
 		Client: http.Client{
 			Transport: transport,
 		},
-		host: host,
+		host:         host,
+		maxRetries:   3,
+		maxRetryWait: time.Duration(time.Second * 30),
 	}
 }
 
@@ -43,3 +51,20 @@ func (c *client) LogRequests(enabled bool) {
 func (c *client) LogResponses(enabled bool) {
 	c.logResponses = enabled
 }
+
+func (c *client) AuthToken(token string) {
+	c.authToken = token
+}
+
+func (c *client) Tenant(name string) {
+	c.tenant = name
+}
+
+func (c *client) SignHeaders(signer types.HeaderSigner) {
+	c.signer = signer
+}
+
+func (c *client) Retries(max int, maxWait time.Duration) {
+	c.maxRetries = max
+	c.maxRetryWait = maxWait
+}
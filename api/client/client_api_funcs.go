@@ -4,7 +4,9 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/emccode/libstorage/api/types"
 )
@@ -107,6 +109,36 @@ func (c *client) VolumesByService(
 	return reply, nil
 }
 
+func (c *client) VolumesIterator(
+	ctx types.Context,
+	attachments bool,
+	opts *types.PageOpts) (types.VolumeIterator, error) {
+
+	query := url.Values{}
+	query.Set("attachments", strconv.FormatBool(attachments))
+	p, err := newPager(c, ctx, "/volumes", query, opts, decServiceVolumeMap)
+	if err != nil {
+		return nil, err
+	}
+	return &volumeIterator{p}, nil
+}
+
+func (c *client) VolumesByServiceIterator(
+	ctx types.Context,
+	service string,
+	attachments bool,
+	opts *types.PageOpts) (types.VolumeIterator, error) {
+
+	query := url.Values{}
+	query.Set("attachments", strconv.FormatBool(attachments))
+	p, err := newPager(c, ctx,
+		fmt.Sprintf("/volumes/%s", service), query, opts, decVolumeMap)
+	if err != nil {
+		return nil, err
+	}
+	return &volumeIterator{p}, nil
+}
+
 func (c *client) VolumeInspect(
 	ctx types.Context,
 	service, volumeID string,
@@ -162,6 +194,20 @@ func (c *client) VolumeCopy(
 	return &reply, nil
 }
 
+func (c *client) VolumeResize(
+	ctx types.Context,
+	service, volumeID string,
+	request *types.VolumeResizeRequest) (*types.Volume, error) {
+
+	reply := types.Volume{}
+	if _, err := c.httpPost(ctx,
+		fmt.Sprintf("/volumes/%s/%s?resize", service, volumeID),
+		request, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
 func (c *client) VolumeRemove(
 	ctx types.Context,
 	service, volumeID string) error {
@@ -265,6 +311,31 @@ func (c *client) SnapshotsByService(
 	return reply, nil
 }
 
+func (c *client) SnapshotsIterator(
+	ctx types.Context,
+	opts *types.PageOpts) (types.SnapshotIterator, error) {
+
+	p, err := newPager(
+		c, ctx, "/snapshots", nil, opts, decServiceSnapshotMap)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotIterator{p}, nil
+}
+
+func (c *client) SnapshotsByServiceIterator(
+	ctx types.Context,
+	service string,
+	opts *types.PageOpts) (types.SnapshotIterator, error) {
+
+	p, err := newPager(c, ctx,
+		fmt.Sprintf("/snapshots/%s", service), nil, opts, decSnapshotMap)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotIterator{p}, nil
+}
+
 func (c *client) SnapshotInspect(
 	ctx types.Context,
 	service, snapshotID string) (*types.Snapshot, error) {
@@ -303,6 +374,99 @@ func (c *client) SnapshotCopy(
 	return &reply, nil
 }
 
+func (c *client) TasksIterator(
+	ctx types.Context,
+	opts *types.PageOpts) (types.TaskIterator, error) {
+
+	p, err := newPager(c, ctx, "/tasks", nil, opts, decTaskMap)
+	if err != nil {
+		return nil, err
+	}
+	return &taskIterator{p}, nil
+}
+
+func (c *client) Tasks(
+	ctx types.Context) (map[string]*types.Task, error) {
+
+	reply := map[string]*types.Task{}
+	if _, err := c.httpGet(ctx, "/tasks", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *client) TaskInspect(
+	ctx types.Context,
+	taskID int) (*types.Task, error) {
+
+	reply := types.Task{}
+	if _, err := c.httpGet(ctx,
+		fmt.Sprintf("/tasks/%d", taskID), &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// maxTaskWait is the longest amount of time a single request made by
+// TaskWait waits for a task to complete.
+const maxTaskWait = time.Duration(time.Second * 30)
+
+func (c *client) TaskWait(
+	ctx types.Context,
+	taskID int,
+	timeout time.Duration) (*types.Task, error) {
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		wait := deadline.Sub(time.Now())
+		if wait < 0 {
+			wait = 0
+		}
+		if wait > maxTaskWait {
+			wait = maxTaskWait
+		}
+
+		reply := &types.Task{}
+		if _, err := c.httpGet(ctx,
+			fmt.Sprintf("/tasks/%d?wait=%s", taskID, wait),
+			reply); err != nil {
+			return nil, err
+		}
+
+		if isTaskComplete(reply) || !time.Now().Before(deadline) {
+			return reply, nil
+		}
+	}
+}
+
+func isTaskComplete(t *types.Task) bool {
+	switch t.State {
+	case types.TaskStateSuccess, types.TaskStateError, types.TaskStateCanceled:
+		return true
+	}
+	return false
+}
+
+func (c *client) Quotas(ctx types.Context) (types.ServiceQuotaMap, error) {
+	reply := types.ServiceQuotaMap{}
+	if _, err := c.httpGet(ctx, "/quotas", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *client) QuotasByService(
+	ctx types.Context, service string) ([]*types.Quota, error) {
+
+	reply := []*types.Quota{}
+	if _, err := c.httpGet(ctx,
+		fmt.Sprintf("/quotas/%s", service), &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
 func (c *client) Executors(
 	ctx types.Context) (map[string]*types.ExecutorInfo, error) {
 
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func (c *client) Events(
+	ctx types.Context,
+	opts *types.EventsOpts) (<-chan *types.Event, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	path := "/events"
+	if opts != nil {
+		query := url.Values{}
+		if len(opts.Services) > 0 {
+			query.Set("service", strings.Join(opts.Services, ","))
+		}
+		if len(opts.Types) > 0 {
+			eventTypes := []string{}
+			for _, t := range opts.Types {
+				eventTypes = append(eventTypes, string(t))
+			}
+			query.Set("type", strings.Join(eventTypes, ","))
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	res, err := c.httpGet(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *types.Event)
+
+	go func() {
+		defer close(events)
+		defer res.Body.Close()
+
+		dec := json.NewDecoder(res.Body)
+		for {
+			e := &types.Event{}
+			if err := dec.Decode(e); err != nil {
+				if err != io.EOF {
+					ctx.WithError(err).Debug("event stream closed")
+				}
+				return
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
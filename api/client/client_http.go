@@ -7,7 +7,10 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/goof"
 	"golang.org/x/net/context/ctxhttp"
 
@@ -40,17 +43,6 @@ func (c *client) httpDo(
 	method, path string,
 	payload, reply interface{}) (*http.Response, error) {
 
-	reqBody, err := encPayload(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	url := fmt.Sprintf("http://%s%s", c.host, path)
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, err
-	}
-
 	ctx = context.RequireTX(ctx)
 	tx := context.MustTransaction(ctx)
 	ctx = ctx.WithValue(transactionHeaderKey, tx)
@@ -81,6 +73,82 @@ func (c *client) httpDo(
 		}
 	}
 
+	// a mutating request that may be retried is made with an idempotency key
+	// so that the server does not execute the request more than once
+	var idempotencyKey string
+	if c.maxRetries > 0 &&
+		(method == http.MethodPost || method == http.MethodDelete) {
+		key, err := types.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		idempotencyKey = key.String()
+	}
+
+	for retries := 0; ; retries++ {
+
+		req, err := c.newRequest(ctx, method, path, payload)
+		if err != nil {
+			return nil, err
+		}
+		if idempotencyKey != "" {
+			req.Header.Set(types.IdempotencyKeyHeader, idempotencyKey)
+		}
+
+		c.logRequest(req)
+
+		res, err := ctxhttp.Do(ctx, &c.Client, req)
+		if err != nil {
+			return nil, err
+		}
+		c.setServerName(res)
+
+		c.logResponse(res)
+
+		if res.StatusCode > 299 {
+			if wait, ok := c.retryAfter(res, retries); ok {
+				res.Body.Close()
+				ctx.WithFields(log.Fields{
+					"status":     res.StatusCode,
+					"retryAfter": wait,
+					"retries":    retries,
+				}).Debug("retrying request")
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return res, decodeHTTPError(res)
+		}
+
+		if req.Method != http.MethodHead && reply != nil {
+			if err := decRes(res.Body, reply); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	}
+}
+
+// newRequest returns a new request with the headers for the values stored
+// in the context as well as the authorization and signature headers.
+func (c *client) newRequest(
+	ctx types.Context,
+	method, path string,
+	payload interface{}) (*http.Request, error) {
+
+	reqBody, err := encPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("http://%s%s", c.host, path)
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
 	for key := range context.CustomHeaderKeys() {
 
 		var headerName string
@@ -119,31 +187,135 @@ func (c *client) httpDo(
 		}
 	}
 
-	c.logRequest(req)
+	if c.authToken != "" {
+		req.Header.Set(
+			types.AuthorizationHeader, fmt.Sprintf("Bearer %s", c.authToken))
+	}
 
-	res, err := ctxhttp.Do(ctx, &c.Client, req)
-	if err != nil {
+	if c.tenant != "" {
+		req.Header.Set(types.TenantHeader, c.tenant)
+	}
+
+	if err := c.signHeaders(req); err != nil {
 		return nil, err
 	}
-	defer c.setServerName(res)
 
-	c.logResponse(res)
+	return req, nil
+}
 
-	if res.StatusCode > 299 {
-		httpErr, err := goof.DecodeHTTPError(res.Body)
-		if err != nil {
-			return res, goof.WithField("status", res.StatusCode, "http error")
+// decodeHTTPError decodes the error in the body of a response with an error
+// status. An error with a known error code is decoded as the code's typed
+// error, and any other error is decoded as a goof.HTTPError. A typed error
+// is also a goof.HTTPError whose status is that of the response.
+func decodeHTTPError(res *http.Response) error {
+	buf, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return goof.WithField("status", res.StatusCode, "http error")
+	}
+
+	var v struct {
+		Message string          `json:"message"`
+		Code    types.ErrorCode `json:"code"`
+		Fields  goof.Fields     `json:"error"`
+	}
+	if err := json.Unmarshal(buf, &v); err == nil && v.Code != "" {
+		if err := types.NewErrorFromCode(
+			v.Code,
+			goof.NewHTTPError(
+				goof.WithFields(v.Fields, v.Message),
+				res.StatusCode)); err != nil {
+			return err
 		}
-		return res, httpErr
 	}
 
-	if req.Method != http.MethodHead && reply != nil {
-		if err := decRes(res.Body, reply); err != nil {
-			return nil, err
+	httpErr, err := goof.DecodeHTTPError(bytes.NewReader(buf))
+	if err != nil {
+		return goof.WithField("status", res.StatusCode, "http error")
+	}
+	return httpErr
+}
+
+// retryAfter returns the amount of time to wait before retrying a request
+// that was rejected because the server was too busy to handle it. A request
+// is retried only if the server indicated when to do so, the wait does not
+// exceed the client's maximum wait, and the client has not already retried
+// the request its maximum number of times. A request that timed out is
+// retried immediately if the server honored its idempotency key, since the
+// retry returns the result of the original request.
+func (c *client) retryAfter(
+	res *http.Response, retries int) (time.Duration, bool) {
+
+	if retries >= c.maxRetries {
+		return 0, false
+	}
+	if res.StatusCode == http.StatusRequestTimeout &&
+		res.Header.Get(types.IdempotencyKeyHeader) != "" {
+		return 0, true
+	}
+	if res.StatusCode != http.StatusTooManyRequests &&
+		res.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	wait, ok := parseRetryAfter(res.Header.Get(types.RetryAfterHeader))
+	if !ok || wait > c.maxRetryWait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
 		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
 	}
+	if wait := t.Sub(time.Now()); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// sleep waits for the duration to elapse or the context to be done.
+func sleep(ctx types.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	return res, nil
+// signHeaders adds to the request the signatures of the values of its
+// InstanceID and LocalDevices headers if the client has a signer.
+func (c *client) signHeaders(req *http.Request) error {
+	if c.signer == nil {
+		return nil
+	}
+	for name, sigName := range map[string]string{
+		types.InstanceIDHeader:   types.InstanceIDSignatureHeader,
+		types.LocalDevicesHeader: types.LocalDevicesSignatureHeader,
+	} {
+		for _, v := range req.Header[name] {
+			sig, err := c.signer.SignHeader(name, v)
+			if err != nil {
+				return err
+			}
+			req.Header.Add(sigName, sig)
+		}
+	}
+	return nil
 }
 
 func (c *client) setServerName(res *http.Response) {
@@ -9,6 +9,8 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/gotil"
+
+	"github.com/emccode/libstorage/api/types"
 )
 
 func (c *client) logRequest(req *http.Request) {
@@ -46,9 +48,12 @@ func (c *client) logResponse(res *http.Response) {
 	fmt.Fprint(w, "HTTP RESPONSE (CLIENT)")
 	fmt.Fprintln(w, " -------------------------")
 
+	// the body of a stream is not dumped since reading it blocks until the
+	// stream ends
+	ct := res.Header.Get("Content-Type")
 	buf, err := httputil.DumpResponse(
 		res,
-		res.Header.Get("Content-Type") != "application/octet-stream")
+		ct != "application/octet-stream" && ct != types.JSONStreamContentType)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,270 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils/paging"
+)
+
+// pageDecoder decodes a page of objects as a list of items.
+type pageDecoder func(c *client, ctx types.Context, path string) (
+	[]*paging.Item, string, error)
+
+// pager fetches the pages of a list resource and iterates over the objects
+// in them in the order in which they are sorted by the server.
+type pager struct {
+	c       *client
+	ctx     types.Context
+	path    string
+	query   url.Values
+	sortKey string
+	desc    bool
+	decode  pageDecoder
+	items   []*paging.Item
+	index   int
+	token   string
+	done    bool
+	err     error
+}
+
+func newPager(
+	c *client,
+	ctx types.Context,
+	path string,
+	query url.Values,
+	opts *types.PageOpts,
+	decode pageDecoder) (*pager, error) {
+
+	if query == nil {
+		query = url.Values{}
+	}
+
+	p := &pager{
+		c:      c,
+		ctx:    ctx,
+		path:   path,
+		query:  query,
+		decode: decode,
+		index:  -1,
+	}
+
+	if opts != nil {
+		if opts.Limit > 0 {
+			query.Set(paging.LimitParam, strconv.Itoa(opts.Limit))
+		}
+		if opts.Token != "" {
+			page, err := paging.ParsePage(
+				url.Values{paging.TokenParam: []string{opts.Token}})
+			if err != nil {
+				return nil, err
+			}
+			p.token = opts.Token
+			p.sortKey, p.desc = page.SortKey, page.SortDesc
+		} else {
+			if opts.Offset > 0 {
+				query.Set(paging.OffsetParam, strconv.Itoa(opts.Offset))
+			}
+			if opts.Sort != "" {
+				query.Set(paging.SortParam, opts.Sort)
+				p.sortKey, p.desc = paging.ParseSort(opts.Sort)
+			}
+		}
+	}
+
+	// fetch the first page eagerly so that errors such as invalid page
+	// options are returned to the caller immediately
+	if err := p.fetch(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *pager) fetch() error {
+
+	query := url.Values{}
+	for k, v := range p.query {
+		query[k] = v
+	}
+	if p.token != "" {
+		query.Del(paging.OffsetParam)
+		query.Del(paging.SortParam)
+		query.Set(paging.TokenParam, p.token)
+	}
+
+	path := p.path
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
+	}
+
+	items, token, err := p.decode(p.c, p.ctx, path)
+	if err != nil {
+		return err
+	}
+
+	// the page is a map so it must be sorted again once it is decoded
+	paging.Sort(items, p.sortKey, p.desc)
+
+	p.items = items
+	p.index = -1
+	p.token = token
+	p.done = token == ""
+	return nil
+}
+
+func (p *pager) Next() bool {
+	for {
+		if p.err != nil {
+			return false
+		}
+		if p.index+1 < len(p.items) {
+			p.index++
+			return true
+		}
+		if p.done {
+			return false
+		}
+		p.err = p.fetch()
+	}
+}
+
+func (p *pager) Err() error {
+	return p.err
+}
+
+func (p *pager) current() *paging.Item {
+	if p.index < 0 || p.index >= len(p.items) {
+		return nil
+	}
+	return p.items[p.index]
+}
+
+func (p *pager) Service() string {
+	if i := p.current(); i != nil {
+		return i.Service
+	}
+	return ""
+}
+
+type volumeIterator struct {
+	*pager
+}
+
+func (i *volumeIterator) Volume() *types.Volume {
+	if c := i.current(); c != nil {
+		return c.Object.(*types.Volume)
+	}
+	return nil
+}
+
+type snapshotIterator struct {
+	*pager
+}
+
+func (i *snapshotIterator) Snapshot() *types.Snapshot {
+	if c := i.current(); c != nil {
+		return c.Object.(*types.Snapshot)
+	}
+	return nil
+}
+
+type taskIterator struct {
+	*pager
+}
+
+func (i *taskIterator) Task() *types.Task {
+	if c := i.current(); c != nil {
+		return c.Object.(*types.Task)
+	}
+	return nil
+}
+
+func decServiceVolumeMap(
+	c *client,
+	ctx types.Context,
+	path string) ([]*paging.Item, string, error) {
+
+	reply := types.ServiceVolumeMap{}
+	res, err := c.httpGet(ctx, path, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	return paging.ServiceVolumeItems(reply),
+		res.Header.Get(types.NextPageTokenHeader), nil
+}
+
+func decServiceSnapshotMap(
+	c *client,
+	ctx types.Context,
+	path string) ([]*paging.Item, string, error) {
+
+	reply := types.ServiceSnapshotMap{}
+	res, err := c.httpGet(ctx, path, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	return paging.ServiceSnapshotItems(reply),
+		res.Header.Get(types.NextPageTokenHeader), nil
+}
+
+func decTaskMap(
+	c *client,
+	ctx types.Context,
+	path string) ([]*paging.Item, string, error) {
+
+	reply := map[string]*types.Task{}
+	res, err := c.httpGet(ctx, path, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	tasks := []*types.Task{}
+	for _, t := range reply {
+		tasks = append(tasks, t)
+	}
+	return paging.TaskItems(tasks),
+		res.Header.Get(types.NextPageTokenHeader), nil
+}
+
+// serviceFromPath returns the service name from a path such as
+// "/volumes/{service}".
+func serviceFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func decVolumeMap(
+	c *client,
+	ctx types.Context,
+	path string) ([]*paging.Item, string, error) {
+
+	reply := types.VolumeMap{}
+	res, err := c.httpGet(ctx, path, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	service := serviceFromPath(strings.SplitN(path, "?", 2)[0])
+	return paging.VolumeItems(service, reply),
+		res.Header.Get(types.NextPageTokenHeader), nil
+}
+
+func decSnapshotMap(
+	c *client,
+	ctx types.Context,
+	path string) ([]*paging.Item, string, error) {
+
+	reply := types.SnapshotMap{}
+	res, err := c.httpGet(ctx, path, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	service := serviceFromPath(strings.SplitN(path, "?", 2)[0])
+	return paging.SnapshotItems(service, reply),
+		res.Header.Get(types.NextPageTokenHeader), nil
+}
@@ -107,6 +107,36 @@ func WithStorageService(
 	return newContext(parent, ServiceKey, service, nil, nil)
 }
 
+// WithCancel returns a copy of parent with a new Done channel. The returned
+// context's Done channel is closed when the returned cancel function is
+// called or when the parent context's Done channel is closed, whichever
+// happens first.
+func WithCancel(parent types.Context) (types.Context, context.CancelFunc) {
+	cctx, cancel := context.WithCancel(parent)
+	ctx := newContext(cctx, nil, nil, nil, nil)
+	if logger, ok := parent.Value(LoggerKey).(*log.Logger); ok {
+		ctx.logger = logger
+	}
+	return ctx, cancel
+}
+
+// WithDetachedCancel returns a context that provides all of parent's values
+// but whose Done channel is closed only when the returned cancel function is
+// called. Unlike WithCancel, the parent context's cancellation is not
+// propagated to the returned context. This allows work, such as an
+// asynchronous task, to outlive the request that started it while still
+// being able to be canceled independently.
+func WithDetachedCancel(
+	parent types.Context) (types.Context, context.CancelFunc) {
+
+	cctx, cancel := context.WithCancel(context.Background())
+	ctx := newContext(cctx, nil, nil, nil, parent)
+	if logger, ok := parent.Value(LoggerKey).(*log.Logger); ok {
+		ctx.logger = logger
+	}
+	return ctx, cancel
+}
+
 // WithValue returns a copy of parent in which the value associated with
 // key is val.
 func WithValue(ctx context.Context, key, val interface{}) types.Context {
@@ -315,6 +345,54 @@ func Route(ctx context.Context) (types.Route, bool) {
 	return v, ok
 }
 
+// Principal returns the context's authenticated principal. This value is
+// valid only for contexts created on the server after the auth handler has
+// authenticated the request.
+func Principal(ctx context.Context) (*types.Principal, bool) {
+	v, ok := ctx.Value(PrincipalKey).(*types.Principal)
+	return v, ok
+}
+
+// AuthVerb returns the verb that the context's principal was authorized to
+// perform. This value is valid only for contexts created on the server after
+// the authorizer of the request's route has authorized the request.
+func AuthVerb(ctx context.Context) (types.AuthVerb, bool) {
+	v, ok := ctx.Value(AuthVerbKey).(types.AuthVerb)
+	return v, ok
+}
+
+// PeerCredentials returns the credentials of the process that made the
+// context's request over a UNIX socket.
+func PeerCredentials(ctx context.Context) (*types.PeerCredentials, bool) {
+	v, ok := ctx.Value(PeerCredentialsKey).(*types.PeerCredentials)
+	return v, ok
+}
+
+// Tenant returns the tenant on whose behalf the context's request is made.
+func Tenant(ctx context.Context) (*types.Tenant, bool) {
+	v, ok := ctx.Value(TenantKey).(*types.Tenant)
+	return v, ok
+}
+
+// AuditRecord returns the audit record of the context's request.
+func AuditRecord(ctx context.Context) (*types.AuditRecord, bool) {
+	v, ok := ctx.Value(AuditRecordKey).(*types.AuditRecord)
+	return v, ok
+}
+
+// Authorized returns a flag indicating whether or not the context's principal
+// is allowed to perform the verb on the service. A true value is returned if
+// the context has no principal, which is the case when authentication is
+// disabled or the route allows anonymous requests.
+func Authorized(
+	ctx context.Context, service string, verb types.AuthVerb) bool {
+	p, ok := Principal(ctx)
+	if !ok {
+		return true
+	}
+	return p.Authorized(service, verb)
+}
+
 // Server returns the context's server name. This value is valid on both the
 // client and the server.
 func Server(ctx context.Context) (string, bool) {
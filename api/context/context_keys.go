@@ -33,6 +33,33 @@ const (
 	// AdminTokenKey is the key for the server's admin token.
 	AdminTokenKey
 
+	// PrincipalKey is the key for the *types.Principal value of the
+	// authenticated principal that made a request.
+	PrincipalKey
+
+	// AuthVerbKey is the key for the types.AuthVerb value of the verb that
+	// the principal that made a request was authorized to perform.
+	AuthVerbKey
+
+	// PeerCredentialsKey is the key for the *types.PeerCredentials value of
+	// the process that made a request over a UNIX socket.
+	PeerCredentialsKey
+
+	// TenantKey is the key for the *types.Tenant value of the tenant on
+	// whose behalf a request is made.
+	TenantKey
+
+	// AuditRecordKey is the key for the *types.AuditRecord value of the
+	// audit record of a request.
+	AuditRecordKey
+
+	// AuditLogKey is the key for the server's audit log.
+	AuditLogKey
+
+	// IdempotencyEntryKey is the key for the *idempotency.Entry value of a
+	// request made with an idempotency key.
+	IdempotencyEntryKey
+
 	// keyLoggable is the minimum value from which the succeeding keys should
 	// be checked when logging.
 	keyLoggable
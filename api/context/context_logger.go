@@ -83,10 +83,16 @@ func (ctx *lsc) addkeyFieldOffsetFieldsToEntry(entry *log.Entry) {
 }
 
 func (ctx *lsc) WithField(key string, value interface{}) types.LogEntry {
-	return &entry{Entry: ctx.logger.WithField(key, value), ctx: ctx}
+	return &entry{
+		Entry: ctx.logger.WithField(key, types.RedactLogField(key, value)),
+		ctx:   ctx,
+	}
 }
 func (ctx *lsc) WithFields(fields log.Fields) types.LogEntry {
-	return &entry{Entry: ctx.logger.WithFields(fields), ctx: ctx}
+	return &entry{
+		Entry: ctx.logger.WithFields(types.RedactLogFields(fields)),
+		ctx:   ctx,
+	}
 }
 func (ctx *lsc) WithError(err error) types.LogEntry {
 	return &entry{Entry: ctx.logger.WithError(err), ctx: ctx}
@@ -352,11 +358,17 @@ func (e *entry) Panicln(args ...interface{}) {
 }
 
 func (e *entry) WithField(key string, value interface{}) types.LogEntry {
-	return &entry{Entry: e.Entry.WithField(key, value), ctx: e.ctx}
+	return &entry{
+		Entry: e.Entry.WithField(key, types.RedactLogField(key, value)),
+		ctx:   e.ctx,
+	}
 }
 
 func (e *entry) WithFields(fields log.Fields) types.LogEntry {
-	return &entry{Entry: e.Entry.WithFields(fields), ctx: e.ctx}
+	return &entry{
+		Entry: e.Entry.WithFields(types.RedactLogFields(fields)),
+		ctx:   e.ctx,
+	}
 }
 
 func (e *entry) WithError(err error) types.LogEntry {
@@ -87,6 +87,42 @@ func TestJoin(t *testing.T) {
 	assert.Equal(t, serviceName, v)
 }
 
+func TestWithCancel(t *testing.T) {
+
+	parent, cancelParent := WithCancel(
+		Background().WithValue(ServerKey, serverName))
+	ctx, cancel := WithCancel(parent)
+	defer cancel()
+
+	v, ok := Server(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, serverName, v)
+	assert.NoError(t, ctx.Err())
+
+	cancelParent()
+	<-ctx.Done()
+	assert.Error(t, ctx.Err())
+}
+
+func TestWithDetachedCancel(t *testing.T) {
+
+	parent, cancelParent := WithCancel(
+		Background().WithValue(ServerKey, serverName))
+	ctx, cancel := WithDetachedCancel(parent)
+
+	v, ok := Server(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, serverName, v)
+
+	cancelParent()
+	<-parent.Done()
+	assert.NoError(t, ctx.Err())
+
+	cancel()
+	<-ctx.Done()
+	assert.Error(t, ctx.Err())
+}
+
 type driver struct {
 }
 
@@ -241,6 +241,19 @@ func (d *idm) Remove(
 	return d.IntegrationDriver.Remove(ctx.Join(d.ctx), volumeName, opts)
 }
 
+func (d *idm) Resize(
+	ctx types.Context,
+	volumeName string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	fields := log.Fields{
+		"volumeName": volumeName,
+		"opts":       opts}
+	ctx.WithFields(fields).Debug("resizing volume")
+
+	return d.IntegrationDriver.Resize(ctx.Join(d.ctx), volumeName, opts)
+}
+
 func (d *idm) Attach(
 	ctx types.Context,
 	volumeName string,
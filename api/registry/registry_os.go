@@ -63,3 +63,16 @@ func (d *odm) Format(
 	}
 	return d.OSDriver.Format(ctx, deviceName, opts)
 }
+
+func (d *odm) Resize(
+	ctx types.Context,
+	deviceName string,
+	opts *types.DeviceResizeOpts) error {
+
+	ctx = ctx.Join(d.Context)
+
+	if strings.Contains(deviceName, ":") {
+		return nil
+	}
+	return d.OSDriver.Resize(ctx, deviceName, opts)
+}
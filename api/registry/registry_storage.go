@@ -1,12 +1,42 @@
 package registry
 
 import (
+	"time"
+
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/metrics"
+)
+
+var (
+	driverCalls = metrics.NewCounter(
+		"libstorage_driver_calls_total",
+		"The number of calls to the storage drivers' methods.",
+		"service", "driver", "method")
+
+	driverErrors = metrics.NewCounter(
+		"libstorage_driver_errors_total",
+		"The number of calls to the storage drivers' methods that failed.",
+		"service", "driver", "method")
+
+	driverDuration = metrics.NewHistogram(
+		"libstorage_driver_call_duration_seconds",
+		"The latency of the calls to the storage drivers' methods.",
+		nil,
+		"service", "driver", "method")
 )
 
 type sdm struct {
 	types.StorageDriver
 	types.Context
+
+	// calls is a semaphore that caps the number of the driver's calls that
+	// are in flight. It is nil if the calls are not capped.
+	calls    chan struct{}
+	callWait time.Duration
 }
 
 // NewStorageDriverManager returns a new storage driver manager.
@@ -15,6 +45,69 @@ func NewStorageDriverManager(
 	return &sdm{StorageDriver: d}
 }
 
+// Init initializes the driver after reading the maximum number of the
+// driver's calls that may be in flight, which may be specified beneath a
+// storage service's config scope.
+func (d *sdm) Init(ctx types.Context, config gofig.Config) error {
+	if max := config.GetInt(types.ConfigServerDriverCallsMax); max > 0 {
+		d.calls = make(chan struct{}, max)
+		wait, err := time.ParseDuration(
+			config.GetString(types.ConfigServerDriverCallsMaxWait))
+		if err != nil {
+			wait = time.Duration(time.Second * 5)
+		}
+		d.callWait = wait
+	}
+	return d.StorageDriver.Init(ctx, config)
+}
+
+// acquire waits for one of the calls that may be in flight to become
+// available. An error is returned if none becomes available before the
+// maximum wait elapses or the context is done.
+func (d *sdm) acquire(ctx types.Context) error {
+	if d.calls == nil {
+		return nil
+	}
+	select {
+	case d.calls <- struct{}{}:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(d.callWait)
+	defer timer.Stop()
+	select {
+	case d.calls <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		service, _ := context.ServiceName(ctx)
+		return utils.NewTooManyDriverCallsErr(service, cap(d.calls))
+	}
+}
+
+// release makes available a call acquired with acquire.
+func (d *sdm) release() {
+	if d.calls != nil {
+		<-d.calls
+	}
+}
+
+// observe records the latency and outcome of a call to one of the driver's
+// methods.
+func (d *sdm) observe(
+	ctx types.Context, method string, start time.Time, err *error) {
+
+	service, _ := context.ServiceName(ctx)
+	driver := d.StorageDriver.Name()
+
+	driverCalls.Inc(service, driver, method)
+	driverDuration.ObserveSince(start, service, driver, method)
+	if *err != nil {
+		driverErrors.Inc(service, driver, method)
+	}
+}
+
 func (d *sdm) API() types.APIClient {
 	if sd, ok := d.StorageDriver.(types.ProvidesAPIClient); ok {
 		return sd.API()
@@ -29,45 +122,80 @@ func (d *sdm) XCLI() types.StorageExecutorCLI {
 	return nil
 }
 
+// Capabilities returns the driver's capabilities. A nil value is returned
+// if the driver does not describe its capabilities.
+func (d *sdm) Capabilities(
+	ctx types.Context) (caps *types.StorageCapabilities, err error) {
+
+	sd, ok := d.StorageDriver.(types.StorageDriverCapabilities)
+	if !ok {
+		return nil, nil
+	}
+	defer d.observe(ctx, "Capabilities", time.Now(), &err)
+	return sd.Capabilities(ctx.Join(d.Context))
+}
+
 func (d *sdm) NextDeviceInfo(
-	ctx types.Context) (*types.NextDeviceInfo, error) {
+	ctx types.Context) (info *types.NextDeviceInfo, err error) {
 
+	defer d.observe(ctx, "NextDeviceInfo", time.Now(), &err)
 	return d.StorageDriver.NextDeviceInfo(ctx.Join(d.Context))
 }
 
 func (d *sdm) Type(
-	ctx types.Context) (types.StorageType, error) {
+	ctx types.Context) (st types.StorageType, err error) {
 
+	defer d.observe(ctx, "Type", time.Now(), &err)
 	return d.StorageDriver.Type(ctx.Join(d.Context))
 }
 
 func (d *sdm) InstanceInspect(
 	ctx types.Context,
-	opts types.Store) (*types.Instance, error) {
+	opts types.Store) (i *types.Instance, err error) {
 
+	defer d.observe(ctx, "InstanceInspect", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.InstanceInspect(ctx.Join(d.Context), opts)
 }
 
 func (d *sdm) Volumes(
 	ctx types.Context,
-	opts *types.VolumesOpts) ([]*types.Volume, error) {
+	opts *types.VolumesOpts) (vols []*types.Volume, err error) {
 
+	defer d.observe(ctx, "Volumes", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.Volumes(ctx.Join(d.Context), opts)
 }
 
 func (d *sdm) VolumeInspect(
 	ctx types.Context,
 	volumeID string,
-	opts *types.VolumeInspectOpts) (*types.Volume, error) {
+	opts *types.VolumeInspectOpts) (vol *types.Volume, err error) {
 
+	defer d.observe(ctx, "VolumeInspect", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeInspect(ctx.Join(d.Context), volumeID, opts)
 }
 
 func (d *sdm) VolumeCreate(
 	ctx types.Context,
 	name string,
-	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+	opts *types.VolumeCreateOpts) (vol *types.Volume, err error) {
 
+	defer d.observe(ctx, "VolumeCreate", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeCreate(ctx.Join(d.Context), name, opts)
 }
 
@@ -75,8 +203,13 @@ func (d *sdm) VolumeCreateFromSnapshot(
 	ctx types.Context,
 	snapshotID,
 	volumeName string,
-	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+	opts *types.VolumeCreateOpts) (vol *types.Volume, err error) {
 
+	defer d.observe(ctx, "VolumeCreateFromSnapshot", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeCreateFromSnapshot(
 		ctx.Join(d.Context), snapshotID, volumeName, opts)
 }
@@ -85,18 +218,42 @@ func (d *sdm) VolumeCopy(
 	ctx types.Context,
 	volumeID,
 	volumeName string,
-	opts types.Store) (*types.Volume, error) {
+	opts types.Store) (vol *types.Volume, err error) {
 
+	defer d.observe(ctx, "VolumeCopy", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeCopy(
 		ctx.Join(d.Context), volumeID, volumeName, opts)
 }
 
+func (d *sdm) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (vol *types.Volume, err error) {
+
+	defer d.observe(ctx, "VolumeResize", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
+	return d.StorageDriver.VolumeResize(
+		ctx.Join(d.Context), volumeID, opts)
+}
+
 func (d *sdm) VolumeSnapshot(
 	ctx types.Context,
 	volumeID,
 	snapshotName string,
-	opts types.Store) (*types.Snapshot, error) {
+	opts types.Store) (snap *types.Snapshot, err error) {
 
+	defer d.observe(ctx, "VolumeSnapshot", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeSnapshot(
 		ctx.Join(d.Context), volumeID, snapshotName, opts)
 }
@@ -104,8 +261,13 @@ func (d *sdm) VolumeSnapshot(
 func (d *sdm) VolumeRemove(
 	ctx types.Context,
 	volumeID string,
-	opts types.Store) error {
+	opts types.Store) (err error) {
 
+	defer d.observe(ctx, "VolumeRemove", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeRemove(
 		ctx.Join(d.Context), volumeID, opts)
 }
@@ -113,8 +275,14 @@ func (d *sdm) VolumeRemove(
 func (d *sdm) VolumeAttach(
 	ctx types.Context,
 	volumeID string,
-	opts *types.VolumeAttachOpts) (*types.Volume, string, error) {
+	opts *types.VolumeAttachOpts) (
+	vol *types.Volume, token string, err error) {
 
+	defer d.observe(ctx, "VolumeAttach", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeAttach(
 		ctx.Join(d.Context), volumeID, opts)
 }
@@ -122,24 +290,39 @@ func (d *sdm) VolumeAttach(
 func (d *sdm) VolumeDetach(
 	ctx types.Context,
 	volumeID string,
-	opts *types.VolumeDetachOpts) (*types.Volume, error) {
+	opts *types.VolumeDetachOpts) (vol *types.Volume, err error) {
 
+	defer d.observe(ctx, "VolumeDetach", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.VolumeDetach(
 		ctx.Join(d.Context), volumeID, opts)
 }
 
 func (d *sdm) Snapshots(
 	ctx types.Context,
-	opts types.Store) ([]*types.Snapshot, error) {
+	opts types.Store) (snaps []*types.Snapshot, err error) {
 
+	defer d.observe(ctx, "Snapshots", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.Snapshots(ctx.Join(d.Context), opts)
 }
 
 func (d *sdm) SnapshotInspect(
 	ctx types.Context,
 	snapshotID string,
-	opts types.Store) (*types.Snapshot, error) {
+	opts types.Store) (snap *types.Snapshot, err error) {
 
+	defer d.observe(ctx, "SnapshotInspect", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.SnapshotInspect(
 		ctx.Join(d.Context), snapshotID, opts)
 }
@@ -149,8 +332,13 @@ func (d *sdm) SnapshotCopy(
 	snapshotID,
 	snapshotName,
 	destinationID string,
-	opts types.Store) (*types.Snapshot, error) {
+	opts types.Store) (snap *types.Snapshot, err error) {
 
+	defer d.observe(ctx, "SnapshotCopy", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.SnapshotCopy(
 		ctx.Join(d.Context), snapshotID, snapshotName, destinationID, opts)
 }
@@ -158,7 +346,12 @@ func (d *sdm) SnapshotCopy(
 func (d *sdm) SnapshotRemove(
 	ctx types.Context,
 	snapshotID string,
-	opts types.Store) error {
+	opts types.Store) (err error) {
 
+	defer d.observe(ctx, "SnapshotRemove", time.Now(), &err)
+	if err = d.acquire(ctx); err != nil {
+		return
+	}
+	defer d.release()
 	return d.StorageDriver.SnapshotRemove(ctx.Join(d.Context), snapshotID, opts)
 }
@@ -0,0 +1,267 @@
+/*
+Package audit provides the audit log of the operations that mutate the
+storage services' volumes and snapshots.
+
+The records are appended to a dedicated file, one JSON object per line, that
+is separate from the server's log. The file is rotated once it reaches its
+maximum size, and a configured number of rotated files are retained.
+*/
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// Operations are the names of the routes whose requests are audited.
+var Operations = map[string]bool{
+	"volumeCreate":            true,
+	"volumeCopy":              true,
+	"volumeResize":            true,
+	"volumeSnapshot":          true,
+	"volumeAttach":            true,
+	"volumeDetach":            true,
+	"volumesDetachForService": true,
+	"volumesDetachAll":        true,
+	"volumeRemove":            true,
+	"snapshotCreate":          true,
+	"snapshotCopy":            true,
+	"snapshotRemove":          true,
+}
+
+// Config is the configuration of the audit log.
+type Config struct {
+
+	// File is the path of the file to which the records are written.
+	File string
+
+	// MaxSize is the size, in bytes, at which the file is rotated.
+	MaxSize int64
+
+	// MaxFiles is the number of rotated files that are retained.
+	MaxFiles int
+}
+
+// ParseConfig parses the configuration of the audit log from the
+// libstorage.server.audit section of the server's configuration. A nil value
+// is returned if the audit log is not enabled.
+func ParseConfig(config gofig.Config) (*Config, error) {
+	if !config.GetBool(types.ConfigServerAuditEnabled) {
+		return nil, nil
+	}
+
+	c := &Config{
+		File:     config.GetString(types.ConfigServerAuditFile),
+		MaxSize:  int64(config.GetInt(types.ConfigServerAuditMaxSize)),
+		MaxFiles: config.GetInt(types.ConfigServerAuditMaxFiles),
+	}
+	if c.File == "" {
+		c.File = types.Log.Join("audit.log")
+	}
+	if c.MaxSize < 0 || c.MaxFiles < 0 {
+		return nil, goof.WithFields(goof.Fields{
+			"maxSize":  c.MaxSize,
+			"maxFiles": c.MaxFiles,
+		}, "invalid audit log rotation")
+	}
+	c.MaxSize = c.MaxSize * 1024 * 1024
+	return c, nil
+}
+
+// Query selects the records returned by Log.Query.
+type Query struct {
+
+	// Start selects the records of the operations requested at or after the
+	// time. The zero value does not restrict the records.
+	Start time.Time
+
+	// End selects the records of the operations requested before the time.
+	// The zero value does not restrict the records.
+	End time.Time
+
+	// VolumeID selects the records of the operations on the volume.
+	VolumeID string
+}
+
+func (q *Query) matches(r *types.AuditRecord) bool {
+	if !q.Start.IsZero() && r.Time.Before(q.Start) {
+		return false
+	}
+	if !q.End.IsZero() && !r.Time.Before(q.End) {
+		return false
+	}
+	if q.VolumeID != "" && !strings.EqualFold(q.VolumeID, r.VolumeID) {
+		return false
+	}
+	return true
+}
+
+// Log is an append-only log of audit records.
+type Log struct {
+	sync.Mutex
+	config *Config
+	f      *os.File
+	size   int64
+}
+
+// New opens the audit log described by the provided config.
+func New(config *Config) (*Log, error) {
+	l := &Log{config: config}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Write appends the record to the log.
+func (l *Log) Write(r *types.AuditRecord) error {
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.f == nil {
+		return goof.WithField("file", l.config.File, "audit log closed")
+	}
+
+	if l.config.MaxSize > 0 && l.size > 0 &&
+		l.size+int64(len(buf)) > l.config.MaxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.f.Write(buf)
+	l.size += int64(n)
+	return err
+}
+
+// Query returns the records selected by the query, oldest first. The
+// rotated files are read as well as the current file.
+func (l *Log) Query(q *Query) ([]*types.AuditRecord, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	records := []*types.AuditRecord{}
+	for i := l.config.MaxFiles; i >= 0; i-- {
+		if err := l.read(l.name(i), q, &records); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// Close closes the log's file.
+func (l *Log) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+func (l *Log) open() error {
+	if err := os.MkdirAll(filepath.Dir(l.config.File), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(
+		l.config.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return goof.WithFieldE(
+			"file", l.config.File, "error opening audit log", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.f = f
+	l.size = fi.Size()
+	return nil
+}
+
+// rotate renames the current file to the first of the rotated files and
+// opens a new file. The oldest rotated file is removed if the maximum number
+// of rotated files are retained.
+func (l *Log) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	l.f = nil
+
+	if l.config.MaxFiles == 0 {
+		if err := os.Remove(l.config.File); err != nil {
+			return err
+		}
+		return l.open()
+	}
+
+	os.Remove(l.name(l.config.MaxFiles))
+	for i := l.config.MaxFiles - 1; i >= 0; i-- {
+		if err := os.Rename(l.name(i), l.name(i+1)); err != nil &&
+			!os.IsNotExist(err) {
+			return err
+		}
+	}
+	return l.open()
+}
+
+// name returns the name of the file with the provided index. The current
+// file's index is 0, and the rotated files' indices are 1 through MaxFiles,
+// newest first.
+func (l *Log) name(i int) string {
+	if i == 0 {
+		return l.config.File
+	}
+	return fmt.Sprintf("%s.%d", l.config.File, i)
+}
+
+func (l *Log) read(
+	name string, q *Query, records *[]*types.AuditRecord) error {
+
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rdr := bufio.NewReader(f)
+	for {
+		line, err := rdr.ReadBytes('\n')
+		if len(line) > 0 {
+			r := &types.AuditRecord{}
+			if json.Unmarshal(line, r) == nil && q.matches(r) {
+				*records = append(*records, r)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+func newTestLog(t *testing.T, maxSize int64) (*Log, func()) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	l, err := New(&Config{
+		File:     filepath.Join(dir, "audit", "audit.log"),
+		MaxSize:  maxSize,
+		MaxFiles: 2,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return l, func() {
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	l, cleanup := newTestLog(t, 0)
+	defer cleanup()
+
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"vol-000", "vol-001", "vol-000"} {
+		assert.NoError(t, l.Write(&types.AuditRecord{
+			Time:      start.Add(time.Duration(i) * time.Hour),
+			Operation: "volumeAttach",
+			VolumeID:  id,
+			Outcome:   types.AuditOutcomeSuccess,
+			Body:      map[string]interface{}{"force": true},
+		}))
+	}
+
+	records, err := l.Query(&Query{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, map[string]interface{}{"force": true}, records[0].Body)
+
+	records, err = l.Query(&Query{VolumeID: "VOL-000"})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	// the start of the range is inclusive and the end is exclusive
+	records, err = l.Query(&Query{
+		Start: start.Add(time.Hour),
+		End:   start.Add(2 * time.Hour),
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "vol-001", records[0].VolumeID)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	l, cleanup := newTestLog(t, 200)
+	defer cleanup()
+
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, l.Write(&types.AuditRecord{
+			Time:      start.Add(time.Duration(i) * time.Minute),
+			Operation: "volumeRemove",
+			VolumeID:  "vol-000",
+		}))
+	}
+
+	_, err := os.Stat(l.config.File + ".2")
+	assert.NoError(t, err)
+	_, err = os.Stat(l.config.File + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	// the records in the retained files are returned oldest first
+	records, err := l.Query(&Query{})
+	assert.NoError(t, err)
+	assert.True(t, len(records) > 0 && len(records) < 10)
+	for i := 1; i < len(records); i++ {
+		assert.True(t, records[i-1].Time.Before(records[i].Time))
+	}
+	assert.Equal(t, start.Add(9*time.Minute), records[len(records)-1].Time)
+}
@@ -0,0 +1,203 @@
+/*
+Package auth authenticates the principals on whose behalf requests are made
+to the server and resolves the rules that determine what they are allowed to
+do.
+
+A request is authenticated with a bearer token in its Authorization header.
+The token is either the server's admin token, a static token configured for a
+principal, or a JWT signed with a configured key. Additional methods may be
+supported by adding an Authenticator to a Config. A request without a bearer
+token may instead be authenticated by the verified certificate with which the
+client established its TLS connection or, for a request made over a UNIX
+socket, by the credentials of the process that made it.
+*/
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+const (
+	// MethodAdmin is the method of the principal authenticated by the
+	// server's admin token.
+	MethodAdmin = "admin"
+
+	// MethodToken is the method of the principals authenticated by static
+	// tokens.
+	MethodToken = "token"
+
+	// MethodJWT is the method of the principals authenticated by JWTs.
+	MethodJWT = "jwt"
+
+	// MethodCert is the method of the principals authenticated by client
+	// certificates.
+	MethodCert = "certificate"
+
+	// MethodPeer is the method of the principals authenticated by the
+	// credentials of the processes on the other end of UNIX sockets.
+	MethodPeer = "peer"
+
+	// AdminPrincipal is the name of the principal authenticated by the
+	// server's admin token.
+	AdminPrincipal = "admin"
+)
+
+// Authenticator authenticates bearer tokens.
+type Authenticator interface {
+
+	// Authenticate returns the principal identified by the token. A nil
+	// principal and a nil error are returned if the token is not one the
+	// authenticator recognizes, and an error is returned if it is one that
+	// the authenticator recognizes but rejects.
+	Authenticate(ctx types.Context, token string) (*types.Principal, error)
+}
+
+// Config is the configuration of the server's authentication and
+// authorization.
+type Config struct {
+
+	// Authenticators are the authenticators consulted, in order, for a
+	// token that is not the admin token.
+	Authenticators []Authenticator
+
+	// Principals are the rules of the principals, by name. The rules of the
+	// wildcard principal, "*", apply to every authenticated principal.
+	Principals map[string][]*types.AuthRule
+
+	// AnonymousRoutes are the names of the routes that do not require
+	// authentication.
+	AnonymousRoutes []string
+
+	// Certificates are the rules of the client certificates, by identity.
+	// The rule of the wildcard identity, "*", applies to every certificate
+	// that does not match another rule.
+	Certificates map[string]*CertRule
+
+	// Peers are the rules, in order, of the processes that make requests
+	// over UNIX sockets.
+	Peers []*PeerRule
+}
+
+// Authenticate returns the principal identified by the token along with its
+// rules. The server's admin token identifies the admin principal, which is
+// allowed to do everything.
+func (c *Config) Authenticate(
+	ctx types.Context, token string) (*types.Principal, error) {
+
+	if adminToken, ok := ctx.Value(context.AdminTokenKey).(string); ok &&
+		subtle.ConstantTimeCompare([]byte(adminToken), []byte(token)) == 1 {
+		return &types.Principal{
+			Name:   AdminPrincipal,
+			Method: MethodAdmin,
+			Rules: []*types.AuthRule{&types.AuthRule{
+				Services: []string{types.AuthWildcard},
+				Verbs:    []types.AuthVerb{types.AuthWildcard},
+			}},
+		}, nil
+	}
+
+	for _, a := range c.Authenticators {
+		p, err := a.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			continue
+		}
+		return c.withRules(p), nil
+	}
+
+	return nil, utils.NewUnauthenticatedErr("invalid token")
+}
+
+// withRules appends to the principal's rules those configured for the
+// principal and for the wildcard principal.
+func (c *Config) withRules(p *types.Principal) *types.Principal {
+	p.Rules = append(p.Rules, c.Principals[p.Name]...)
+	if p.Name != types.AuthWildcard {
+		p.Rules = append(p.Rules, c.Principals[types.AuthWildcard]...)
+	}
+	return p
+}
+
+// IsAnonymous returns a flag indicating whether or not the route with the
+// provided name allows anonymous requests.
+func (c *Config) IsAnonymous(routeName string) bool {
+	for _, r := range c.AnonymousRoutes {
+		if strings.EqualFold(r, routeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// BearerToken returns the bearer token from the request's Authorization
+// header. An empty string is returned if the request does not have one.
+func BearerToken(req *http.Request) string {
+	h := req.Header.Get(types.AuthorizationHeader)
+	parts := strings.SplitN(h, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// AuthorizeAdmin returns an error unless the request includes the server's
+// admin token or one of the other provided tokens. The token is read from the
+// Authorization header as a bearer token or from the admin query parameter,
+// and it is compared to the expected tokens in constant time.
+func AuthorizeAdmin(
+	ctx types.Context,
+	req *http.Request,
+	store types.Store,
+	tokens ...string) error {
+
+	actualToken := BearerToken(req)
+	if actualToken == "" {
+		actualToken = store.GetString("admin")
+	}
+	if actualToken == "" {
+		return utils.NewBadAdminTokenError("missing")
+	}
+
+	if adminToken, ok := ctx.Value(context.AdminTokenKey).(string); ok {
+		tokens = append(tokens, adminToken)
+	}
+	for _, t := range tokens {
+		if t != "" &&
+			subtle.ConstantTimeCompare([]byte(t), []byte(actualToken)) == 1 {
+			return nil
+		}
+	}
+
+	return utils.NewBadAdminTokenError(actualToken)
+}
+
+// tokenAuthenticator authenticates static tokens.
+type tokenAuthenticator struct {
+	tokens map[string]string
+}
+
+// NewTokenAuthenticator returns an authenticator for static tokens. The
+// provided map's keys are the names of the principals and its values are
+// the principals' tokens.
+func NewTokenAuthenticator(tokens map[string]string) Authenticator {
+	return &tokenAuthenticator{tokens: tokens}
+}
+
+func (a *tokenAuthenticator) Authenticate(
+	ctx types.Context, token string) (*types.Principal, error) {
+
+	for name, t := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return &types.Principal{Name: name, Method: MethodToken}, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// CertRule maps the identity of a client certificate to a principal.
+type CertRule struct {
+
+	// Principal is the name of the principal the certificate identifies.
+	// The principal is named after the certificate's identity if the name
+	// is empty.
+	Principal string
+
+	// InstanceID is the ID of the only instance on whose behalf the
+	// certificate may be used to make requests.
+	InstanceID string
+
+	// Rules are the rules of the principal in addition to those configured
+	// for the principal by name.
+	Rules []*types.AuthRule
+}
+
+// ClientCert returns the verified certificate with which the client
+// established the TLS connection. A nil value is returned if the connection
+// is not a TLS connection or the client did not present a verified
+// certificate.
+func ClientCert(state *tls.ConnectionState) *x509.Certificate {
+	if state == nil ||
+		len(state.VerifiedChains) == 0 ||
+		len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
+
+// CertIdentities returns the identities of a certificate: its subject's
+// common name followed by its DNS names, email addresses, and IP addresses.
+func CertIdentities(cert *x509.Certificate) []string {
+	ids := []string{}
+	if cert.Subject.CommonName != "" {
+		ids = append(ids, cert.Subject.CommonName)
+	}
+	ids = append(ids, cert.DNSNames...)
+	ids = append(ids, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		ids = append(ids, ip.String())
+	}
+	return ids
+}
+
+// AuthenticateCert returns the principal identified by a verified client
+// certificate along with its rules. The certificate's identities are matched
+// in order against the configured rules, and the first match determines the
+// principal.
+func (c *Config) AuthenticateCert(
+	ctx types.Context, cert *x509.Certificate) (*types.Principal, error) {
+
+	ids := CertIdentities(cert)
+	if len(ids) == 0 {
+		return nil, utils.NewUnauthenticatedErr("certificate has no identity")
+	}
+
+	id, rule := c.certRule(ids)
+	if rule == nil {
+		return nil, utils.NewUnauthenticatedErr("unknown certificate")
+	}
+
+	p := &types.Principal{
+		Name:       rule.Principal,
+		Method:     MethodCert,
+		InstanceID: rule.InstanceID,
+		Rules:      append([]*types.AuthRule{}, rule.Rules...),
+		Claims: map[string]interface{}{
+			"subject":    cert.Subject.CommonName,
+			"identities": ids,
+		},
+	}
+	if p.Name == "" {
+		p.Name = id
+	}
+
+	return c.withRules(p), nil
+}
+
+// certRule returns the first of the identities that matches a rule along
+// with the rule. The first identity is returned with the wildcard rule if no
+// identity matches another rule.
+func (c *Config) certRule(ids []string) (string, *CertRule) {
+	for _, id := range ids {
+		for k, r := range c.Certificates {
+			if k != types.AuthWildcard && strings.EqualFold(k, id) {
+				return id, r
+			}
+		}
+	}
+	if r, ok := c.Certificates[types.AuthWildcard]; ok {
+		return ids[0], r
+	}
+	return "", nil
+}
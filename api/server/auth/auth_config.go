@@ -0,0 +1,283 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// ParseConfig parses the configuration of the server's authentication and
+// authorization from the libstorage.server.auth section of the server's
+// configuration. A nil value is returned if neither tokens, JWT keys,
+// certificates, nor peers are configured, in which case authentication is
+// disabled.
+func ParseConfig(config gofig.Config) (*Config, error) {
+
+	c := &Config{
+		AnonymousRoutes: toStrings(
+			config.Get(types.ConfigServerAuthAnonymousRoutes)),
+	}
+
+	tokens, err := toStringMap(
+		config.Get(types.ConfigServerAuthTokens),
+		types.ConfigServerAuthTokens)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		t := map[string]string{}
+		for name, v := range tokens {
+			s, ok := v.(string)
+			if !ok || s == "" {
+				return nil, goof.WithFields(goof.Fields{
+					"configKey": types.ConfigServerAuthTokens,
+					"principal": name,
+				}, "invalid token")
+			}
+			t[name] = s
+		}
+		c.Authenticators = append(c.Authenticators, NewTokenAuthenticator(t))
+	}
+
+	jwtConfig, err := parseJWTConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if jwtConfig != nil {
+		c.Authenticators = append(
+			c.Authenticators, NewJWTAuthenticator(jwtConfig))
+	}
+
+	if c.Certificates, err = parseCertificates(
+		config.Get(types.ConfigServerAuthCertificates)); err != nil {
+		return nil, err
+	}
+
+	if c.Peers, err = parsePeers(
+		config.Get(types.ConfigServerAuthPeers)); err != nil {
+		return nil, err
+	}
+
+	if len(c.Authenticators) == 0 &&
+		len(c.Certificates) == 0 &&
+		len(c.Peers) == 0 {
+		return nil, nil
+	}
+
+	if c.Principals, err = parsePrincipals(
+		config.Get(types.ConfigServerAuthPrincipals)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parseJWTConfig(config gofig.Config) (*JWTConfig, error) {
+
+	c := &JWTConfig{
+		Key:      []byte(config.GetString(types.ConfigServerAuthJWTKey)),
+		Issuer:   config.GetString(types.ConfigServerAuthJWTIssuer),
+		Audience: config.GetString(types.ConfigServerAuthJWTAudience),
+		PrincipalClaim: config.GetString(
+			types.ConfigServerAuthJWTPrincipalClaim),
+	}
+	if c.PrincipalClaim == "" {
+		c.PrincipalClaim = "sub"
+	}
+
+	if v := config.GetString(types.ConfigServerAuthJWTPublicKey); v != "" {
+		pk, err := utils.ParseRSAPublicKey(v)
+		if err != nil {
+			return nil, goof.WithFieldE(
+				"configKey", types.ConfigServerAuthJWTPublicKey,
+				"invalid public key", err)
+		}
+		c.PublicKey = pk
+	}
+
+	if len(c.Key) == 0 && c.PublicKey == nil {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// parsePrincipals parses the principals' rules. Each principal's value is
+// either a rule or a list of rules, and each rule is a map with the keys
+// services and verbs.
+func parsePrincipals(v interface{}) (map[string][]*types.AuthRule, error) {
+
+	m, err := toStringMap(v, types.ConfigServerAuthPrincipals)
+	if err != nil {
+		return nil, err
+	}
+
+	principals := map[string][]*types.AuthRule{}
+	for name, pv := range m {
+		var list []interface{}
+		if l, ok := pv.([]interface{}); ok {
+			list = l
+		} else {
+			list = []interface{}{pv}
+		}
+		for _, rv := range list {
+			rm, err := toStringMap(rv, types.ConfigServerAuthPrincipals)
+			if err != nil {
+				return nil, err
+			}
+			r := &types.AuthRule{Services: toStrings(rm["services"])}
+			for _, verb := range toStrings(rm["verbs"]) {
+				r.Verbs = append(r.Verbs, types.AuthVerb(verb))
+			}
+			principals[name] = append(principals[name], r)
+		}
+	}
+	return principals, nil
+}
+
+// parseCertificates parses the rules of the client certificates. Each
+// identity's value is a map with the optional keys principal, instanceID,
+// services, and verbs.
+func parseCertificates(v interface{}) (map[string]*CertRule, error) {
+
+	m, err := toStringMap(v, types.ConfigServerAuthCertificates)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := map[string]*CertRule{}
+	for id, cv := range m {
+		r := &CertRule{}
+		if cv != nil {
+			cm, err := toStringMap(cv, types.ConfigServerAuthCertificates)
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := cm["principal"]; ok {
+				r.Principal = fmt.Sprintf("%v", v)
+			}
+			if v, ok := cm["instanceID"]; ok {
+				r.InstanceID = fmt.Sprintf("%v", v)
+			}
+			if ar := parseRule(cm); ar != nil {
+				r.Rules = append(r.Rules, ar)
+			}
+		}
+		certs[id] = r
+	}
+	return certs, nil
+}
+
+// parsePeers parses the rules of the processes that make requests over UNIX
+// sockets. The value is a list of maps with the optional keys principal,
+// users, groups, services, and verbs. Users and groups are either names or
+// numeric IDs, and the names are resolved when the config is parsed.
+func parsePeers(v interface{}) ([]*PeerRule, error) {
+
+	if v == nil {
+		return nil, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, goof.WithField(
+			"configKey", types.ConfigServerAuthPeers, "invalid format")
+	}
+
+	peers := []*PeerRule{}
+	for _, pv := range list {
+		pm, err := toStringMap(pv, types.ConfigServerAuthPeers)
+		if err != nil {
+			return nil, err
+		}
+		r := &PeerRule{}
+		if v, ok := pm["principal"]; ok {
+			r.Principal = fmt.Sprintf("%v", v)
+		}
+		for _, name := range toStrings(pm["users"]) {
+			uid, err := utils.LookupUID(name)
+			if err != nil {
+				return nil, goof.WithFieldE(
+					"configKey", types.ConfigServerAuthPeers,
+					"invalid user", err)
+			}
+			r.UIDs = append(r.UIDs, uid)
+		}
+		for _, name := range toStrings(pm["groups"]) {
+			gid, members, err := utils.LookupGroup(name)
+			if err != nil {
+				return nil, goof.WithFieldE(
+					"configKey", types.ConfigServerAuthPeers,
+					"invalid group", err)
+			}
+			r.GIDs = append(r.GIDs, gid)
+			for _, member := range members {
+				if uid, err := utils.LookupUID(member); err == nil {
+					r.MemberUIDs = append(r.MemberUIDs, uid)
+				}
+			}
+		}
+		if ar := parseRule(pm); ar != nil {
+			r.Rules = append(r.Rules, ar)
+		}
+		peers = append(peers, r)
+	}
+	return peers, nil
+}
+
+// parseRule returns a rule from a map with the keys services and verbs. A
+// nil value is returned if the map has no services.
+func parseRule(m map[string]interface{}) *types.AuthRule {
+	services := toStrings(m["services"])
+	if len(services) == 0 {
+		return nil
+	}
+	r := &types.AuthRule{Services: services}
+	for _, verb := range toStrings(m["verbs"]) {
+		r.Verbs = append(r.Verbs, types.AuthVerb(verb))
+	}
+	return r
+}
+
+func toStringMap(v interface{}, key string) (map[string]interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return tv, nil
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range tv {
+			m[fmt.Sprintf("%v", k)] = v
+		}
+		return m, nil
+	}
+	return nil, goof.WithField("configKey", key, "invalid format")
+}
+
+// toStrings returns a list of strings from a list or a string of
+// comma-separated values.
+func toStrings(v interface{}) []string {
+	switch tv := v.(type) {
+	case string:
+		s := []string{}
+		for _, p := range strings.Split(tv, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				s = append(s, p)
+			}
+		}
+		return s
+	case []string:
+		return tv
+	case []interface{}:
+		s := []string{}
+		for _, iv := range tv {
+			s = append(s, fmt.Sprintf("%v", iv))
+		}
+		return s
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+// JWTConfig is the configuration of a JWT authenticator.
+type JWTConfig struct {
+
+	// Key is the secret with which HS256 tokens are verified. HS256 tokens
+	// are rejected if the key is empty.
+	Key []byte
+
+	// PublicKey is the key with which RS256 tokens are verified. RS256
+	// tokens are rejected if the key is nil.
+	PublicKey *rsa.PublicKey
+
+	// Issuer is the required value of the iss claim, if set.
+	Issuer string
+
+	// Audience is the value the aud claim is required to contain, if set.
+	Audience string
+
+	// PrincipalClaim is the name of the claim whose value is the name of
+	// the principal.
+	PrincipalClaim string
+}
+
+type jwtAuthenticator struct {
+	config *JWTConfig
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// NewJWTAuthenticator returns an authenticator for JWTs signed with either
+// HS256 or RS256.
+func NewJWTAuthenticator(config *JWTConfig) Authenticator {
+	return &jwtAuthenticator{config: config}
+}
+
+func (a *jwtAuthenticator) Authenticate(
+	ctx types.Context, token string) (*types.Principal, error) {
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, utils.NewUnauthenticatedErr("invalid jwt header")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, utils.NewUnauthenticatedErr("invalid jwt signature")
+	}
+	if err := a.verify(header.Alg, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	claims := map[string]interface{}{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, utils.NewUnauthenticatedErr("invalid jwt claims")
+	}
+	if err := a.validate(claims); err != nil {
+		return nil, err
+	}
+
+	name, _ := claims[a.config.PrincipalClaim].(string)
+	if name == "" {
+		return nil, utils.NewUnauthenticatedErr("missing jwt principal claim")
+	}
+
+	return &types.Principal{
+		Name:   name,
+		Method: MethodJWT,
+		Claims: claims,
+	}, nil
+}
+
+func (a *jwtAuthenticator) verify(alg, signed string, sig []byte) error {
+	switch alg {
+	case algHS256:
+		if len(a.config.Key) == 0 {
+			break
+		}
+		mac := hmac.New(sha256.New, a.config.Key)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return utils.NewUnauthenticatedErr("invalid jwt signature")
+		}
+		return nil
+	case algRS256:
+		if a.config.PublicKey == nil {
+			break
+		}
+		sum := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(
+			a.config.PublicKey, crypto.SHA256, sum[:], sig); err != nil {
+			return utils.NewUnauthenticatedErr("invalid jwt signature")
+		}
+		return nil
+	}
+	return utils.NewUnauthenticatedErr("unsupported jwt algorithm")
+}
+
+func (a *jwtAuthenticator) validate(claims map[string]interface{}) error {
+
+	now := float64(time.Now().Unix())
+
+	if exp, ok := claims["exp"].(float64); ok && now >= exp {
+		return utils.NewUnauthenticatedErr("jwt expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now < nbf {
+		return utils.NewUnauthenticatedErr("jwt not yet valid")
+	}
+
+	if a.config.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != a.config.Issuer {
+			return utils.NewUnauthenticatedErr("invalid jwt issuer")
+		}
+	}
+
+	if a.config.Audience != "" {
+		ok := false
+		switch aud := claims["aud"].(type) {
+		case string:
+			ok = aud == a.config.Audience
+		case []interface{}:
+			for _, v := range aud {
+				if s, _ := v.(string); s == a.config.Audience {
+					ok = true
+					break
+				}
+			}
+		}
+		if !ok {
+			return utils.NewUnauthenticatedErr("invalid jwt audience")
+		}
+	}
+
+	return nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	buf, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, v)
+}
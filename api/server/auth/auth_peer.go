@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// PeerRule maps the credentials of the process on the other end of a UNIX
+// socket connection to a principal. A rule without users or groups matches
+// any process.
+type PeerRule struct {
+
+	// Principal is the name of the principal the process is. The principal
+	// is named after the process's user ID if the name is empty.
+	Principal string
+
+	// UIDs are the IDs of the users whose processes the rule matches.
+	UIDs []int
+
+	// GIDs are the IDs of the groups whose processes the rule matches. A
+	// process matches a group if it is the process's group or if the
+	// process's user is one of the group's members.
+	GIDs []int
+
+	// MemberUIDs are the IDs of the users who are members of the groups.
+	MemberUIDs []int
+
+	// Rules are the rules of the principal in addition to those configured
+	// for the principal by name.
+	Rules []*types.AuthRule
+}
+
+// Matches returns a flag indicating whether or not the rule matches the
+// process with the provided credentials.
+func (r *PeerRule) Matches(pc *types.PeerCredentials) bool {
+	if len(r.UIDs) == 0 && len(r.GIDs) == 0 {
+		return true
+	}
+	return containsInt(r.UIDs, pc.UID) ||
+		containsInt(r.GIDs, pc.GID) ||
+		containsInt(r.MemberUIDs, pc.UID)
+}
+
+// AuthenticatePeer returns the principal identified by the credentials of
+// the process that made a request over a UNIX socket along with its rules.
+// The first of the configured rules that matches the process determines the
+// principal.
+func (c *Config) AuthenticatePeer(
+	ctx types.Context, pc *types.PeerCredentials) (*types.Principal, error) {
+
+	for _, r := range c.Peers {
+		if !r.Matches(pc) {
+			continue
+		}
+		p := &types.Principal{
+			Name:   r.Principal,
+			Method: MethodPeer,
+			Rules:  append([]*types.AuthRule{}, r.Rules...),
+			Claims: map[string]interface{}{
+				"pid": pc.PID,
+				"uid": pc.UID,
+				"gid": pc.GID,
+			},
+		}
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("uid:%d", pc.UID)
+		}
+		return c.withRules(p), nil
+	}
+
+	return nil, utils.NewUnauthenticatedErr("unknown peer")
+}
+
+func containsInt(a []int, v int) bool {
+	for _, i := range a {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,399 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+func newJWT(
+	t *testing.T,
+	alg string,
+	claims map[string]interface{},
+	sign func(signed []byte) []byte) string {
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	assert.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(
+		sign([]byte(signed)))
+}
+
+func hs256(key []byte) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	}
+}
+
+func rs256(t *testing.T, key *rsa.PrivateKey) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		sum := sha256.Sum256(signed)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		assert.NoError(t, err)
+		return sig
+	}
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	ctx := context.Background().WithValue(context.AdminTokenKey, "admintoken")
+	c := &Config{
+		Authenticators: []Authenticator{
+			NewTokenAuthenticator(map[string]string{"alice": "alicetoken"}),
+		},
+		Principals: map[string][]*types.AuthRule{
+			"alice": []*types.AuthRule{&types.AuthRule{
+				Services: []string{"vfs"},
+				Verbs:    []types.AuthVerb{types.AuthVerbRead},
+			}},
+			"*": []*types.AuthRule{&types.AuthRule{
+				Services: []string{"*"},
+				Verbs:    []types.AuthVerb{types.AuthVerbAttach},
+			}},
+		},
+	}
+
+	p, err := c.Authenticate(ctx, "alicetoken")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "alice", p.Name)
+	assert.Equal(t, MethodToken, p.Method)
+	assert.True(t, p.Authorized("vfs", types.AuthVerbRead))
+	assert.True(t, p.Authorized("", types.AuthVerbRead))
+	assert.False(t, p.Authorized("ebs", types.AuthVerbRead))
+	assert.False(t, p.Authorized("vfs", types.AuthVerbRemove))
+	assert.True(t, p.Authorized("ebs", types.AuthVerbAttach))
+
+	p, err = c.Authenticate(ctx, "admintoken")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, AdminPrincipal, p.Name)
+	assert.True(t, p.Authorized("ebs", types.AuthVerbRemove))
+
+	_, err = c.Authenticate(ctx, "badtoken")
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+}
+
+func TestAuthenticateJWTHS256(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("secret")
+	c := &Config{
+		Authenticators: []Authenticator{NewJWTAuthenticator(&JWTConfig{
+			Key:            key,
+			Issuer:         "issuer",
+			Audience:       "libstorage",
+			PrincipalClaim: "sub",
+		})},
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	token := newJWT(t, algHS256, map[string]interface{}{
+		"sub": "bob",
+		"iss": "issuer",
+		"aud": []string{"other", "libstorage"},
+		"exp": exp,
+	}, hs256(key))
+
+	p, err := c.Authenticate(ctx, token)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "bob", p.Name)
+	assert.Equal(t, MethodJWT, p.Method)
+	assert.Equal(t, "issuer", p.Claims["iss"])
+
+	token = newJWT(t, algHS256, map[string]interface{}{
+		"sub": "bob", "iss": "issuer", "aud": "libstorage", "exp": exp,
+	}, hs256([]byte("wrong")))
+	_, err = c.Authenticate(ctx, token)
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+
+	token = newJWT(t, algHS256, map[string]interface{}{
+		"sub": "bob", "iss": "issuer", "aud": "libstorage",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	}, hs256(key))
+	_, err = c.Authenticate(ctx, token)
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+
+	token = newJWT(t, algHS256, map[string]interface{}{
+		"sub": "bob", "iss": "other", "aud": "libstorage", "exp": exp,
+	}, hs256(key))
+	_, err = c.Authenticate(ctx, token)
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+
+	token = newJWT(t, "none", map[string]interface{}{
+		"sub": "bob", "iss": "issuer", "aud": "libstorage", "exp": exp,
+	}, func([]byte) []byte { return nil })
+	_, err = c.Authenticate(ctx, token)
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+}
+
+func TestAuthenticateJWTRS256(t *testing.T) {
+	ctx := context.Background()
+
+	privKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	pubBuf, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	pubKey, err := utils.ParseRSAPublicKey(string(pem.EncodeToMemory(
+		&pem.Block{Type: "PUBLIC KEY", Bytes: pubBuf})))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	c := &Config{
+		Authenticators: []Authenticator{NewJWTAuthenticator(&JWTConfig{
+			PublicKey:      pubKey,
+			PrincipalClaim: "name",
+		})},
+	}
+
+	token := newJWT(t, algRS256, map[string]interface{}{
+		"name": "carol",
+	}, rs256(t, privKey))
+	p, err := c.Authenticate(ctx, token)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "carol", p.Name)
+
+	// an HS256 token is rejected when no HS256 key is configured
+	token = newJWT(t, algHS256, map[string]interface{}{
+		"name": "carol",
+	}, hs256([]byte("")))
+	_, err = c.Authenticate(ctx, token)
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+}
+
+func TestAuthenticateCert(t *testing.T) {
+	buf, err := ioutil.ReadFile("../../../.tls/libstorage-client.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(buf)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, ClientCert(nil))
+	assert.Nil(t, ClientCert(&tls.ConnectionState{}))
+	assert.Equal(t, cert, ClientCert(&tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{cert}},
+	}))
+	assert.Equal(t, []string{"libstorage-client"}, CertIdentities(cert))
+
+	ctx := context.Background()
+	c := &Config{
+		Principals: map[string][]*types.AuthRule{
+			"host1": []*types.AuthRule{&types.AuthRule{
+				Services: []string{"vfs"},
+				Verbs:    []types.AuthVerb{types.AuthVerbRead},
+			}},
+		},
+		Certificates: map[string]*CertRule{
+			"LIBSTORAGE-CLIENT": &CertRule{
+				Principal:  "host1",
+				InstanceID: "i-1234",
+				Rules: []*types.AuthRule{&types.AuthRule{
+					Services: []string{"vfs"},
+					Verbs:    []types.AuthVerb{types.AuthVerbAttach},
+				}},
+			},
+		},
+	}
+
+	p, err := c.AuthenticateCert(ctx, cert)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "host1", p.Name)
+	assert.Equal(t, MethodCert, p.Method)
+	assert.Equal(t, "i-1234", p.InstanceID)
+	assert.Equal(t, "libstorage-client", p.Claims["subject"])
+	assert.True(t, p.Authorized("vfs", types.AuthVerbRead))
+	assert.True(t, p.Authorized("vfs", types.AuthVerbAttach))
+	assert.False(t, p.Authorized("vfs", types.AuthVerbRemove))
+
+	c.Certificates = map[string]*CertRule{"ebs-host": &CertRule{}}
+	_, err = c.AuthenticateCert(ctx, cert)
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+
+	c.Certificates["*"] = &CertRule{}
+	p, err = c.AuthenticateCert(ctx, cert)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "libstorage-client", p.Name)
+	assert.Equal(t, "", p.InstanceID)
+	assert.False(t, p.Authorized("vfs", types.AuthVerbRead))
+}
+
+func TestParseCertificates(t *testing.T) {
+	certs, err := parseCertificates(map[interface{}]interface{}{
+		"host1.example.com": map[interface{}]interface{}{
+			"principal":  "host1",
+			"instanceID": "i-1234",
+			"services":   "vfs",
+			"verbs":      "read,attach",
+		},
+		"*": nil,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, certs, 2)
+	r := certs["host1.example.com"]
+	assert.Equal(t, "host1", r.Principal)
+	assert.Equal(t, "i-1234", r.InstanceID)
+	assert.Len(t, r.Rules, 1)
+	assert.Equal(t, []string{"vfs"}, r.Rules[0].Services)
+	assert.NotNil(t, certs["*"])
+}
+
+func TestAuthenticatePeer(t *testing.T) {
+	c := &Config{
+		Principals: map[string][]*types.AuthRule{
+			"operators": []*types.AuthRule{&types.AuthRule{
+				Services: []string{"*"},
+				Verbs:    []types.AuthVerb{types.AuthWildcard},
+			}},
+		},
+	}
+
+	peers, err := parsePeers([]interface{}{
+		map[interface{}]interface{}{
+			"principal": "operators",
+			"users":     "0",
+			"groups":    []interface{}{"999"},
+		},
+		map[interface{}]interface{}{
+			"services": "*",
+			"verbs":    "read",
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, peers, 2)
+	c.Peers = peers
+
+	ctx := context.Background()
+
+	p, err := c.AuthenticatePeer(
+		ctx, &types.PeerCredentials{PID: 1, UID: 0, GID: 0})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "operators", p.Name)
+	assert.Equal(t, MethodPeer, p.Method)
+	assert.True(t, p.Authorized("vfs", types.AuthVerbRemove))
+
+	p, err = c.AuthenticatePeer(
+		ctx, &types.PeerCredentials{PID: 2, UID: 1000, GID: 999})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "operators", p.Name)
+
+	p, err = c.AuthenticatePeer(
+		ctx, &types.PeerCredentials{PID: 3, UID: 1000, GID: 1000})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "uid:1000", p.Name)
+	assert.Equal(t, 3, p.Claims["pid"])
+	assert.True(t, p.Authorized("vfs", types.AuthVerbRead))
+	assert.False(t, p.Authorized("vfs", types.AuthVerbCreate))
+
+	c.Peers = c.Peers[:1]
+	_, err = c.AuthenticatePeer(
+		ctx, &types.PeerCredentials{PID: 3, UID: 1000, GID: 1000})
+	assert.IsType(t, &types.ErrUnauthenticated{}, err)
+}
+
+func TestParsePrincipals(t *testing.T) {
+	principals, err := parsePrincipals(map[interface{}]interface{}{
+		"alice": map[interface{}]interface{}{
+			"services": []interface{}{"vfs", "ebs"},
+			"verbs":    "read,attach",
+		},
+		"bob": []interface{}{
+			map[string]interface{}{"services": "*", "verbs": "read"},
+			map[string]interface{}{"services": "vfs", "verbs": "*"},
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, principals["alice"], 1)
+	assert.Equal(t, []string{"vfs", "ebs"}, principals["alice"][0].Services)
+	assert.Equal(t,
+		[]types.AuthVerb{types.AuthVerbRead, types.AuthVerbAttach},
+		principals["alice"][0].Verbs)
+	assert.Len(t, principals["bob"], 2)
+}
+
+func TestBearerToken(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/volumes", nil)
+	assert.Equal(t, "", BearerToken(req))
+	req.Header.Set(types.AuthorizationHeader, "Basic abc")
+	assert.Equal(t, "", BearerToken(req))
+	req.Header.Set(types.AuthorizationHeader, "Bearer abc")
+	assert.Equal(t, "abc", BearerToken(req))
+}
+
+func TestAuthorizeAdmin(t *testing.T) {
+	ctx := context.Background().WithValue(context.AdminTokenKey, "admin")
+	req, _ := http.NewRequest("GET", "http://localhost/audit", nil)
+
+	err := AuthorizeAdmin(ctx, req, utils.NewStore())
+	assert.IsType(t, &types.ErrBadAdminToken{}, err)
+
+	req.Header.Set(types.AuthorizationHeader, "Bearer admin")
+	assert.NoError(t, AuthorizeAdmin(ctx, req, utils.NewStore()))
+
+	req.Header.Set(types.AuthorizationHeader, "Bearer metrics")
+	err = AuthorizeAdmin(ctx, req, utils.NewStore())
+	assert.IsType(t, &types.ErrBadAdminToken{}, err)
+	assert.NoError(t, AuthorizeAdmin(ctx, req, utils.NewStore(), "metrics"))
+
+	// the token may also be provided as the admin query parameter
+	req.Header.Del(types.AuthorizationHeader)
+	store := utils.NewStore()
+	store.Set("admin", "admin")
+	assert.NoError(t, AuthorizeAdmin(ctx, req, store))
+
+	// an empty token never matches
+	store.Set("admin", "")
+	err = AuthorizeAdmin(context.Background(), req, store, "")
+	assert.IsType(t, &types.ErrBadAdminToken{}, err)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/audit"
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+)
+
+// auditHandler is a global HTTP filter for recording the requests that
+// mutate the storage services' volumes and snapshots in the audit log.
+type auditHandler struct {
+	handler types.APIFunc
+	log     *audit.Log
+}
+
+// NewAuditHandler returns a new global HTTP filter for recording the requests
+// that mutate the storage services' volumes and snapshots in the audit log.
+// Requests are not recorded if the log is nil. The filter must precede the
+// auth handler so that the requests that fail authentication are recorded,
+// and the principal and instance on whose behalf a request is made are added
+// to the record once they are known.
+func NewAuditHandler(log *audit.Log) types.Middleware {
+	return &auditHandler{log: log}
+}
+
+func (h *auditHandler) Name() string {
+	return "audit-handler"
+}
+
+func (h *auditHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&auditHandler{m, h.log}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *auditHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if h.log == nil {
+		return h.handler(ctx, w, req, store)
+	}
+
+	route, ok := context.Route(ctx)
+	if !ok || !audit.Operations[route.GetName()] {
+		return h.handler(ctx, w, req, store)
+	}
+
+	start := time.Now()
+	r := &types.AuditRecord{
+		Time:       start.UTC(),
+		RemoteAddr: req.RemoteAddr,
+		Service:    store.GetString("service"),
+		Operation:  route.GetName(),
+		VolumeID:   store.GetString("volumeID"),
+		SnapshotID: store.GetString("snapshotID"),
+	}
+	if tx, ok := context.Transaction(ctx); ok && tx.ID != nil {
+		r.TransactionID = tx.ID.String()
+	}
+
+	// the body is restored so that it may be read by the post args handler
+	if req.Body != nil {
+		buf, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(buf))
+		var body interface{}
+		if json.Unmarshal(buf, &body) == nil {
+			r.Body = body
+		}
+	}
+
+	sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+	err := h.handler(ctx.WithValue(context.AuditRecordKey, r), sw, req, store)
+	if err != nil {
+		r.Status = getStatus(err)
+	} else {
+		r.Status = sw.status
+	}
+
+	// an asynchronous task is recorded once it completes
+	if err == nil && r.Status == http.StatusAccepted && r.TaskID > 0 {
+		go func() {
+			<-services.TaskWaitC(ctx, r.TaskID)
+			h.write(ctx, r, start, nil)
+		}()
+		return nil
+	}
+
+	h.write(ctx, r, start, err)
+	return err
+}
+
+// write completes the record with the outcome of the request's task, or of
+// the request itself if it did not create a task, and writes the record to
+// the audit log.
+func (h *auditHandler) write(
+	ctx types.Context, r *types.AuditRecord, start time.Time, err error) {
+
+	var t *types.Task
+	if r.TaskID > 0 {
+		t = services.TaskInspect(ctx, r.TaskID)
+	}
+
+	switch {
+	case t != nil && t.State == types.TaskStateSuccess:
+		r.Outcome = types.AuditOutcomeSuccess
+		setAuditResult(r, t.Result)
+	case t != nil && t.State == types.TaskStateCanceled:
+		r.Outcome = types.AuditOutcomeCanceled
+	case err != nil:
+		r.Outcome = types.AuditOutcomeError
+	case t != nil && t.Error != nil:
+		r.Outcome = types.AuditOutcomeError
+		err = t.Error
+	case r.Status >= http.StatusBadRequest:
+		r.Outcome = types.AuditOutcomeError
+	default:
+		r.Outcome = types.AuditOutcomeSuccess
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	r.Duration = time.Since(start).Seconds()
+
+	if err := h.log.Write(r); err != nil {
+		ctx.WithError(err).Error("error writing audit record")
+	}
+}
+
+// setAuditResult records the IDs of the volume or snapshot an operation
+// created unless the record already has the IDs of the resources on which
+// the operation was performed.
+func setAuditResult(r *types.AuditRecord, result interface{}) {
+	switch tr := result.(type) {
+	case *types.Volume:
+		if tr != nil && r.VolumeID == "" {
+			r.VolumeID = tr.ID
+		}
+	case *types.Snapshot:
+		if tr == nil {
+			return
+		}
+		if r.SnapshotID == "" {
+			r.SnapshotID = tr.ID
+		}
+		if r.VolumeID == "" {
+			r.VolumeID = tr.VolumeID
+		}
+	}
+}
+
+// statusWriter records the status written to a ResponseWriter.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/auth"
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// authHandler is a global HTTP filter for authenticating the principal that
+// makes a request.
+type authHandler struct {
+	handler types.APIFunc
+	config  *auth.Config
+}
+
+// NewAuthHandler returns a new global HTTP filter for authenticating the
+// principal that makes a request. Requests are not authenticated if the
+// config is nil.
+func NewAuthHandler(config *auth.Config) types.Middleware {
+	return &authHandler{config: config}
+}
+
+func (h *authHandler) Name() string {
+	return "auth-handler"
+}
+
+func (h *authHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&authHandler{m, h.config}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *authHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if h.config == nil {
+		return h.handler(ctx, w, req, store)
+	}
+
+	if route, ok := context.Route(ctx); ok &&
+		h.config.IsAnonymous(route.GetName()) {
+		return h.handler(ctx, w, req, store)
+	}
+
+	var (
+		p    *types.Principal
+		err  error
+		cert = auth.ClientCert(req.TLS)
+	)
+
+	if token := auth.BearerToken(req); token != "" {
+		p, err = h.config.Authenticate(ctx, token)
+	} else if cert != nil && len(h.config.Certificates) > 0 {
+		p, err = h.config.AuthenticateCert(ctx, cert)
+	} else if pc, ok := context.PeerCredentials(ctx); ok &&
+		len(h.config.Peers) > 0 {
+		p, err = h.config.AuthenticatePeer(ctx, pc)
+	} else {
+		return utils.NewUnauthenticatedErr("missing bearer token")
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx = ctx.WithValue(context.PrincipalKey, p)
+	ctx = ctx.WithValue(context.UserKey, p.Name)
+	if r, ok := context.AuditRecord(ctx); ok {
+		r.Principal = p.Name
+	}
+	ctx.WithField("method", p.Method).Debug("authenticated principal")
+
+	return h.handler(ctx, w, req, store)
+}
+
+// authorizer is an HTTP filter for authorizing the principal that makes a
+// request to perform a verb on the service specified as part of the path.
+type authorizer struct {
+	handler types.APIFunc
+	verb    types.AuthVerb
+}
+
+// NewAuthorizer returns a new filter for authorizing the principal that makes
+// a request to perform the verb on the service specified as part of the path.
+// A route without a service in its path requires that the principal be
+// allowed to perform the verb on at least one service. The filter must
+// precede the ServiceValidator so that a service is not resolved for a
+// principal that may not use it.
+func NewAuthorizer(verb types.AuthVerb) types.Middleware {
+	return &authorizer{verb: verb}
+}
+
+func (h *authorizer) Name() string {
+	return "authorizer"
+}
+
+func (h *authorizer) Handler(m types.APIFunc) types.APIFunc {
+	return (&authorizer{m, h.verb}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *authorizer) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	// the verb is recorded so that a task created by the request can only
+	// be canceled by a principal that may perform the same verb
+	ctx = ctx.WithValue(context.AuthVerbKey, h.verb)
+
+	p, ok := context.Principal(ctx)
+	if !ok {
+		return h.handler(ctx, w, req, store)
+	}
+
+	service := store.GetString("service")
+	if !p.Authorized(service, h.verb) {
+		return utils.NewForbiddenErr(p.Name, service, h.verb)
+	}
+
+	return h.handler(ctx, w, req, store)
+}
+
+// taskAuthorizer is an HTTP filter for authorizing the principal that makes
+// a request to perform the verb of the operation of the task specified as
+// part of the path.
+type taskAuthorizer struct {
+	handler types.APIFunc
+}
+
+// NewTaskAuthorizer returns a new filter for authorizing the principal that
+// makes a request to perform the verb of the operation of the task specified
+// as part of the path on the task's service. A task whose operation was not
+// authorized when it was created requires the read verb.
+func NewTaskAuthorizer() types.Middleware {
+	return &taskAuthorizer{}
+}
+
+func (h *taskAuthorizer) Name() string {
+	return "task-authorizer"
+}
+
+func (h *taskAuthorizer) Handler(m types.APIFunc) types.APIFunc {
+	return (&taskAuthorizer{m}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *taskAuthorizer) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	p, ok := context.Principal(ctx)
+	if !ok {
+		return h.handler(ctx, w, req, store)
+	}
+
+	// a task that is not found is left to the route to report
+	service, verb, ok := services.TaskOperation(ctx, store.GetInt("taskID"))
+	if !ok {
+		return h.handler(ctx, w, req, store)
+	}
+	if verb == "" {
+		verb = types.AuthVerbRead
+	}
+
+	if !p.Authorized(service, verb) {
+		return utils.NewForbiddenErr(p.Name, service, verb)
+	}
+
+	return h.handler(ctx, w, req, store)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// capabilityValidator is an HTTP filter for validating that the driver of the
+// service specified as part of the path supports an operation.
+type capabilityValidator struct {
+	handler    types.APIFunc
+	capability types.StorageCapability
+}
+
+// NewCapabilityValidator returns a new filter for validating that the driver
+// of the service specified as part of the path supports the capability. The
+// filter must follow the ServiceValidator.
+func NewCapabilityValidator(
+	capability types.StorageCapability) types.Middleware {
+	return &capabilityValidator{capability: capability}
+}
+
+func (h *capabilityValidator) Name() string {
+	return "capability-validator"
+}
+
+func (h *capabilityValidator) Handler(m types.APIFunc) types.APIFunc {
+	return (&capabilityValidator{m, h.capability}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *capabilityValidator) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	service := context.MustService(ctx)
+	if !services.Capabilities(service).Supports(h.capability) {
+		return utils.NewNotImplementedErr(service.Name(), h.capability)
+	}
+	return h.handler(ctx, w, req, store)
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/akutz/goof"
 
@@ -41,18 +43,57 @@ func (h *errorHandler) Handle(
 
 	ctx.Error(err)
 
-	httpErr := goof.NewHTTPError(err, getStatus(err))
+	switch tErr := err.(type) {
+	case *types.ErrQueueFull:
+		setRetryAfter(w, tErr.Fields())
+	case *types.ErrTooManyRequests:
+		setRetryAfter(w, tErr.Fields())
+	}
+
+	if _, ok := err.(*types.ErrUnauthenticated); ok {
+		w.Header().Set(types.WWWAuthenticateHeader, "Bearer")
+	}
+
+	httpErr := &codedHTTPError{
+		goof.NewHTTPError(err, getStatus(err)), types.ErrorCodeOf(err)}
 	httputils.WriteJSON(w, httpErr.Status(), httpErr)
 	return nil
 }
 
+// setRetryAfter sets the Retry-After header from an error's retryAfter
+// field.
+func setRetryAfter(w http.ResponseWriter, fields map[string]interface{}) {
+	if v, ok := fields["retryAfter"].(int); ok {
+		w.Header().Set(types.RetryAfterHeader, strconv.Itoa(v))
+	}
+}
+
+// getStatus returns the HTTP status of a response to a request that failed
+// with the error. The status is determined by the error's code.
 func getStatus(err error) int {
-	switch err.(type) {
-	case *types.ErrBadAdminToken:
-		return http.StatusUnauthorized
-	case *types.ErrNotFound:
-		return http.StatusNotFound
-	default:
-		return http.StatusInternalServerError
+	return types.ErrorCodeOf(err).Status()
+}
+
+// codedHTTPError is an HTTP error that includes its error's code in its JSON
+// representation.
+type codedHTTPError struct {
+	goof.HTTPError
+	code types.ErrorCode
+}
+
+// MarshalJSON marshals the error to JSON, adding the error's code to the
+// object as the value of the "code" key.
+func (e *codedHTTPError) MarshalJSON() ([]byte, error) {
+	buf, err := json.Marshal(e.HTTPError)
+	if err != nil || e.code == "" {
+		return buf, err
+	}
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, err
+	}
+	if m["code"], err = json.Marshal(e.code); err != nil {
+		return nil, err
 	}
+	return json.Marshal(m)
 }
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/server/idempotency"
+	"github.com/emccode/libstorage/api/types"
+)
+
+// idempotencyHandler is a global HTTP filter for answering the repeats of
+// the mutating requests made with idempotency keys with the tasks or
+// responses of the original requests.
+type idempotencyHandler struct {
+	handler types.APIFunc
+	cache   *idempotency.Cache
+	config  gofig.Config
+}
+
+// NewIdempotencyHandler returns a new global HTTP filter for answering the
+// repeats of the mutating requests made with idempotency keys with the tasks
+// or responses of the original requests. The idempotency keys are ignored if
+// the cache is nil. The filter must follow the auth handler so that the keys
+// of different principals are distinguished.
+func NewIdempotencyHandler(
+	cache *idempotency.Cache, config gofig.Config) types.Middleware {
+	return &idempotencyHandler{cache: cache, config: config}
+}
+
+func (h *idempotencyHandler) Name() string {
+	return "idempotency-handler"
+}
+
+func (h *idempotencyHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&idempotencyHandler{m, h.cache, h.config}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *idempotencyHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	key := req.Header.Get(types.IdempotencyKeyHeader)
+	if h.cache == nil || key == "" ||
+		(req.Method != http.MethodPost && req.Method != http.MethodDelete) {
+		return h.handler(ctx, w, req, store)
+	}
+
+	// the body is restored so that it may be read by the post args handler
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	fingerprint := requestFingerprint(req, body)
+
+	// the key is echoed so that the client knows the request may be retried
+	// without being executed again
+	w.Header().Set(types.IdempotencyKeyHeader, key)
+
+	var scope string
+	if p, ok := context.Principal(ctx); ok {
+		scope = p.Name
+	}
+
+	for {
+		e, first, err := h.cache.Begin(scope, key, fingerprint)
+		if err != nil {
+			return err
+		}
+		if first {
+			return h.execute(ctx, w, req, store, e)
+		}
+
+		ctx.WithField("idempotencyKey", key).Debug(
+			"waiting for original request")
+		select {
+		case <-e.Ready():
+		case <-ctx.Done():
+			ctx.Info("http client disconnected")
+			return nil
+		}
+
+		// the original request was rejected before it created a task, so
+		// the repeat is executed
+		if e.Forgotten() {
+			continue
+		}
+
+		ctx.WithField("idempotencyKey", key).Info("replaying request")
+		return h.replay(ctx, w, store, e)
+	}
+}
+
+// execute executes the first request made with a key and records its task
+// or response.
+func (h *idempotencyHandler) execute(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store,
+	e *idempotency.Entry) error {
+
+	rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+	err := h.handler(
+		ctx.WithValue(context.IdempotencyEntryKey, e), rw, req, store)
+
+	task, _ := e.Task()
+	_, queueFull := err.(*types.ErrQueueFull)
+	switch {
+	case err != nil && (task == nil || queueFull):
+		h.cache.Forget(e)
+	default:
+		header := http.Header{}
+		for k, v := range rw.Header() {
+			header[k] = v
+		}
+		h.cache.Complete(e, &idempotency.Response{
+			Status: rw.status,
+			Header: header,
+			Body:   rw.body.Bytes(),
+		})
+	}
+	return err
+}
+
+// replay answers a repeated request with the task or response of the
+// original request. The entry is added to the repeat's context so that the
+// original request's task is not canceled if the repeat times out or its
+// client disconnects.
+func (h *idempotencyHandler) replay(
+	ctx types.Context,
+	w http.ResponseWriter,
+	store types.Store,
+	e *idempotency.Entry) error {
+
+	if task, okStatus := e.Task(); task != nil {
+		return httputils.WriteTask(
+			ctx.WithValue(context.IdempotencyEntryKey, e),
+			h.config, w, store, task, okStatus)
+	}
+
+	res := e.Response()
+	if res == nil {
+		return nil
+	}
+	for k, v := range res.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(res.Status)
+	w.Write(res.Body)
+	return nil
+}
+
+// requestFingerprint returns the hex-encoded SHA-256 of the request's method,
+// URL, and body.
+func requestFingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter records the status and body written to a ResponseWriter.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -6,18 +6,23 @@ import (
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/signing"
 )
 
 // instanceIDHandler is a global HTTP filter for grokking the InstanceIDs
 // from the headers
 type instanceIDHandler struct {
 	handler types.APIFunc
+	config  *signing.Config
 }
 
 // NewInstanceIDHandler returns a new global HTTP filter for grokking the
-// InstanceIDs from the headers
-func NewInstanceIDHandler() types.Middleware {
-	return &instanceIDHandler{}
+// InstanceIDs from the headers. The headers' signatures are verified unless
+// the config is nil, and a principal bound to an instance may only send that
+// instance's ID.
+func NewInstanceIDHandler(config *signing.Config) types.Middleware {
+	return &instanceIDHandler{config: config}
 }
 
 func (h *instanceIDHandler) Name() string {
@@ -25,7 +30,7 @@ func (h *instanceIDHandler) Name() string {
 }
 
 func (h *instanceIDHandler) Handler(m types.APIFunc) types.APIFunc {
-	return (&instanceIDHandler{m}).Handle
+	return (&instanceIDHandler{m, h.config}).Handle
 }
 
 // Handle is the type's Handler function.
@@ -39,11 +44,23 @@ func (h *instanceIDHandler) Handle(
 	ctx.WithField(types.InstanceIDHeader, headers).Debug("http header")
 
 	valMap := types.InstanceIDMap{}
-	for _, h := range headers {
+	for _, hv := range headers {
 		val := &types.InstanceID{}
-		if err := val.UnmarshalText([]byte(h)); err != nil {
+		if err := val.UnmarshalText([]byte(hv)); err != nil {
 			return err
 		}
+		if p, ok := context.Principal(ctx); ok &&
+			p.InstanceID != "" && p.InstanceID != val.ID {
+			return utils.NewInstanceForbiddenErr(p.Name, val.ID)
+		}
+		if h.config != nil {
+			if err := verifySignature(
+				ctx, h.config, req,
+				types.InstanceIDHeader, types.InstanceIDSignatureHeader,
+				hv, val.Driver, val.ID); err != nil {
+				return err
+			}
+		}
 		valMap[strings.ToLower(val.Driver)] = val
 	}
 
@@ -6,18 +6,21 @@ import (
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils/signing"
 )
 
 // localDevicesHandler is a global HTTP filter for grokking the local devices
 // from the headers
 type localDevicesHandler struct {
 	handler types.APIFunc
+	config  *signing.Config
 }
 
 // NewLocalDevicesHandler returns a new global HTTP filter for grokking the
-// local devices from the headers
-func NewLocalDevicesHandler() types.Middleware {
-	return &localDevicesHandler{}
+// local devices from the headers. The headers' signatures are verified
+// unless the config is nil.
+func NewLocalDevicesHandler(config *signing.Config) types.Middleware {
+	return &localDevicesHandler{config: config}
 }
 
 func (h *localDevicesHandler) Name() string {
@@ -25,7 +28,7 @@ func (h *localDevicesHandler) Name() string {
 }
 
 func (h *localDevicesHandler) Handler(m types.APIFunc) types.APIFunc {
-	return (&localDevicesHandler{m}).Handle
+	return (&localDevicesHandler{m, h.config}).Handle
 }
 
 // Handle is the type's Handler function.
@@ -39,14 +42,35 @@ func (h *localDevicesHandler) Handle(
 	ctx.WithField(types.LocalDevicesHeader, headers).Debug("http header")
 
 	valMap := types.LocalDevicesMap{}
-	for _, h := range headers {
+	for _, hv := range headers {
 		val := &types.LocalDevices{}
-		if err := val.UnmarshalText([]byte(h)); err != nil {
+		if err := val.UnmarshalText([]byte(hv)); err != nil {
 			return err
 		}
+		if h.config != nil {
+			if err := verifySignature(
+				ctx, h.config, req,
+				types.LocalDevicesHeader, types.LocalDevicesSignatureHeader,
+				hv, val.Driver, instanceID(ctx, val.Driver)); err != nil {
+				return err
+			}
+		}
 		valMap[strings.ToLower(val.Driver)] = val
 	}
 
 	ctx = ctx.WithValue(context.AllLocalDevicesKey, valMap)
 	return h.handler(ctx, w, req, store)
 }
+
+// instanceID returns the ID of the instance for the provided driver from the
+// request's InstanceID headers.
+func instanceID(ctx types.Context, driver string) string {
+	iids, ok := ctx.Value(context.AllInstanceIDsKey).(types.InstanceIDMap)
+	if !ok {
+		return ""
+	}
+	if iid, ok := iids[strings.ToLower(driver)]; ok {
+		return iid.ID
+	}
+	return ""
+}
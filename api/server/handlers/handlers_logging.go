@@ -16,6 +16,7 @@ import (
 
 	"github.com/akutz/gotil"
 
+	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/types"
 )
 
@@ -72,10 +73,19 @@ func (h *loggingHandler) Handle(
 		}
 	}
 
+	// a streamed response is written directly to the client, so only its
+	// request is logged
+	if route, ok := context.Route(ctx); ok && route.GetStreaming() {
+		sw := &streamWriter{ResponseWriter: w, code: http.StatusOK}
+		reqErr := h.handler(ctx, sw, req, store)
+		logRequest(h.logRequests, bw, sw.code, sw.size, req, reqDump)
+		return reqErr
+	}
+
 	rec := httptest.NewRecorder()
 	reqErr := h.handler(ctx, rec, req, store)
 
-	logRequest(h.logRequests, bw, rec, req, reqDump)
+	logRequest(h.logRequests, bw, rec.Code, rec.Body.Len(), req, reqDump)
 
 	if reqErr != nil {
 		return reqErr
@@ -101,12 +111,11 @@ func (h *loggingHandler) Handle(
 func logRequest(
 	l bool,
 	w io.Writer,
-	rec *httptest.ResponseRecorder,
+	code, size int,
 	req *http.Request,
 	reqDump []byte) {
 
-	cll := buildCommonLogLine(
-		req, *req.URL, time.Now(), rec.Code, rec.Body.Len())
+	cll := buildCommonLogLine(req, *req.URL, time.Now(), code, size)
 	fmt.Fprintln(w, string(cll))
 
 	if !l || len(reqDump) == 0 {
@@ -120,6 +129,32 @@ func logRequest(
 	gotil.WriteIndented(w, reqDump)
 }
 
+// streamWriter is a ResponseWriter that records the status and size of a
+// streamed response for the common log line.
+type streamWriter struct {
+	http.ResponseWriter
+	code int
+	size int
+}
+
+func (w *streamWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *streamWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+// Flush sends any buffered data to the client.
+func (w *streamWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func logResponse(
 	w io.Writer,
 	rec *httptest.ResponseRecorder,
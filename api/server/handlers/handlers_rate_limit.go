@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/ratelimit"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// rateLimitHandler is a global HTTP filter for limiting the rate at which
+// clients make requests.
+type rateLimitHandler struct {
+	handler types.APIFunc
+	config  *ratelimit.Config
+}
+
+// NewRateLimitHandler returns a new global HTTP filter for limiting the rate
+// at which clients make requests. Requests are not limited if the config is
+// nil. The filter must follow the auth and InstanceID handlers so that the
+// principal and instance IDs by which requests may be limited are known.
+func NewRateLimitHandler(config *ratelimit.Config) types.Middleware {
+	return &rateLimitHandler{config: config}
+}
+
+func (h *rateLimitHandler) Name() string {
+	return "rate-limit-handler"
+}
+
+func (h *rateLimitHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&rateLimitHandler{m, h.config}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *rateLimitHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if h.config == nil {
+		return h.handler(ctx, w, req, store)
+	}
+
+	l := h.config.Limiter(req.Method)
+	if l == nil {
+		return h.handler(ctx, w, req, store)
+	}
+
+	key := rateLimitKey(ctx, h.config.Key, req)
+	if ok, wait := l.Allow(key); !ok {
+		return utils.NewRateLimitedErr(
+			key, int(math.Ceil(wait.Seconds())))
+	}
+
+	return h.handler(ctx, w, req, store)
+}
+
+// rateLimitKey returns the key of the bucket from which the request takes a
+// token. The remote address is used when the request has no value for the
+// configured key.
+func rateLimitKey(ctx types.Context, key string, req *http.Request) string {
+	switch key {
+	case ratelimit.KeyPrincipal:
+		if p, ok := context.Principal(ctx); ok {
+			return "principal:" + p.Name
+		}
+	case ratelimit.KeyInstanceID:
+		if iids, ok := ctx.Value(
+			context.AllInstanceIDsKey).(types.InstanceIDMap); ok &&
+			len(iids) > 0 {
+			ids := []string{}
+			for driver, iid := range iids {
+				ids = append(ids, driver+"="+iid.ID)
+			}
+			sort.Strings(ids)
+			return "instanceID:" + strings.Join(ids, ",")
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return "remoteAddr:" + host
+}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +9,7 @@ import (
 	//log "github.com/Sirupsen/logrus"
 
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
 	"github.com/emccode/libstorage/api/utils/schema"
 )
 
@@ -58,14 +58,14 @@ func (h *schemaValidator) Handle(
 
 	reqBody, err := ioutil.ReadAll(req.Body)
 	if err != nil {
-		return fmt.Errorf("validate req schema: read req error: %v", err)
+		return utils.NewInvalidRequestErr("read error", err)
 	}
 
 	// do the request validation
 	if h.reqSchema != nil {
 		err = schema.Validate(ctx, h.reqSchema, reqBody)
 		if err != nil {
-			return fmt.Errorf("validate req schema: validation error: %v", err)
+			return utils.NewInvalidRequestErr("schema validation error", err)
 		}
 	}
 
@@ -74,8 +74,7 @@ func (h *schemaValidator) Handle(
 		reqObj := h.newReqObjFunc()
 		if len(reqBody) > 0 {
 			if err = json.Unmarshal(reqBody, reqObj); err != nil {
-				return fmt.Errorf(
-					"validate req schema: unmarshal error: %v", err)
+				return utils.NewInvalidRequestErr("unmarshal error", err)
 			}
 		}
 		ctx = ctx.WithValue("reqObj", reqObj)
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/signing"
+)
+
+// verifySignature returns an error unless the signature header of the
+// request contains a valid signature of the header value for the provided
+// driver and instance.
+func verifySignature(
+	ctx types.Context,
+	config *signing.Config,
+	req *http.Request,
+	name, sigName, value, driver, instanceID string) error {
+
+	sig, err := signing.FindSignature(req.Header[sigName], driver)
+	if err != nil {
+		return utils.NewBadSignatureErr(name, driver, err.Error())
+	}
+
+	keys := config.Keys(instanceID, services.SigningKeys(ctx, driver)...)
+	if len(keys) == 0 {
+		return utils.NewBadSignatureErr(name, driver, "no signing key")
+	}
+
+	return signing.Verify(keys, name, value, sig, config.MaxAge)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/tenants"
+	"github.com/emccode/libstorage/api/types"
+)
+
+// tenantHandler is a global HTTP filter for determining the tenant on whose
+// behalf a request is made.
+type tenantHandler struct {
+	handler types.APIFunc
+	config  *tenants.Config
+}
+
+// NewTenantHandler returns a new global HTTP filter for determining the
+// tenant on whose behalf a request is made. Requests are not confined to a
+// tenant's namespace if the config is nil. The filter must follow the auth
+// handler so that the principal from which a tenant is derived is known.
+func NewTenantHandler(config *tenants.Config) types.Middleware {
+	return &tenantHandler{config: config}
+}
+
+func (h *tenantHandler) Name() string {
+	return "tenant-handler"
+}
+
+func (h *tenantHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&tenantHandler{m, h.config}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *tenantHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if h.config == nil {
+		return h.handler(ctx, w, req, store)
+	}
+
+	t, err := h.config.Tenant(ctx, req)
+	if err != nil {
+		return err
+	}
+	if t != nil {
+		ctx = ctx.WithValue(context.TenantKey, t)
+		ctx.WithField("tenant", t.Name).Debug("set tenant")
+	}
+
+	return h.handler(ctx, w, req, store)
+}
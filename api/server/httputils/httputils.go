@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/akutz/gofig"
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/idempotency"
 	"github.com/emccode/libstorage/api/server/services"
 	"github.com/emccode/libstorage/api/types"
 )
@@ -55,7 +57,31 @@ func WriteTask(
 	task *types.Task,
 	okStatus int) error {
 
+	if r, ok := context.AuditRecord(ctx); ok {
+		r.TaskID = task.ID
+		if iid, ok := context.InstanceID(ctx); ok {
+			r.InstanceID = iid.ID
+		}
+	}
+
+	// the task of a request made with an idempotency key is not canceled when
+	// the request times out or its client disconnects, since the client may
+	// repeat the request with the same key to receive the task's result
+	e, idempotent := ctx.Value(
+		context.IdempotencyEntryKey).(*idempotency.Entry)
+	if idempotent {
+		e.SetTask(task, okStatus)
+	}
+
 	if store.GetBool("async") {
+		// a task that was rejected because its service's task queue is full
+		// is already complete, and the rejection is returned as an error
+		// rather than as an accepted task
+		if services.TaskCompleted(ctx, task.ID) {
+			if err, ok := task.Error.(*types.ErrQueueFull); ok {
+				return err
+			}
+		}
 		WriteJSON(w, http.StatusAccepted, task)
 		return nil
 	}
@@ -72,9 +98,22 @@ func WriteTask(
 		if task.Error != nil {
 			return task.Error
 		}
+		writeNextPageToken(w, store)
 		WriteJSON(w, okStatus, task.Result)
 	case <-exeTimeout.C:
+		if !idempotent {
+			services.TaskCancel(ctx, task.ID)
+		}
 		WriteJSON(w, http.StatusRequestTimeout, task)
+	case <-ctx.Done():
+		// the request's context is canceled when the client disconnects
+		if idempotent {
+			ctx.WithField("taskID", task.ID).Info("client disconnected")
+			break
+		}
+		ctx.WithField("taskID", task.ID).Info(
+			"client disconnected; canceling task")
+		services.TaskCancel(ctx, task.ID)
 	}
 
 	return nil
@@ -0,0 +1,26 @@
+package httputils
+
+import (
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/filters"
+)
+
+// ParseFilter compiles the value of the filter query string parameter if it
+// is present in the store. A nil filter is returned if the parameter is not
+// present. The compiled filter replaces the raw value in the store.
+func ParseFilter(store types.Store) (*types.Filter, error) {
+	if !store.IsSet("filter") {
+		return nil, nil
+	}
+	if f, ok := store.Get("filter").(*types.Filter); ok {
+		return f, nil
+	}
+	fsz := store.GetString("filter")
+	filter, err := filters.CompileFilter(fsz)
+	if err != nil {
+		return nil, utils.NewBadFilterErr(fsz, err)
+	}
+	store.Set("filter", filter)
+	return filter, nil
+}
@@ -0,0 +1,35 @@
+package httputils
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils/paging"
+)
+
+// nextPageTokenKey is the store key for the continuation token written to
+// the NextPageTokenHeader by WriteTask.
+const nextPageTokenKey = "nextPageToken"
+
+// ParsePage parses the paging and sorting options from the request's query
+// string. A nil page is returned if the request does not contain any.
+func ParsePage(req *http.Request) (*paging.Page, error) {
+	return paging.ParsePage(req.URL.Query())
+}
+
+// SetNextPageToken stores the continuation token for the next page so that
+// it may be written to the response by WriteTask.
+func SetNextPageToken(store types.Store, token string) {
+	if token == "" {
+		return
+	}
+	store.Set(nextPageTokenKey, token)
+}
+
+// writeNextPageToken writes the stored continuation token, if any, to the
+// response's headers.
+func writeNextPageToken(w http.ResponseWriter, store types.Store) {
+	if token := store.GetString(nextPageTokenKey); token != "" {
+		w.Header().Set(types.NextPageTokenHeader, token)
+	}
+}
@@ -12,6 +12,7 @@ type route struct {
 	queries     []string
 	handler     types.APIFunc
 	middlewares []types.Middleware
+	streaming   bool
 }
 
 func (r *route) ContextLoggerField() (string, interface{}) {
@@ -52,6 +53,12 @@ func (r *route) Middlewares(
 	return r
 }
 
+// Streaming marks the route as one whose response is streamed.
+func (r *route) Streaming() types.Route {
+	r.streaming = true
+	return r
+}
+
 // Name returns the name of the route.
 func (r *route) GetName() string {
 	return r.name
@@ -82,6 +89,12 @@ func (r *route) GetMiddlewares() []types.Middleware {
 	return r.middlewares
 }
 
+// GetStreaming returns a flag indicating whether or not the route's response
+// is streamed.
+func (r *route) GetStreaming() bool {
+	return r.streaming
+}
+
 // NewRoute initialies a new local route for the reouter
 func NewRoute(
 	name, method, path string,
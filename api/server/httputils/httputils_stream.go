@@ -0,0 +1,42 @@
+package httputils
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// JSONStream writes values to a http response stream as JSON, one value per
+// line. Each value is flushed to the client as soon as it is written.
+type JSONStream struct {
+	w   http.ResponseWriter
+	enc *json.Encoder
+}
+
+// NewJSONStream writes the status code of a streamed JSON response and
+// returns a JSONStream for writing the response's values. The route that
+// writes the response must be a streaming route, or the response is buffered
+// by the server until it is complete.
+func NewJSONStream(w http.ResponseWriter, code int) *JSONStream {
+	w.Header().Set("Content-Type", types.JSONStreamContentType)
+	w.WriteHeader(code)
+	s := &JSONStream{w: w, enc: json.NewEncoder(w)}
+	s.flush()
+	return s
+}
+
+// Write writes the value to the stream.
+func (s *JSONStream) Write(v interface{}) error {
+	if err := s.enc.Encode(v); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+func (s *JSONStream) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Package idempotency provides the cache of the mutating requests that include
+an idempotency key.
+
+The first request made with a key is executed, and the task it creates or,
+if it does not create a task, the response it writes is remembered for the
+duration of the cache's window. A repeat of the request with the same key
+is answered with the remembered task or response rather than executed
+again, and a request that reuses a key for a different request is rejected.
+*/
+package idempotency
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// purgeInterval is the minimum amount of time between the purges of the
+// expired entries.
+const purgeInterval = time.Minute
+
+// Config is the configuration of the idempotency cache.
+type Config struct {
+
+	// Window is the amount of time for which a request's key is remembered.
+	Window time.Duration
+}
+
+// ParseConfig parses the configuration of the idempotency cache from the
+// libstorage.server.idempotency section of the server's configuration. A nil
+// value is returned if the window is zero, in which case the idempotency
+// keys are ignored.
+func ParseConfig(config gofig.Config) (*Config, error) {
+	v := config.GetString(types.ConfigServerIdempotencyWindow)
+	if v == "" {
+		return nil, nil
+	}
+	window, err := time.ParseDuration(v)
+	if err != nil || window < 0 {
+		return nil, goof.WithFields(goof.Fields{
+			"configKey": types.ConfigServerIdempotencyWindow,
+			"window":    v,
+		}, "invalid idempotency window")
+	}
+	if window == 0 {
+		return nil, nil
+	}
+	return &Config{Window: window}, nil
+}
+
+// Response is a response written by a request that did not create a task.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Entry is the record of the first request made with an idempotency key.
+type Entry struct {
+	sync.Mutex
+	id          string
+	fingerprint string
+	created     time.Time
+	ready       chan struct{}
+	readyOnce   sync.Once
+	forgotten   bool
+	task        *types.Task
+	okStatus    int
+	response    *Response
+}
+
+// Ready returns a channel that is closed once the request has created a
+// task, written its response, or been forgotten.
+func (e *Entry) Ready() <-chan struct{} {
+	return e.ready
+}
+
+// SetTask records the task created by the request and the status with which
+// the task's result is written if the task succeeds.
+func (e *Entry) SetTask(task *types.Task, okStatus int) {
+	e.Lock()
+	e.task = task
+	e.okStatus = okStatus
+	e.Unlock()
+	e.setReady()
+}
+
+// Task returns the task created by the request and the status with which
+// its result is written. A nil task is returned if the request did not
+// create a task.
+func (e *Entry) Task() (*types.Task, int) {
+	e.Lock()
+	defer e.Unlock()
+	return e.task, e.okStatus
+}
+
+// Response returns the response written by the request if it did not create
+// a task.
+func (e *Entry) Response() *Response {
+	e.Lock()
+	defer e.Unlock()
+	return e.response
+}
+
+// Forgotten returns a flag indicating whether or not the request was
+// forgotten, in which case a repeat of the request must be executed.
+func (e *Entry) Forgotten() bool {
+	e.Lock()
+	defer e.Unlock()
+	return e.forgotten
+}
+
+func (e *Entry) setReady() {
+	e.readyOnce.Do(func() { close(e.ready) })
+}
+
+// Cache is the cache of the requests made with idempotency keys.
+type Cache struct {
+	sync.Mutex
+	config  *Config
+	entries map[string]*Entry
+	purged  time.Time
+}
+
+// New returns a new idempotency cache.
+func New(config *Config) *Cache {
+	return &Cache{
+		config:  config,
+		entries: map[string]*Entry{},
+		purged:  time.Now(),
+	}
+}
+
+// Begin returns the entry of the request made with the key. The scope
+// distinguishes the keys of different principals, and the fingerprint
+// identifies the request. The returned flag is true if the request is the
+// first made with the key, in which case the caller must execute the
+// request and then either complete or forget the entry. An error is returned
+// if the key was used by a request with a different fingerprint.
+func (c *Cache) Begin(
+	scope, key, fingerprint string) (*Entry, bool, error) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	c.purge(now)
+
+	id := scope + "\x00" + key
+	if e, ok := c.entries[id]; ok && now.Sub(e.created) < c.config.Window {
+		if e.fingerprint != fingerprint {
+			return nil, false, utils.NewIdempotencyKeyReusedErr(key)
+		}
+		return e, false, nil
+	}
+
+	e := &Entry{
+		id:          id,
+		fingerprint: fingerprint,
+		created:     now,
+		ready:       make(chan struct{}),
+	}
+	c.entries[id] = e
+	return e, true, nil
+}
+
+// Complete records the response written by a request that did not create a
+// task.
+func (c *Cache) Complete(e *Entry, res *Response) {
+	e.Lock()
+	if e.task == nil {
+		e.response = res
+	}
+	e.Unlock()
+	e.setReady()
+}
+
+// Forget removes the entry so that the request is executed again if it is
+// repeated. A request is forgotten if it was rejected before it created a
+// task.
+func (c *Cache) Forget(e *Entry) {
+	c.Lock()
+	if c.entries[e.id] == e {
+		delete(c.entries, e.id)
+	}
+	c.Unlock()
+
+	e.Lock()
+	e.forgotten = true
+	e.Unlock()
+	e.setReady()
+}
+
+// purge removes the expired entries if they have not been purged recently.
+func (c *Cache) purge(now time.Time) {
+	if now.Sub(c.purged) < purgeInterval {
+		return
+	}
+	for id, e := range c.entries {
+		if now.Sub(e.created) >= c.config.Window {
+			delete(c.entries, id)
+		}
+	}
+	c.purged = now
+}
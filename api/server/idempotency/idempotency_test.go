@@ -0,0 +1,87 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+func isReady(e *Entry) bool {
+	select {
+	case <-e.Ready():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBegin(t *testing.T) {
+	c := New(&Config{Window: time.Minute})
+
+	e, first, err := c.Begin("alice", "key", "fp")
+	assert.NoError(t, err)
+	assert.True(t, first)
+	assert.False(t, isReady(e))
+
+	// the repeat is given the original entry, which is ready once the
+	// original request creates a task
+	e2, first, err := c.Begin("alice", "key", "fp")
+	assert.NoError(t, err)
+	assert.False(t, first)
+	assert.Equal(t, e, e2)
+
+	task := &types.Task{ID: 1}
+	e.SetTask(task, 201)
+	assert.True(t, isReady(e2))
+	tt, okStatus := e2.Task()
+	assert.Equal(t, task, tt)
+	assert.Equal(t, 201, okStatus)
+
+	// the response of a request that created a task is not recorded
+	c.Complete(e, &Response{Status: 408})
+	assert.Nil(t, e.Response())
+
+	_, _, err = c.Begin("alice", "key", "fp2")
+	assert.IsType(t, &types.ErrIdempotencyKeyReused{}, err)
+
+	// the keys of different principals are distinct
+	_, first, err = c.Begin("bob", "key", "fp2")
+	assert.NoError(t, err)
+	assert.True(t, first)
+}
+
+func TestForget(t *testing.T) {
+	c := New(&Config{Window: time.Minute})
+
+	e, _, _ := c.Begin("", "key", "fp")
+	e2, first, _ := c.Begin("", "key", "fp")
+	assert.False(t, first)
+
+	c.Forget(e)
+	assert.True(t, isReady(e2))
+	assert.True(t, e2.Forgotten())
+
+	e3, first, err := c.Begin("", "key", "fp")
+	assert.NoError(t, err)
+	assert.True(t, first)
+	assert.NotEqual(t, e, e3)
+
+	c.Complete(e3, &Response{Status: 200, Body: []byte("{}")})
+	assert.Equal(t, 200, e3.Response().Status)
+}
+
+func TestWindow(t *testing.T) {
+	c := New(&Config{Window: time.Millisecond})
+
+	_, first, _ := c.Begin("", "key", "fp")
+	assert.True(t, first)
+	time.Sleep(5 * time.Millisecond)
+
+	// an expired key may be reused for a different request
+	_, first, err := c.Begin("", "key", "fp2")
+	assert.NoError(t, err)
+	assert.True(t, first)
+}
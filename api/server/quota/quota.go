@@ -0,0 +1,425 @@
+/*
+Package quota provides the quotas that limit the volumes and snapshots a
+storage service provisions.
+
+A quota's scope is either all of a service's resources, the resources created
+on behalf of a tenant, or the resources created on behalf of an instance. The
+usage of a quota is computed from the service driver's listings of its
+volumes and snapshots, which are cached, and the owners of the resources the
+server creates are recorded so that the resources may be attributed to the
+tenants and instances on whose behalf they were created.
+*/
+package quota
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// Request describes the resources a request would create.
+type Request struct {
+
+	// Volumes is the number of volumes the request would create.
+	Volumes int64
+
+	// Snapshots is the number of snapshots the request would create.
+	Snapshots int64
+
+	// Size is the requested size, in GB, of the volume. If nil, the size of
+	// the source volume or snapshot is used if it is known.
+	Size *int64
+
+	// VolumeID is the ID of the volume that is copied or resized.
+	VolumeID string
+
+	// Resize indicates that the request resizes the volume with VolumeID to
+	// Size rather than creating a volume. The growth of the volume is
+	// reserved on behalf of the volume's recorded owner.
+	Resize bool
+
+	// SnapshotID is the ID of the snapshot from which a volume is created.
+	SnapshotID string
+}
+
+// Owner identifies the tenant and instance on whose behalf a resource is
+// created.
+type Owner struct {
+	Tenant     string `json:"tenant,omitempty"`
+	InstanceID string `json:"instanceID,omitempty"`
+}
+
+// VolumesFunc lists a storage service's volumes.
+type VolumesFunc func(ctx types.Context) ([]*types.Volume, error)
+
+// SnapshotsFunc lists a storage service's snapshots.
+type SnapshotsFunc func(ctx types.Context) ([]*types.Snapshot, error)
+
+// Enforcer enforces the quotas of a storage service.
+type Enforcer struct {
+	sync.Mutex
+	config        *Config
+	owners        *owners
+	volumesFunc   VolumesFunc
+	snapshotsFunc SnapshotsFunc
+	vols          []*types.Volume
+	snaps         []*types.Snapshot
+	listed        time.Time
+	reservations  map[*reservation]struct{}
+}
+
+// reservation is the resources reserved for a request that has not yet
+// completed.
+type reservation struct {
+	owner      *Owner
+	volumes    int64
+	volumeSize int64
+	size       int64
+	snapshots  int64
+	resize     bool
+}
+
+// scope is a quota's scope and its limits.
+type scope struct {
+	scope  types.QuotaScope
+	name   string
+	limits *types.QuotaLimits
+}
+
+// NewEnforcer returns a new enforcer of the quotas in the provided config.
+// The owners of the resources the server creates are saved to the file at
+// the provided path, or are kept only in memory if the path is empty.
+func NewEnforcer(
+	config *Config,
+	path string,
+	volumesFunc VolumesFunc,
+	snapshotsFunc SnapshotsFunc) (*Enforcer, error) {
+
+	o, err := loadOwners(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Enforcer{
+		config:        config,
+		owners:        o,
+		volumesFunc:   volumesFunc,
+		snapshotsFunc: snapshotsFunc,
+		reservations:  map[*reservation]struct{}{},
+	}, nil
+}
+
+// Reserve reserves the resources described by the request for the owner. An
+// error is returned if the resources would exceed one of the quotas that
+// apply to the owner. Otherwise the returned function must be called with the
+// created or resized volume or the created snapshot, or nil if the request
+// failed, in order to release the reservation.
+func (e *Enforcer) Reserve(
+	ctx types.Context,
+	owner *Owner,
+	req *Request) (func(created interface{}), error) {
+
+	e.Lock()
+	defer e.Unlock()
+
+	if req.Resize {
+		if o := e.owners.volume(req.VolumeID); o != nil {
+			owner = o
+		}
+	}
+
+	r := &reservation{
+		owner:     owner,
+		volumes:   req.Volumes,
+		snapshots: req.Snapshots,
+		resize:    req.Resize,
+	}
+
+	scopes := e.scopes(owner)
+	if len(scopes) > 0 {
+		if err := e.refresh(ctx); err != nil {
+			return nil, err
+		}
+		if r.volumes > 0 {
+			r.size = e.requestSize(req)
+			r.volumeSize = r.size
+		} else if r.resize && req.Size != nil {
+			r.volumeSize = *req.Size
+			r.size = *req.Size - e.volumeSize(req.VolumeID)
+			if r.size < 0 {
+				r.size = 0
+			}
+		}
+		for _, s := range scopes {
+			if err := e.check(s, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	e.reservations[r] = struct{}{}
+
+	release := func(created interface{}) {
+		e.Lock()
+		defer e.Unlock()
+		delete(e.reservations, r)
+		if !r.resize {
+			e.record(ctx, owner, created)
+			return
+		}
+
+		// the cached size of a resized volume is stale, so the volumes are
+		// listed again the next time the usage is needed
+		if created != nil {
+			e.listed = time.Time{}
+		}
+	}
+	return release, nil
+}
+
+// Release invalidates the cached usage of the service's resources so that a
+// removed resource is no longer counted against its quotas.
+func (e *Enforcer) Release() {
+	e.Lock()
+	defer e.Unlock()
+	e.listed = time.Time{}
+}
+
+// Quotas returns the service's quota as well as the quotas of the owner's
+// tenant and instance. A quota's limits are nil if its scope is not limited.
+func (e *Enforcer) Quotas(
+	ctx types.Context, owner *Owner) ([]*types.Quota, error) {
+
+	e.Lock()
+	defer e.Unlock()
+
+	if err := e.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	scopes := []*scope{
+		{scope: types.QuotaScopeService, limits: e.config.Service},
+	}
+	if owner.Tenant != "" {
+		scopes = append(scopes, &scope{
+			scope:  types.QuotaScopeTenant,
+			name:   owner.Tenant,
+			limits: e.config.limits(e.config.Tenants, owner.Tenant),
+		})
+	}
+	if owner.InstanceID != "" {
+		scopes = append(scopes, &scope{
+			scope:  types.QuotaScopeInstance,
+			name:   owner.InstanceID,
+			limits: e.config.limits(e.config.Instances, owner.InstanceID),
+		})
+	}
+
+	quotas := []*types.Quota{}
+	for _, s := range scopes {
+		quotas = append(quotas, &types.Quota{
+			Scope:  s.scope,
+			Name:   s.name,
+			Limits: s.limits,
+			Usage:  e.usage(s),
+		})
+	}
+	return quotas, nil
+}
+
+// scopes returns the scopes of the quotas that apply to the owner.
+func (e *Enforcer) scopes(owner *Owner) []*scope {
+	scopes := []*scope{}
+	if e.config.Service != nil {
+		scopes = append(scopes, &scope{
+			scope:  types.QuotaScopeService,
+			limits: e.config.Service,
+		})
+	}
+	if owner.Tenant != "" {
+		if l := e.config.limits(e.config.Tenants, owner.Tenant); l != nil {
+			scopes = append(scopes, &scope{
+				scope:  types.QuotaScopeTenant,
+				name:   owner.Tenant,
+				limits: l,
+			})
+		}
+	}
+	if owner.InstanceID != "" {
+		if l := e.config.limits(
+			e.config.Instances, owner.InstanceID); l != nil {
+			scopes = append(scopes, &scope{
+				scope:  types.QuotaScopeInstance,
+				name:   owner.InstanceID,
+				limits: l,
+			})
+		}
+	}
+	return scopes
+}
+
+// check returns an error if the reservation would exceed one of the limits
+// of the quota in the provided scope.
+func (e *Enforcer) check(s *scope, r *reservation) error {
+	l := s.limits
+	if l.VolumeSize > 0 && r.volumeSize > l.VolumeSize {
+		return utils.NewQuotaExceededErr(
+			s.scope, s.name, "volumeSize", l.VolumeSize, r.volumeSize)
+	}
+	u := e.usage(s)
+	if r.volumes > 0 {
+		if l.Volumes > 0 && u.Volumes+r.volumes > l.Volumes {
+			return utils.NewQuotaExceededErr(
+				s.scope, s.name, "volumes", l.Volumes, u.Volumes+r.volumes)
+		}
+	}
+	if r.volumes > 0 || r.size > 0 {
+		if l.Size > 0 && u.Size+r.size > l.Size {
+			return utils.NewQuotaExceededErr(
+				s.scope, s.name, "size", l.Size, u.Size+r.size)
+		}
+	}
+	if r.snapshots > 0 {
+		if l.Snapshots > 0 && u.Snapshots+r.snapshots > l.Snapshots {
+			return utils.NewQuotaExceededErr(
+				s.scope, s.name, "snapshots",
+				l.Snapshots, u.Snapshots+r.snapshots)
+		}
+	}
+	return nil
+}
+
+// usage returns the usage of the resources in the provided scope, including
+// the resources reserved for the requests that have not yet completed.
+func (e *Enforcer) usage(s *scope) *types.QuotaUsage {
+
+	match := func(o *Owner) bool {
+		switch s.scope {
+		case types.QuotaScopeService:
+			return true
+		case types.QuotaScopeTenant:
+			return o != nil && o.Tenant == s.name
+		case types.QuotaScopeInstance:
+			return o != nil && o.InstanceID == s.name
+		}
+		return false
+	}
+
+	u := &types.QuotaUsage{}
+	for _, v := range e.vols {
+		if match(e.owners.volume(v.ID)) {
+			u.Volumes++
+			u.Size += v.Size
+		}
+	}
+	for _, v := range e.snaps {
+		o := e.owners.snapshot(v.ID)
+		if o == nil {
+			o = e.owners.volume(v.VolumeID)
+		}
+		if match(o) {
+			u.Snapshots++
+		}
+	}
+	for r := range e.reservations {
+		if match(r.owner) {
+			u.Volumes += r.volumes
+			u.Size += r.size
+			u.Snapshots += r.snapshots
+		}
+	}
+	return u
+}
+
+// requestSize returns the size of the volume the request would create.
+func (e *Enforcer) requestSize(req *Request) int64 {
+	if req.Size != nil {
+		return *req.Size
+	}
+	if req.VolumeID != "" {
+		return e.volumeSize(req.VolumeID)
+	}
+	if req.SnapshotID != "" {
+		for _, v := range e.snaps {
+			if v.ID == req.SnapshotID {
+				return v.VolumeSize
+			}
+		}
+	}
+	return 0
+}
+
+// volumeSize returns the cached size of the volume with the provided ID, or
+// zero if the volume is not listed.
+func (e *Enforcer) volumeSize(volumeID string) int64 {
+	for _, v := range e.vols {
+		if v.ID == volumeID {
+			return v.Size
+		}
+	}
+	return 0
+}
+
+// refresh lists the service's volumes and snapshots unless the cached
+// listings are younger than the cache's TTL. The snapshots are listed only
+// if they are limited.
+func (e *Enforcer) refresh(ctx types.Context) error {
+	if !e.listed.IsZero() && time.Since(e.listed) < e.config.CacheTTL {
+		return nil
+	}
+
+	vols, err := e.volumesFunc(ctx)
+	if err != nil {
+		return err
+	}
+
+	var snaps []*types.Snapshot
+	if e.config.snapshotsLimited() {
+		if snaps, err = e.snapshotsFunc(ctx); err != nil {
+			return err
+		}
+	}
+
+	e.vols = vols
+	e.snaps = snaps
+	e.listed = time.Now()
+
+	if e.owners.prune(vols, snaps, e.listed.Add(-e.config.CacheTTL)) {
+		if err := e.owners.save(); err != nil {
+			ctx.WithError(err).Error("error saving quota owners")
+		}
+	}
+	return nil
+}
+
+// record records the owner of the created volume or snapshot and adds it to
+// the cached listings.
+func (e *Enforcer) record(
+	ctx types.Context, owner *Owner, created interface{}) {
+
+	switch tc := created.(type) {
+	case *types.Volume:
+		if tc == nil {
+			return
+		}
+		e.owners.setVolume(tc.ID, owner)
+		if !e.listed.IsZero() {
+			e.vols = append(e.vols, tc)
+		}
+	case *types.Snapshot:
+		if tc == nil {
+			return
+		}
+		e.owners.setSnapshot(tc.ID, owner)
+		if !e.listed.IsZero() {
+			e.snaps = append(e.snaps, tc)
+		}
+	default:
+		return
+	}
+
+	if err := e.owners.save(); err != nil {
+		ctx.WithError(err).Error("error saving quota owners")
+	}
+}
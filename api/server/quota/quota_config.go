@@ -0,0 +1,190 @@
+package quota
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// wildcard is the name of the tenant or instance whose limits apply to the
+// tenants and instances that do not have their own limits.
+const wildcard = "*"
+
+// Config is the configuration of a storage service's quotas.
+type Config struct {
+
+	// CacheTTL is the amount of time for which the listings of the service's
+	// volumes and snapshots are cached.
+	CacheTTL time.Duration
+
+	// Service are the limits of all of the service's resources.
+	Service *types.QuotaLimits
+
+	// Tenants are the limits of the resources of each tenant, keyed by the
+	// tenants' names.
+	Tenants map[string]*types.QuotaLimits
+
+	// Instances are the limits of the resources of each instance, keyed by
+	// the instances' IDs.
+	Instances map[string]*types.QuotaLimits
+}
+
+// ParseConfig parses the configuration of a storage service's quotas from
+// the libstorage.server.quotas section of the service's configuration.
+func ParseConfig(config gofig.Config) (*Config, error) {
+
+	c := &Config{}
+
+	ttl, err := time.ParseDuration(
+		config.GetString(types.ConfigServerQuotasCacheTTL))
+	if err != nil {
+		ttl = time.Duration(time.Second * 30)
+	}
+	c.CacheTTL = ttl
+
+	if c.Service, err = parseLimits(
+		config.Get(types.ConfigServerQuotasService),
+		types.ConfigServerQuotasService); err != nil {
+		return nil, err
+	}
+
+	if c.Tenants, err = parseLimitsMap(
+		config.Get(types.ConfigServerQuotasTenants),
+		types.ConfigServerQuotasTenants); err != nil {
+		return nil, err
+	}
+
+	if c.Instances, err = parseLimitsMap(
+		config.Get(types.ConfigServerQuotasInstances),
+		types.ConfigServerQuotasInstances); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// limits returns the limits for the named tenant or instance, the wildcard
+// limits if the name does not have its own, or nil if neither exist.
+func (c *Config) limits(
+	m map[string]*types.QuotaLimits, name string) *types.QuotaLimits {
+
+	if l, ok := m[strings.ToLower(name)]; ok {
+		return l
+	}
+	return m[wildcard]
+}
+
+// snapshotsLimited returns a flag indicating whether or not any of the
+// quotas limit the number of snapshots.
+func (c *Config) snapshotsLimited() bool {
+	if c.Service != nil && c.Service.Snapshots > 0 {
+		return true
+	}
+	for _, m := range []map[string]*types.QuotaLimits{c.Tenants, c.Instances} {
+		for _, l := range m {
+			if l.Snapshots > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseLimitsMap parses a map of limits keyed by the names of the tenants
+// or instances to which they apply.
+func parseLimitsMap(
+	v interface{}, key string) (map[string]*types.QuotaLimits, error) {
+
+	m, err := toStringMap(v, key)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := map[string]*types.QuotaLimits{}
+	for name, lv := range m {
+		l, err := parseLimits(lv, fmt.Sprintf("%s.%s", key, name))
+		if err != nil {
+			return nil, err
+		}
+		if l != nil {
+			limits[strings.ToLower(name)] = l
+		}
+	}
+	return limits, nil
+}
+
+// parseLimits parses a map with the keys volumes, size, volumeSize, and
+// snapshots. A nil value is returned if the map is empty.
+func parseLimits(v interface{}, key string) (*types.QuotaLimits, error) {
+
+	m, err := toStringMap(v, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	l := &types.QuotaLimits{}
+	for k, lv := range m {
+		i, err := toInt64(lv)
+		if err != nil || i < 0 {
+			return nil, goof.WithFields(goof.Fields{
+				"configKey": key,
+				"limit":     k,
+			}, "invalid quota limit")
+		}
+		switch strings.ToLower(k) {
+		case "volumes":
+			l.Volumes = i
+		case "size":
+			l.Size = i
+		case "volumesize":
+			l.VolumeSize = i
+		case "snapshots":
+			l.Snapshots = i
+		default:
+			return nil, goof.WithFields(goof.Fields{
+				"configKey": key,
+				"limit":     k,
+			}, "unknown quota limit")
+		}
+	}
+	return l, nil
+}
+
+func toStringMap(v interface{}, key string) (map[string]interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return tv, nil
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range tv {
+			m[fmt.Sprintf("%v", k)] = v
+		}
+		return m, nil
+	}
+	return nil, goof.WithField("configKey", key, "invalid format")
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch tv := v.(type) {
+	case int:
+		return int64(tv), nil
+	case int64:
+		return tv, nil
+	case float64:
+		return int64(tv), nil
+	case string:
+		return strconv.ParseInt(tv, 10, 64)
+	}
+	return 0, goof.New("invalid integer")
+}
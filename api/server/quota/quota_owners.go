@@ -0,0 +1,141 @@
+package quota
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// owners are the owners of the volumes and snapshots the server creates.
+type owners struct {
+	path      string
+	Volumes   map[string]*ownerEntry `json:"volumes"`
+	Snapshots map[string]*ownerEntry `json:"snapshots"`
+}
+
+// ownerEntry is the owner of a resource and the time, in epoch seconds, at
+// which the resource was created.
+type ownerEntry struct {
+	Owner
+	Time int64 `json:"time"`
+}
+
+// loadOwners loads the owners from the file at the provided path. An empty
+// set of owners is returned if the file does not exist.
+func loadOwners(path string) (*owners, error) {
+	o := &owners{
+		path:      path,
+		Volumes:   map[string]*ownerEntry{},
+		Snapshots: map[string]*ownerEntry{},
+	}
+	if path == "" {
+		return o, nil
+	}
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, goof.WithFieldE("path", path, "error reading owners", err)
+	}
+	if err := json.Unmarshal(buf, o); err != nil {
+		return nil, goof.WithFieldE("path", path, "error parsing owners", err)
+	}
+	if o.Volumes == nil {
+		o.Volumes = map[string]*ownerEntry{}
+	}
+	if o.Snapshots == nil {
+		o.Snapshots = map[string]*ownerEntry{}
+	}
+	return o, nil
+}
+
+// save writes the owners to their file. The file is replaced atomically so
+// that the owners are not lost if the server stops while they are written.
+func (o *owners) save() error {
+	if o.path == "" {
+		return nil
+	}
+
+	buf, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return err
+	}
+	tmp := o.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path)
+}
+
+func (o *owners) volume(id string) *Owner {
+	if e, ok := o.Volumes[id]; ok {
+		return &e.Owner
+	}
+	return nil
+}
+
+func (o *owners) snapshot(id string) *Owner {
+	if e, ok := o.Snapshots[id]; ok {
+		return &e.Owner
+	}
+	return nil
+}
+
+func (o *owners) setVolume(id string, owner *Owner) {
+	o.Volumes[id] = &ownerEntry{Owner: *owner, Time: time.Now().Unix()}
+}
+
+func (o *owners) setSnapshot(id string, owner *Owner) {
+	o.Snapshots[id] = &ownerEntry{Owner: *owner, Time: time.Now().Unix()}
+}
+
+// prune removes the owners of the resources that are not in the provided
+// listings. The owners of the resources created after the provided time are
+// retained since the resources may not yet appear in the listings. A flag is
+// returned that indicates whether or not any owners were removed.
+func (o *owners) prune(
+	vols []*types.Volume, snaps []*types.Snapshot, since time.Time) bool {
+
+	pruned := false
+
+	ids := map[string]bool{}
+	for _, v := range vols {
+		ids[v.ID] = true
+	}
+	for id, e := range o.Volumes {
+		if !ids[id] && e.Time < since.Unix() {
+			delete(o.Volumes, id)
+			pruned = true
+		}
+	}
+
+	// the snapshots are pruned only if they were listed
+	if snaps == nil {
+		return pruned
+	}
+
+	ids = map[string]bool{}
+	for _, v := range snaps {
+		ids[v.ID] = true
+	}
+	for id, e := range o.Snapshots {
+		if !ids[id] && e.Time < since.Unix() {
+			delete(o.Snapshots, id)
+			pruned = true
+		}
+	}
+
+	return pruned
+}
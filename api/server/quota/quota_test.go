@@ -0,0 +1,230 @@
+package quota
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+type fakeService struct {
+	vols  []*types.Volume
+	snaps []*types.Snapshot
+	lists int
+}
+
+func (s *fakeService) volumes(ctx types.Context) ([]*types.Volume, error) {
+	s.lists++
+	return append([]*types.Volume{}, s.vols...), nil
+}
+
+func (s *fakeService) snapshots(
+	ctx types.Context) ([]*types.Snapshot, error) {
+	return append([]*types.Snapshot{}, s.snaps...), nil
+}
+
+func newTestEnforcer(
+	t *testing.T, c *Config, path string) (*Enforcer, *fakeService) {
+
+	s := &fakeService{
+		vols: []*types.Volume{{ID: "vol-000", Size: 10}},
+	}
+	if c.CacheTTL == 0 {
+		c.CacheTTL = time.Duration(time.Minute)
+	}
+	e, err := NewEnforcer(c, path, s.volumes, s.snapshots)
+	assert.NoError(t, err)
+	return e, s
+}
+
+func assertQuotaExceeded(t *testing.T, err error, limit string) {
+	if !assert.Error(t, err) {
+		return
+	}
+	qErr, ok := err.(*types.ErrQuotaExceeded)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, limit, qErr.Fields()["limit"])
+}
+
+func size(i int64) *int64 {
+	return &i
+}
+
+func TestReserveService(t *testing.T) {
+	ctx := context.Background()
+	e, s := newTestEnforcer(t, &Config{
+		Service: &types.QuotaLimits{Volumes: 3, Size: 30, VolumeSize: 15},
+	}, "")
+	owner := &Owner{}
+
+	_, err := e.Reserve(ctx, owner, &Request{Volumes: 1, Size: size(16)})
+	assertQuotaExceeded(t, err, "volumeSize")
+
+	// the size of a copy is the size of the source volume
+	release, err := e.Reserve(
+		ctx, owner, &Request{Volumes: 1, VolumeID: "vol-000"})
+	assert.NoError(t, err)
+
+	// the reservation counts against the quota until it is released
+	_, err = e.Reserve(ctx, owner, &Request{Volumes: 1, Size: size(11)})
+	assertQuotaExceeded(t, err, "size")
+
+	release(&types.Volume{ID: "vol-001", Size: 10})
+	release2, err := e.Reserve(
+		ctx, owner, &Request{Volumes: 1, Size: size(10)})
+	assert.NoError(t, err)
+	release2(nil)
+
+	_, err = e.Reserve(ctx, owner, &Request{Volumes: 1, Size: size(11)})
+	assertQuotaExceeded(t, err, "size")
+
+	// the listing is cached until the usage is released
+	assert.Equal(t, 1, s.lists)
+	s.vols = s.vols[:1]
+	e.Release()
+	_, err = e.Reserve(ctx, owner, &Request{Volumes: 1, Size: size(11)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s.lists)
+}
+
+func TestReserveResize(t *testing.T) {
+	ctx := context.Background()
+	e, s := newTestEnforcer(t, &Config{
+		Service: &types.QuotaLimits{Size: 35, VolumeSize: 20},
+		Tenants: map[string]*types.QuotaLimits{
+			"alice": {Size: 4},
+		},
+	}, "")
+	s.vols = append(s.vols, &types.Volume{ID: "vol-001", Size: 10})
+	owner := &Owner{}
+
+	resize := func(volumeID string, newSize int64) *Request {
+		return &Request{VolumeID: volumeID, Size: size(newSize), Resize: true}
+	}
+
+	_, err := e.Reserve(ctx, owner, resize("vol-000", 21))
+	assertQuotaExceeded(t, err, "volumeSize")
+
+	// only the growth of the volume counts against the total size
+	release, err := e.Reserve(ctx, owner, resize("vol-000", 20))
+	assert.NoError(t, err)
+	_, err = e.Reserve(ctx, owner, resize("vol-001", 16))
+	assertQuotaExceeded(t, err, "size")
+
+	// a failed resize releases its reservation
+	release(nil)
+	release, err = e.Reserve(ctx, owner, resize("vol-001", 20))
+	assert.NoError(t, err)
+
+	// a successful resize invalidates the cached listing
+	s.vols[1].Size = 20
+	release(&types.Volume{ID: "vol-001", Size: 20})
+	_, err = e.Reserve(ctx, owner, resize("vol-000", 16))
+	assertQuotaExceeded(t, err, "size")
+	assert.Equal(t, 2, s.lists)
+
+	// the growth is counted against the quotas of the volume's owner
+	release, err = e.Reserve(
+		ctx, &Owner{Tenant: "alice"}, &Request{Volumes: 1, Size: size(0)})
+	assert.NoError(t, err)
+	release(&types.Volume{ID: "vol-002", Size: 0})
+	_, err = e.Reserve(ctx, owner, resize("vol-002", 5))
+	assertQuotaExceeded(t, err, "size")
+	release, err = e.Reserve(ctx, owner, resize("vol-002", 4))
+	assert.NoError(t, err)
+	release(nil)
+}
+
+func TestReserveTenantsAndInstances(t *testing.T) {
+	ctx := context.Background()
+	e, s := newTestEnforcer(t, &Config{
+		Tenants: map[string]*types.QuotaLimits{
+			"alice":  {Volumes: 2},
+			wildcard: {Volumes: 1, Snapshots: 1},
+		},
+		Instances: map[string]*types.QuotaLimits{
+			"iid-1": {Volumes: 1},
+		},
+	}, "")
+	s.snaps = []*types.Snapshot{{ID: "snap-000", VolumeID: "vol-000"}}
+
+	alice := &Owner{Tenant: "alice"}
+	bob := &Owner{Tenant: "bob", InstanceID: "iid-2"}
+
+	// vol-000 has no recorded owner, so it is not counted for a tenant
+	release, err := e.Reserve(ctx, bob, &Request{Volumes: 1})
+	assert.NoError(t, err)
+	release(&types.Volume{ID: "vol-001"})
+	_, err = e.Reserve(ctx, bob, &Request{Volumes: 1})
+	assertQuotaExceeded(t, err, "volumes")
+
+	// alice has her own limits
+	release, err = e.Reserve(ctx, alice, &Request{Volumes: 1})
+	assert.NoError(t, err)
+	release(&types.Volume{ID: "vol-002"})
+	release, err = e.Reserve(
+		ctx, &Owner{Tenant: "alice", InstanceID: "iid-1"},
+		&Request{Volumes: 1})
+	assert.NoError(t, err)
+	release(&types.Volume{ID: "vol-003"})
+
+	_, err = e.Reserve(ctx, &Owner{InstanceID: "iid-1"}, &Request{Volumes: 1})
+	assertQuotaExceeded(t, err, "volumes")
+
+	// a snapshot is attributed to the owner of its volume if the snapshot's
+	// own owner was not recorded
+	release, err = e.Reserve(ctx, bob, &Request{Snapshots: 1})
+	assert.NoError(t, err)
+	release(nil)
+	s.vols = append(s.vols,
+		&types.Volume{ID: "vol-001"},
+		&types.Volume{ID: "vol-002"},
+		&types.Volume{ID: "vol-003"})
+	s.snaps = append(s.snaps,
+		&types.Snapshot{ID: "snap-001", VolumeID: "vol-001"})
+	e.Release()
+	_, err = e.Reserve(ctx, bob, &Request{Snapshots: 1})
+	assertQuotaExceeded(t, err, "snapshots")
+
+	quotas, err := e.Quotas(ctx, bob)
+	assert.NoError(t, err)
+	assert.Len(t, quotas, 3)
+	assert.Equal(t, types.QuotaScopeService, quotas[0].Scope)
+	assert.Nil(t, quotas[0].Limits)
+	assert.EqualValues(t, 4, quotas[0].Usage.Volumes)
+	assert.Equal(t, "bob", quotas[1].Name)
+	assert.EqualValues(t, 1, quotas[1].Limits.Volumes)
+	assert.EqualValues(t, 1, quotas[1].Usage.Volumes)
+	assert.EqualValues(t, 1, quotas[1].Usage.Snapshots)
+	assert.Nil(t, quotas[2].Limits)
+}
+
+func TestOwnersPersisted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "quota")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "quotas", "vfs.json")
+
+	ctx := context.Background()
+	c := &Config{Tenants: map[string]*types.QuotaLimits{
+		"alice": {Volumes: 1},
+	}}
+	alice := &Owner{Tenant: "alice"}
+
+	e, _ := newTestEnforcer(t, c, path)
+	release, err := e.Reserve(ctx, alice, &Request{Volumes: 1})
+	assert.NoError(t, err)
+	release(&types.Volume{ID: "vol-000"})
+
+	e, _ = newTestEnforcer(t, c, path)
+	_, err = e.Reserve(ctx, alice, &Request{Volumes: 1})
+	assertQuotaExceeded(t, err, "volumes")
+}
@@ -0,0 +1,97 @@
+/*
+Package ratelimit limits the rate at which clients make requests to the
+server with token buckets.
+
+Each client has a bucket for the routes that read resources and another for
+the routes that mutate them. A bucket holds up to a burst of tokens and is
+refilled at a constant rate, and a request is allowed only if it can take a
+token from its bucket.
+*/
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// pruneInterval is the number of calls to Allow after which the buckets
+// that are full are removed.
+const pruneInterval = 1000
+
+// Limiter is a set of token buckets, one per key, that are refilled at the
+// same rate.
+type Limiter struct {
+	sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	calls   int
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a new limiter whose buckets are refilled with rate
+// tokens per second and hold up to burst tokens. The burst is at least one
+// token.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = int(math.Ceil(rate))
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the key's bucket. A false value is returned along
+// with the time after which the bucket will have a token if the bucket is
+// empty.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+
+	l.calls++
+	if l.calls >= pruneInterval {
+		l.calls = 0
+		l.prune(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.tokens) / l.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// prune removes the buckets that would be full by now, since they are
+// indistinguishable from new buckets.
+func (l *Limiter) prune(now time.Time) {
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
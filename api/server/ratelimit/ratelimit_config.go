@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+const (
+	// KeyPrincipal keys the buckets by the name of the authenticated
+	// principal that makes a request.
+	KeyPrincipal = "principal"
+
+	// KeyInstanceID keys the buckets by the instance IDs in a request's
+	// InstanceID headers.
+	KeyInstanceID = "instanceID"
+
+	// KeyRemoteAddr keys the buckets by the address of the client that
+	// makes a request.
+	KeyRemoteAddr = "remoteAddr"
+)
+
+// Config is the configuration of the server's rate limits.
+type Config struct {
+
+	// Key is the value by which the buckets are keyed. The remote address
+	// is used when a request has no value for the key.
+	Key string
+
+	// Read is the limiter of the routes that read resources. A nil value
+	// means the routes are not limited.
+	Read *Limiter
+
+	// Mutate is the limiter of the routes that mutate resources. A nil value
+	// means the routes are not limited.
+	Mutate *Limiter
+}
+
+// ParseConfig parses the configuration of the server's rate limits from the
+// libstorage.server.rateLimit section of the server's configuration. A nil
+// value is returned if neither the read nor the mutate rate is positive.
+func ParseConfig(config gofig.Config) (*Config, error) {
+
+	c := &Config{Key: config.GetString(types.ConfigServerRateLimitKey)}
+	switch {
+	case c.Key == "":
+		c.Key = KeyPrincipal
+	case strings.EqualFold(c.Key, KeyPrincipal):
+		c.Key = KeyPrincipal
+	case strings.EqualFold(c.Key, KeyInstanceID):
+		c.Key = KeyInstanceID
+	case strings.EqualFold(c.Key, KeyRemoteAddr):
+		c.Key = KeyRemoteAddr
+	default:
+		return nil, goof.WithFields(goof.Fields{
+			"configKey": types.ConfigServerRateLimitKey,
+			"key":       c.Key,
+		}, "invalid rate limit key")
+	}
+
+	var err error
+	if c.Read, err = parseLimiter(
+		config,
+		types.ConfigServerRateLimitReadRate,
+		types.ConfigServerRateLimitReadBurst); err != nil {
+		return nil, err
+	}
+	if c.Mutate, err = parseLimiter(
+		config,
+		types.ConfigServerRateLimitMutateRate,
+		types.ConfigServerRateLimitMutateBurst); err != nil {
+		return nil, err
+	}
+
+	if c.Read == nil && c.Mutate == nil {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// Limiter returns the limiter of the routes with the provided HTTP method.
+// GET and HEAD routes read resources while all others mutate them.
+func (c *Config) Limiter(method string) *Limiter {
+	switch method {
+	case "GET", "HEAD":
+		return c.Read
+	}
+	return c.Mutate
+}
+
+func parseLimiter(
+	config gofig.Config, rateKey, burstKey string) (*Limiter, error) {
+
+	v := config.GetString(rateKey)
+	if v == "" {
+		return nil, nil
+	}
+	rate, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, goof.WithFieldE("configKey", rateKey, "invalid rate", err)
+	}
+	if rate <= 0 {
+		return nil, nil
+	}
+	return NewLimiter(rate, config.GetInt(burstKey)), nil
+}
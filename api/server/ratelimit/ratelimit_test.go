@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	// the bucket starts full
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("alice")
+		assert.True(t, ok)
+	}
+	ok, wait := l.Allow("alice")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	// the buckets are independent
+	ok, _ = l.Allow("bob")
+	assert.True(t, ok)
+
+	// the bucket is refilled at the rate
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("alice")
+	assert.True(t, ok)
+	ok, _ = l.Allow("alice")
+	assert.False(t, ok)
+
+	// the bucket holds no more than the burst
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("alice")
+		assert.True(t, ok)
+	}
+	ok, _ = l.Allow("alice")
+	assert.False(t, ok)
+
+	l.prune(now.Add(time.Hour))
+	assert.Len(t, l.buckets, 0)
+}
+
+func TestNewLimiterBurst(t *testing.T) {
+	assert.Equal(t, float64(1), NewLimiter(0.5, 0).burst)
+	assert.Equal(t, float64(10), NewLimiter(10, 0).burst)
+	assert.Equal(t, float64(4), NewLimiter(10, 4).burst)
+}
+
+func TestConfigLimiter(t *testing.T) {
+	c := &Config{Read: NewLimiter(1, 1)}
+	assert.Equal(t, c.Read, c.Limiter("GET"))
+	assert.Equal(t, c.Read, c.Limiter("HEAD"))
+	assert.Nil(t, c.Limiter("POST"))
+	assert.Nil(t, c.Limiter("DELETE"))
+}
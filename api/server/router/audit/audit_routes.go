@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/emccode/libstorage/api/context"
+	apiaudit "github.com/emccode/libstorage/api/server/audit"
+	"github.com/emccode/libstorage/api/server/auth"
+	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+func (r *router) audit(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if err := auth.AuthorizeAdmin(ctx, req, store); err != nil {
+		return err
+	}
+
+	log, ok := ctx.Value(context.AuditLogKey).(*apiaudit.Log)
+	if !ok {
+		return utils.NewNotFoundError("audit")
+	}
+
+	// the query parameters are read from the URL rather than the store
+	// since the store parses values such as "1" as booleans
+	params := req.URL.Query()
+	q := &apiaudit.Query{VolumeID: params.Get("volume")}
+	var err error
+	if q.Start, err = parseTime(params, "start"); err != nil {
+		return err
+	}
+	if q.End, err = parseTime(params, "end"); err != nil {
+		return err
+	}
+
+	records, err := log.Query(q)
+	if err != nil {
+		return err
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, records)
+	return nil
+}
+
+// parseTime parses a time from a query parameter that is either an RFC 3339
+// timestamp or the number of seconds since the epoch. The zero time is
+// returned if the parameter is omitted.
+func parseTime(params url.Values, key string) (time.Time, error) {
+	v := params.Get(key)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, utils.NewBadFilterErr(key+"="+v, err)
+	}
+	return t, nil
+}
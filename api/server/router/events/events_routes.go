@@ -0,0 +1,55 @@
+package events
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func (r *router) events(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	query := req.URL.Query()
+	opts := &types.EventsOpts{Services: splitQuery(query["service"])}
+	for _, t := range splitQuery(query["type"]) {
+		opts.Types = append(opts.Types, types.EventType(t))
+	}
+
+	// subscribe before the response's status is written so the client does
+	// not miss the events of operations it requests once it receives the
+	// status. the subscription ends when the client disconnects, which
+	// cancels the request's context and closes the channel.
+	events := services.EventSubscribe(ctx, opts)
+	stream := httputils.NewJSONStream(w, http.StatusOK)
+	for e := range events {
+		if !context.Authorized(ctx, e.Service, types.AuthVerbRead) {
+			continue
+		}
+		if err := stream.Write(e); err != nil {
+			ctx.WithError(err).Debug("error writing event to stream")
+			return nil
+		}
+	}
+	return nil
+}
+
+// splitQuery returns the values of a query string parameter that may be
+// specified more than once as well as hold a comma-separated list.
+func splitQuery(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, sv := range strings.Split(v, ",") {
+			if sv = strings.TrimSpace(sv); sv != "" {
+				result = append(result, sv)
+			}
+		}
+	}
+	return result
+}
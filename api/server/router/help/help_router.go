@@ -6,10 +6,9 @@ import (
 	"os"
 
 	"github.com/emccode/libstorage/api"
-	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/auth"
 	"github.com/emccode/libstorage/api/server/httputils"
 	"github.com/emccode/libstorage/api/types"
-	"github.com/emccode/libstorage/api/utils"
 )
 
 func (r *router) helpInspect(
@@ -50,17 +49,12 @@ func (r *router) configInspect(
 	req *http.Request,
 	store types.Store) error {
 
-	expectedToken, ok := ctx.Value(context.AdminTokenKey).(string)
-	if !ok {
-		return utils.NewBadAdminTokenError("missing")
+	if err := auth.AuthorizeAdmin(ctx, req, store); err != nil {
+		return err
 	}
 
-	actualToken := store.GetString("admin")
-	if expectedToken != actualToken {
-		return utils.NewBadAdminTokenError(actualToken)
-	}
-
-	httputils.WriteJSON(w, http.StatusOK, r.config.AllSettings())
+	httputils.WriteJSON(
+		w, http.StatusOK, types.RedactConfig(r.config.AllSettings()))
 	return nil
 }
 
@@ -70,14 +64,8 @@ func (r *router) envInspect(
 	req *http.Request,
 	store types.Store) error {
 
-	expectedToken, ok := ctx.Value(context.AdminTokenKey).(string)
-	if !ok {
-		return utils.NewBadAdminTokenError("missing")
-	}
-
-	actualToken := store.GetString("admin")
-	if expectedToken != actualToken {
-		return utils.NewBadAdminTokenError(actualToken)
+	if err := auth.AuthorizeAdmin(ctx, req, store); err != nil {
+		return err
 	}
 
 	httputils.WriteJSON(w, http.StatusOK, os.Environ())
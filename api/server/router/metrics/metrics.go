@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/registry"
+	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func init() {
+	registry.RegisterRouter(&router{})
+}
+
+type router struct {
+	config gofig.Config
+	routes []types.Route
+}
+
+func (r *router) Name() string {
+	return "metrics-router"
+}
+
+func (r *router) Init(config gofig.Config) {
+	r.config = config
+	r.initRoutes()
+}
+
+// Routes returns the available routes.
+func (r *router) Routes() []types.Route {
+	return r.routes
+}
+
+func (r *router) initRoutes() {
+
+	r.routes = []types.Route{
+
+		// GET
+		httputils.NewGetRoute(
+			"metrics",
+			"/metrics",
+			r.metrics),
+	}
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/server/auth"
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+	apimetrics "github.com/emccode/libstorage/api/utils/metrics"
+)
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4"
+
+var (
+	taskQueueTasks = apimetrics.NewGauge(
+		"libstorage_task_queue_tasks",
+		"The number of tasks in the services' task queues by state.",
+		"service", "state")
+
+	taskQueueWorkers = apimetrics.NewGauge(
+		"libstorage_task_queue_workers",
+		"The number of workers that execute the services' tasks.",
+		"service")
+
+	taskQueueOldestWait = apimetrics.NewGauge(
+		"libstorage_task_queue_oldest_wait_seconds",
+		"The amount of time the oldest queued task has been waiting.",
+		"service")
+)
+
+func (r *router) metrics(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if err := r.authorize(ctx, req, store); err != nil {
+		return err
+	}
+
+	taskQueueTasks.Reset()
+	taskQueueWorkers.Reset()
+	taskQueueOldestWait.Reset()
+	for service, q := range services.TaskQueues(ctx) {
+		if q == nil {
+			continue
+		}
+		taskQueueTasks.Set(
+			float64(q.Queued), service, string(types.TaskStateQueued))
+		taskQueueTasks.Set(
+			float64(q.Running), service, string(types.TaskStateRunning))
+		taskQueueWorkers.Set(float64(q.Workers), service)
+		taskQueueOldestWait.Set(float64(q.OldestWaitTime)/1000, service)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if err := apimetrics.Write(w); err != nil {
+		ctx.WithError(err).Error("error writing metrics")
+	}
+	return nil
+}
+
+// authorize returns an error unless the request is allowed to scrape the
+// metrics. The token is read from the Authorization header as a bearer token
+// or from the admin query parameter, and it must be either the server's admin
+// token or the token configured for the metrics.
+func (r *router) authorize(
+	ctx types.Context,
+	req *http.Request,
+	store types.Store) error {
+
+	if r.config.GetBool(types.ConfigServerMetricsAnonymous) {
+		return nil
+	}
+
+	return auth.AuthorizeAdmin(
+		ctx, req, store, r.config.GetString(types.ConfigServerMetricsToken))
+}
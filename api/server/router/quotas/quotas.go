@@ -0,0 +1,56 @@
+package quotas
+
+import (
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/registry"
+	"github.com/emccode/libstorage/api/server/handlers"
+	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func init() {
+	registry.RegisterRouter(&router{})
+}
+
+type router struct {
+	config gofig.Config
+	routes []types.Route
+}
+
+func (r *router) Name() string {
+	return "quotas-router"
+}
+
+func (r *router) Init(config gofig.Config) {
+	r.config = config
+	r.initRoutes()
+}
+
+// Routes returns the available routes.
+func (r *router) Routes() []types.Route {
+	return r.routes
+}
+
+func (r *router) initRoutes() {
+
+	r.routes = []types.Route{
+
+		// GET
+
+		// get the quotas of all services
+		httputils.NewGetRoute(
+			"quotas",
+			"/quotas",
+			r.quotas,
+			handlers.NewAuthorizer(types.AuthVerbRead)),
+
+		// get the quotas of a specific service
+		httputils.NewGetRoute(
+			"quotasForService",
+			"/quotas/{service}",
+			r.quotasForService,
+			handlers.NewAuthorizer(types.AuthVerbRead),
+			handlers.NewServiceValidator()),
+	}
+}
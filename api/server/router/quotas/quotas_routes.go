@@ -0,0 +1,70 @@
+package quotas
+
+import (
+	"net/http"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func (r *router) quotas(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	run := func(ctx types.Context) (interface{}, error) {
+
+		reply := types.ServiceQuotaMap{}
+
+		for service := range services.StorageServices(ctx) {
+
+			if !context.Authorized(ctx, service.Name(), types.AuthVerbRead) {
+				continue
+			}
+
+			quotas, err := services.Quotas(
+				context.WithStorageService(ctx, service), service)
+			if err != nil {
+				return nil, err
+			}
+			reply[service.Name()] = quotas
+		}
+
+		return reply, nil
+	}
+
+	return httputils.WriteTask(
+		ctx,
+		r.config,
+		w,
+		store,
+		services.TaskExecute(ctx, run, nil),
+		http.StatusOK)
+}
+
+func (r *router) quotasForService(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	service := context.MustService(ctx)
+
+	run := func(
+		ctx types.Context,
+		svc types.StorageService) (interface{}, error) {
+
+		return services.Quotas(ctx, svc)
+	}
+
+	return httputils.WriteTask(
+		ctx,
+		r.config,
+		w,
+		store,
+		service.TaskExecute(ctx, run, nil),
+		http.StatusOK)
+}
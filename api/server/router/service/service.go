@@ -40,12 +40,14 @@ func (r *router) initRoutes() {
 			"services",
 			"/services",
 			r.servicesList,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewSchemaValidator(nil, schema.ServiceInfoMapSchema, nil)),
 
 		httputils.NewGetRoute(
 			"serviceInspect",
 			"/services/{service}",
 			r.serviceInspect,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(nil, schema.ServiceInfoSchema, nil)),
 	}
@@ -18,6 +18,9 @@ func (r *router) servicesList(
 
 	reply := map[string]*types.ServiceInfo{}
 	for service := range services.StorageServices(ctx) {
+		if !context.Authorized(ctx, service.Name(), types.AuthVerbRead) {
+			continue
+		}
 		ctx := context.WithStorageService(ctx, service)
 		si, err := toServiceInfo(ctx, service, store)
 		if err != nil {
@@ -79,9 +82,11 @@ func toServiceInfo(
 		Name:     service.Name(),
 		Instance: instance,
 		Driver: &types.DriverInfo{
-			Name:       d.Name(),
-			Type:       st,
-			NextDevice: nd,
+			Name:         d.Name(),
+			Type:         st,
+			NextDevice:   nd,
+			Capabilities: services.Capabilities(service),
 		},
+		TaskQueue: service.TaskQueueInfo(),
 	}, nil
 }
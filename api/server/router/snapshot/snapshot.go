@@ -43,6 +43,7 @@ func (r *router) initRoutes() {
 			"snapshots",
 			"/snapshots",
 			r.snapshots,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewSchemaValidator(
 				nil, schema.ServiceSnapshotMapSchema, nil),
 		),
@@ -52,7 +53,10 @@ func (r *router) initRoutes() {
 			"snapshotsForService",
 			"/snapshots/{service}",
 			r.snapshotsForService,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilitySnapshots),
 			handlers.NewSchemaValidator(
 				nil, schema.SnapshotMapSchema, nil),
 		),
@@ -62,7 +66,10 @@ func (r *router) initRoutes() {
 			"snapshotInspect",
 			"/snapshots/{service}/{snapshotID}",
 			r.snapshotInspect,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilitySnapshots),
 			handlers.NewSchemaValidator(nil, schema.SnapshotSchema, nil),
 		),
 
@@ -73,7 +80,10 @@ func (r *router) initRoutes() {
 			"snapshotCreate",
 			"/snapshots/{service}/{snapshotID}",
 			r.volumeCreate,
+			handlers.NewAuthorizer(types.AuthVerbCreate),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilityCreateFromSnapshot),
 			handlers.NewSchemaValidator(
 				schema.VolumeCreateRequestSchema,
 				schema.VolumeSchema,
@@ -86,7 +96,10 @@ func (r *router) initRoutes() {
 			"snapshotCopy",
 			"/snapshots/{service}/{snapshotID}",
 			r.snapshotCopy,
+			handlers.NewAuthorizer(types.AuthVerbSnapshot),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilitySnapshotCopy),
 			handlers.NewSchemaValidator(
 				schema.SnapshotCopyRequestSchema,
 				schema.SnapshotSchema,
@@ -101,7 +114,10 @@ func (r *router) initRoutes() {
 			"snapshotRemove",
 			"/snapshots/{service}/{snapshotID}",
 			r.snapshotRemove,
+			handlers.NewAuthorizer(types.AuthVerbRemove),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilitySnapshots),
 		),
 	}
 }
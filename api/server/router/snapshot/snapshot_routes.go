@@ -6,10 +6,13 @@ import (
 	"github.com/akutz/goof"
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/server/quota"
 	"github.com/emccode/libstorage/api/server/router/volume"
 	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/server/tenants"
 	"github.com/emccode/libstorage/api/types"
 	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/filters"
 	"github.com/emccode/libstorage/api/utils/schema"
 )
 
@@ -19,6 +22,16 @@ func (r *router) snapshots(
 	req *http.Request,
 	store types.Store) error {
 
+	filter, err := httputils.ParseFilter(store)
+	if err != nil {
+		return err
+	}
+
+	page, err := httputils.ParsePage(req)
+	if err != nil {
+		return err
+	}
+
 	var (
 		tasks   = map[string]*types.Task{}
 		taskIDs []int
@@ -27,22 +40,22 @@ func (r *router) snapshots(
 
 	for service := range services.StorageServices(ctx) {
 
+		if !context.Authorized(ctx, service.Name(), types.AuthVerbRead) {
+			continue
+		}
+
+		// the services whose drivers do not support snapshots are omitted
+		if !services.Capabilities(service).Supports(
+			types.StorageCapabilitySnapshots) {
+			continue
+		}
+
 		run := func(
 			ctx types.Context,
 			svc types.StorageService) (interface{}, error) {
 
 			ctx = context.WithStorageService(ctx, svc)
-
-			objs, err := svc.Driver().Snapshots(ctx, store)
-			if err != nil {
-				return nil, err
-			}
-
-			objMap := map[string]*types.Snapshot{}
-			for _, obj := range objs {
-				objMap[obj.ID] = obj
-			}
-			return objMap, nil
+			return getFilteredSnapshots(ctx, store, svc, filter)
 		}
 
 		task := service.TaskExecute(ctx, run, schema.SnapshotMapSchema)
@@ -59,14 +72,20 @@ func (r *router) snapshots(
 				return nil, utils.NewBatchProcessErr(reply, v.Error)
 			}
 
-			objMap, ok := v.Result.(map[string]*types.Snapshot)
+			objMap, ok := v.Result.(types.SnapshotMap)
 			if !ok {
 				return nil, utils.NewBatchProcessErr(
-					reply, goof.New("error casting to []*types.Snapshot"))
+					reply, goof.New("error casting to types.SnapshotMap"))
 			}
 			reply[k] = objMap
 		}
 
+		if page != nil {
+			var token string
+			reply, token = page.ServiceSnapshotMap(reply)
+			httputils.SetNextPageToken(store, token)
+		}
+
 		return reply, nil
 	}
 
@@ -85,23 +104,34 @@ func (r *router) snapshotsForService(
 	req *http.Request,
 	store types.Store) error {
 
+	filter, err := httputils.ParseFilter(store)
+	if err != nil {
+		return err
+	}
+
+	page, err := httputils.ParsePage(req)
+	if err != nil {
+		return err
+	}
+
 	service := context.MustService(ctx)
 
 	run := func(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		var reply types.SnapshotMap = map[string]*types.Snapshot{}
-
-		objs, err := svc.Driver().Snapshots(ctx, store)
+		objMap, err := getFilteredSnapshots(ctx, store, svc, filter)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, obj := range objs {
-			reply[obj.ID] = obj
+		if page != nil {
+			var token string
+			objMap, token = page.SnapshotMap(svc.Name(), objMap)
+			httputils.SetNextPageToken(store, token)
 		}
-		return reply, nil
+
+		return objMap, nil
 	}
 
 	return httputils.WriteTask(
@@ -113,6 +143,36 @@ func (r *router) snapshotsForService(
 		http.StatusOK)
 }
 
+func getFilteredSnapshots(
+	ctx types.Context,
+	store types.Store,
+	storSvc types.StorageService,
+	filter *types.Filter) (types.SnapshotMap, error) {
+
+	objs, err := storSvc.Driver().Snapshots(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	// a tenant sees only the snapshots of the volumes in its namespace
+	volIDs, err := tenants.VolumeIDs(ctx, storSvc.Driver(), store)
+	if err != nil {
+		return nil, err
+	}
+
+	objMap := types.SnapshotMap{}
+	for _, obj := range objs {
+		if volIDs != nil && !volIDs[obj.VolumeID] {
+			continue
+		}
+		if filter != nil && !filters.MatchSnapshot(filter, obj) {
+			continue
+		}
+		objMap[obj.ID] = obj
+	}
+	return objMap, nil
+}
+
 func (r *router) snapshotInspect(
 	ctx types.Context,
 	w http.ResponseWriter,
@@ -125,6 +185,14 @@ func (r *router) snapshotInspect(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.SnapshotCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("snapshotID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		return svc.Driver().SnapshotInspect(
 			ctx,
 			store.GetString("snapshotID"),
@@ -152,12 +220,24 @@ func (r *router) snapshotRemove(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.SnapshotCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("snapshotID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		return nil, svc.Driver().SnapshotRemove(
 			ctx,
 			store.GetString("snapshotID"),
 			store)
 	}
 
+	run = services.QuotaReleaseRunFunc(run)
+	run = services.EventRunFunc(
+		types.EventSnapshotRemove, "", store.GetString("snapshotID"), run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -174,15 +254,27 @@ func (r *router) volumeCreate(
 	store types.Store) error {
 
 	service := context.MustService(ctx)
+	if err := services.CheckVolumeSize(
+		service, store.GetInt64Ptr("size")); err != nil {
+		return err
+	}
 
 	run := func(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.SnapshotCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("snapshotID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		v, err := svc.Driver().VolumeCreateFromSnapshot(
 			ctx,
 			store.GetString("snapshotID"),
-			store.GetString("name"),
+			tenants.VolumeName(ctx, store.GetString("name")),
 			&types.VolumeCreateOpts{
 				AvailabilityZone: store.GetStringPtr("availabilityZone"),
 				IOPS:             store.GetInt64Ptr("iops"),
@@ -195,6 +287,8 @@ func (r *router) volumeCreate(
 			return nil, err
 		}
 
+		tenants.Volume(ctx, v)
+
 		if volume.OnVolume != nil {
 			ok, err := volume.OnVolume(ctx, req, store, v)
 			if err != nil {
@@ -208,6 +302,15 @@ func (r *router) volumeCreate(
 		return v, nil
 	}
 
+	run = services.QuotaRunFunc(
+		&quota.Request{
+			Volumes:    1,
+			Size:       store.GetInt64Ptr("size"),
+			SnapshotID: store.GetString("snapshotID"),
+		}, run)
+	run = services.EventRunFunc(
+		types.EventVolumeCreateFromSnapshot, "", store.GetString("snapshotID"), run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -229,6 +332,14 @@ func (r *router) snapshotCopy(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.SnapshotCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("snapshotID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		return svc.Driver().SnapshotCopy(
 			ctx,
 			store.GetString("snapshotID"),
@@ -237,6 +348,10 @@ func (r *router) snapshotCopy(
 			store)
 	}
 
+	run = services.QuotaRunFunc(&quota.Request{Snapshots: 1}, run)
+	run = services.EventRunFunc(
+		types.EventSnapshotCopy, "", store.GetString("snapshotID"), run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
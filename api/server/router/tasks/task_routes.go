@@ -3,7 +3,11 @@ package tasks
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/server/httputils"
 	"github.com/emccode/libstorage/api/server/services"
 	"github.com/emccode/libstorage/api/types"
@@ -16,25 +20,151 @@ func (r *router) tasks(
 	req *http.Request,
 	store types.Store) error {
 
+	page, err := httputils.ParsePage(req)
+	if err != nil {
+		return err
+	}
+
 	tasks := map[string]*types.Task{}
-	for t := range services.Tasks(ctx) {
-		tasks[fmt.Sprintf("%d", t.ID)] = t
+
+	if page == nil {
+		for t := range services.Tasks(ctx) {
+			if !readable(ctx, t) {
+				continue
+			}
+			tasks[fmt.Sprintf("%d", t.ID)] = t
+		}
+	} else {
+		taskList := []*types.Task{}
+		for t := range services.Tasks(ctx) {
+			if !readable(ctx, t) {
+				continue
+			}
+			taskList = append(taskList, t)
+		}
+		var token string
+		if tasks, token = page.TaskMap(taskList); token != "" {
+			w.Header().Set(types.NextPageTokenHeader, token)
+		}
 	}
+
 	httputils.WriteJSON(w, http.StatusOK, tasks)
 	return nil
 }
 
+// readable returns a flag indicating whether or not the context's principal
+// may read the task's service. A task that does not operate on a service is
+// readable by a principal that may read any service.
+func readable(ctx types.Context, t *types.Task) bool {
+	return context.Authorized(ctx, t.Service, types.AuthVerbRead)
+}
+
+func (r *router) taskQueues(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	httputils.WriteJSON(w, http.StatusOK, services.TaskQueues(ctx))
+	return nil
+}
+
+func (r *router) tasksWatch(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	// watch before the response's status is written so the client does not
+	// miss the state changes of tasks it creates once it receives the
+	// status. the watch ends when the client disconnects, which cancels the
+	// request's context and closes the channel.
+	tasks := services.TaskWatch(ctx)
+	stream := httputils.NewJSONStream(w, http.StatusOK)
+	for t := range tasks {
+		if !readable(ctx, t) {
+			continue
+		}
+		if err := stream.Write(t); err != nil {
+			ctx.WithError(err).Debug("error writing task to watch stream")
+			return nil
+		}
+	}
+	return nil
+}
+
 func (r *router) taskInspect(
 	ctx types.Context,
 	w http.ResponseWriter,
 	req *http.Request,
 	store types.Store) error {
 
-	task := services.TaskInspect(ctx, store.GetInt("taskID"))
+	taskID := store.GetInt("taskID")
+
+	task := services.TaskInspect(ctx, taskID)
 	if task == nil {
 		return utils.NewNotFoundError(store.GetString("taskID"))
 	}
 
+	// the route is not scoped to a service, so the principal is authorized
+	// for the task's service once the task is known
+	if !readable(ctx, task) {
+		p, _ := context.Principal(ctx)
+		return utils.NewForbiddenErr(p.Name, task.Service, types.AuthVerbRead)
+	}
+
+	if wait := req.URL.Query().Get("wait"); wait != "" {
+		waitDur, err := time.ParseDuration(wait)
+		if err != nil {
+			return goof.WithFieldE("wait", wait, "invalid wait duration", err)
+		}
+		waitTimeout := time.NewTimer(waitDur)
+		defer waitTimeout.Stop()
+
+		// respond with the task's current state if the task does not
+		// complete before the wait expires
+		select {
+		case <-services.TaskWaitC(ctx, taskID):
+		case <-waitTimeout.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
 	httputils.WriteJSON(w, http.StatusOK, task)
 	return nil
 }
+
+func (r *router) taskCancel(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	taskID := store.GetInt("taskID")
+
+	task := services.TaskCancel(ctx, taskID)
+	if task == nil {
+		return utils.NewNotFoundError(store.GetString("taskID"))
+	}
+
+	exeTimeoutDur, err := time.ParseDuration(
+		r.config.GetString(types.ConfigServerTasksExeTimeout))
+	if err != nil {
+		exeTimeoutDur = time.Duration(time.Second * 60)
+	}
+	exeTimeout := time.NewTimer(exeTimeoutDur)
+	defer exeTimeout.Stop()
+
+	// a running task is canceled only once its run function returns, so wait
+	// for that to happen before responding with the task's final state
+	select {
+	case <-services.TaskWaitC(ctx, taskID):
+		httputils.WriteJSON(w, http.StatusOK, task)
+	case <-exeTimeout.C:
+		httputils.WriteJSON(w, http.StatusAccepted, task)
+	case <-ctx.Done():
+	}
+
+	return nil
+}
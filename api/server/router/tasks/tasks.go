@@ -3,6 +3,7 @@ package tasks
 import (
 	"github.com/akutz/gofig"
 	"github.com/emccode/libstorage/api/registry"
+	"github.com/emccode/libstorage/api/server/handlers"
 	"github.com/emccode/libstorage/api/server/httputils"
 	"github.com/emccode/libstorage/api/types"
 )
@@ -12,6 +13,7 @@ func init() {
 }
 
 type router struct {
+	config gofig.Config
 	routes []types.Route
 }
 
@@ -20,6 +22,7 @@ func (r *router) Name() string {
 }
 
 func (r *router) Init(config gofig.Config) {
+	r.config = config
 	r.initRoutes()
 }
 
@@ -32,16 +35,39 @@ func (r *router) initRoutes() {
 
 	r.routes = []types.Route{
 
+		// GET
+		httputils.NewGetRoute(
+			"taskQueues",
+			"/tasks",
+			r.taskQueues,
+			handlers.NewAuthorizer(types.AuthVerbRead)).Queries("queues"),
+
+		// GET
+		httputils.NewGetRoute(
+			"tasksWatch",
+			"/tasks",
+			r.tasksWatch,
+			handlers.NewAuthorizer(types.AuthVerbRead)).Queries("watch").Streaming(),
+
 		// GET
 		httputils.NewGetRoute(
 			"tasks",
 			"/tasks",
-			r.tasks),
+			r.tasks,
+			handlers.NewAuthorizer(types.AuthVerbRead)),
 
 		// GET
 		httputils.NewGetRoute(
 			"taskInspect",
 			"/tasks/{taskID}",
-			r.taskInspect),
+			r.taskInspect,
+			handlers.NewAuthorizer(types.AuthVerbRead)),
+
+		// DELETE
+		httputils.NewDeleteRoute(
+			"taskCancel",
+			"/tasks/{taskID}",
+			r.taskCancel,
+			handlers.NewTaskAuthorizer()),
 	}
 }
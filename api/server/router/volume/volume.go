@@ -56,6 +56,7 @@ func (r *router) initRoutes() {
 			"volumes",
 			"/volumes",
 			r.volumes,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewSchemaValidator(nil, schema.ServiceVolumeMapSchema, nil),
 		),
 
@@ -64,6 +65,7 @@ func (r *router) initRoutes() {
 			"volumesForService",
 			"/volumes/{service}",
 			r.volumesForService,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(nil, schema.VolumeMapSchema, nil),
 		),
@@ -73,6 +75,7 @@ func (r *router) initRoutes() {
 			"volumeInspect",
 			"/volumes/{service}/{volumeID}",
 			r.volumeInspect,
+			handlers.NewAuthorizer(types.AuthVerbRead),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(nil, schema.VolumeSchema, nil),
 		),
@@ -84,6 +87,7 @@ func (r *router) initRoutes() {
 			"volumesDetachForService",
 			"/volumes/{service}",
 			r.volumeDetachAllForService,
+			handlers.NewAuthorizer(types.AuthVerbAttach),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(
 				schema.VolumeDetachRequestSchema,
@@ -97,6 +101,7 @@ func (r *router) initRoutes() {
 			"volumeCreate",
 			"/volumes/{service}",
 			r.volumeCreate,
+			handlers.NewAuthorizer(types.AuthVerbCreate),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(
 				schema.VolumeCreateRequestSchema,
@@ -110,7 +115,10 @@ func (r *router) initRoutes() {
 			"volumeCopy",
 			"/volumes/{service}/{volumeID}",
 			r.volumeCopy,
+			handlers.NewAuthorizer(types.AuthVerbCreate),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilityVolumeCopy),
 			handlers.NewSchemaValidator(
 				schema.VolumeCopyRequestSchema,
 				schema.VolumeSchema,
@@ -118,12 +126,31 @@ func (r *router) initRoutes() {
 			handlers.NewPostArgsHandler(),
 		).Queries("copy"),
 
+		// expand an existing volume
+		httputils.NewPostRoute(
+			"volumeResize",
+			"/volumes/{service}/{volumeID}",
+			r.volumeResize,
+			handlers.NewAuthorizer(types.AuthVerbCreate),
+			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilityResize),
+			handlers.NewSchemaValidator(
+				schema.VolumeResizeRequestSchema,
+				schema.VolumeSchema,
+				func() interface{} { return &types.VolumeResizeRequest{} }),
+			handlers.NewPostArgsHandler(),
+		).Queries("resize"),
+
 		// snapshot an existing volume
 		httputils.NewPostRoute(
 			"volumeSnapshot",
 			"/volumes/{service}/{volumeID}",
 			r.volumeSnapshot,
+			handlers.NewAuthorizer(types.AuthVerbSnapshot),
 			handlers.NewServiceValidator(),
+			handlers.NewCapabilityValidator(
+				types.StorageCapabilitySnapshots),
 			handlers.NewSchemaValidator(
 				schema.VolumeSnapshotRequestSchema,
 				schema.SnapshotSchema,
@@ -136,6 +163,7 @@ func (r *router) initRoutes() {
 			"volumeAttach",
 			"/volumes/{service}/{volumeID}",
 			r.volumeAttach,
+			handlers.NewAuthorizer(types.AuthVerbAttach),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(
 				schema.VolumeAttachRequestSchema,
@@ -149,6 +177,7 @@ func (r *router) initRoutes() {
 			"volumesDetachAll",
 			"/volumes",
 			r.volumeDetachAll,
+			handlers.NewAuthorizer(types.AuthVerbAttach),
 			handlers.NewSchemaValidator(
 				schema.VolumeDetachRequestSchema,
 				schema.ServiceVolumeMapSchema,
@@ -161,6 +190,7 @@ func (r *router) initRoutes() {
 			"volumeDetach",
 			"/volumes/{service}/{volumeID}",
 			r.volumeDetach,
+			handlers.NewAuthorizer(types.AuthVerbAttach),
 			handlers.NewServiceValidator(),
 			handlers.NewSchemaValidator(
 				schema.VolumeDetachRequestSchema,
@@ -174,6 +204,7 @@ func (r *router) initRoutes() {
 			"volumeRemove",
 			"/volumes/{service}/{volumeID}",
 			r.volumeRemove,
+			handlers.NewAuthorizer(types.AuthVerbRemove),
 			handlers.NewServiceValidator(),
 		),
 	}
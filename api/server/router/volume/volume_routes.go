@@ -9,7 +9,9 @@ import (
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/server/httputils"
+	"github.com/emccode/libstorage/api/server/quota"
 	"github.com/emccode/libstorage/api/server/services"
+	"github.com/emccode/libstorage/api/server/tenants"
 	"github.com/emccode/libstorage/api/types"
 	"github.com/emccode/libstorage/api/utils"
 	"github.com/emccode/libstorage/api/utils/filters"
@@ -22,12 +24,14 @@ func (r *router) volumes(
 	req *http.Request,
 	store types.Store) error {
 
-	filter, err := parseFilter(store)
+	filter, err := httputils.ParseFilter(store)
 	if err != nil {
 		return err
 	}
-	if filter != nil {
-		store.Set("filter", filter)
+
+	page, err := httputils.ParsePage(req)
+	if err != nil {
+		return err
 	}
 
 	var (
@@ -42,6 +46,10 @@ func (r *router) volumes(
 
 	for service := range services.StorageServices(ctx) {
 
+		if !context.Authorized(ctx, service.Name(), types.AuthVerbRead) {
+			continue
+		}
+
 		run := func(
 			ctx types.Context,
 			svc types.StorageService) (interface{}, error) {
@@ -72,6 +80,12 @@ func (r *router) volumes(
 			reply[k] = objMap
 		}
 
+		if page != nil {
+			var token string
+			reply, token = page.ServiceVolumeMap(reply)
+			httputils.SetNextPageToken(store, token)
+		}
+
 		return reply, nil
 	}
 
@@ -90,12 +104,14 @@ func (r *router) volumesForService(
 	req *http.Request,
 	store types.Store) error {
 
-	filter, err := parseFilter(store)
+	filter, err := httputils.ParseFilter(store)
 	if err != nil {
 		return err
 	}
-	if filter != nil {
-		store.Set("filter", filter)
+
+	page, err := httputils.ParsePage(req)
+	if err != nil {
+		return err
 	}
 
 	service := context.MustService(ctx)
@@ -109,7 +125,19 @@ func (r *router) volumesForService(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		return getFilteredVolumes(ctx, req, store, svc, opts, filter)
+		objMap, err := getFilteredVolumes(
+			ctx, req, store, svc, opts, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if page != nil {
+			var token string
+			objMap, token = page.VolumeMap(svc.Name(), objMap)
+			httputils.SetNextPageToken(store, token)
+		}
+
+		return objMap, nil
 	}
 
 	return httputils.WriteTask(
@@ -129,12 +157,7 @@ func getFilteredVolumes(
 	opts *types.VolumesOpts,
 	filter *types.Filter) (types.VolumeMap, error) {
 
-	var (
-		filterOp    types.FilterOperator
-		filterLeft  string
-		filterRight string
-		objMap      = types.VolumeMap{}
-	)
+	objMap := types.VolumeMap{}
 
 	iid, iidOK := context.InstanceID(ctx)
 	if opts.Attachments && !iidOK {
@@ -151,18 +174,14 @@ func getFilteredVolumes(
 		lcaseIID = strings.ToLower(iid.ID)
 	}
 
-	if filter != nil {
-		filterOp = filter.Op
-		filterLeft = strings.ToLower(filter.Left)
-		filterRight = strings.ToLower(filter.Right)
-	}
-
 	for _, obj := range objs {
 
-		if filterOp == types.FilterEqualityMatch && filterLeft == "name" {
-			if strings.ToLower(obj.Name) != filterRight {
-				continue
-			}
+		if !tenants.Volume(ctx, obj) {
+			continue
+		}
+
+		if filter != nil && !filters.MatchVolume(filter, obj) {
+			continue
 		}
 
 		if opts.Attachments {
@@ -232,6 +251,9 @@ func (r *router) volumeInspect(
 
 			volID := strings.ToLower(store.GetString("volumeID"))
 			for _, v := range vols {
+				if !tenants.Volume(ctx, v) {
+					continue
+				}
 				if strings.ToLower(v.Name) == volID {
 
 					if OnVolume != nil {
@@ -264,6 +286,10 @@ func (r *router) volumeInspect(
 				return nil, err
 			}
 
+			if !tenants.Volume(ctx, v) {
+				return nil, utils.NewNotFoundError(v.ID)
+			}
+
 			if OnVolume != nil {
 				ok, err := OnVolume(ctx, req, store, v)
 				if err != nil {
@@ -294,6 +320,10 @@ func (r *router) volumeCreate(
 	store types.Store) error {
 
 	service := context.MustService(ctx)
+	if err := services.CheckVolumeSize(
+		service, store.GetInt64Ptr("size")); err != nil {
+		return err
+	}
 
 	run := func(
 		ctx types.Context,
@@ -301,7 +331,7 @@ func (r *router) volumeCreate(
 
 		v, err := svc.Driver().VolumeCreate(
 			ctx,
-			store.GetString("name"),
+			tenants.VolumeName(ctx, store.GetString("name")),
 			&types.VolumeCreateOpts{
 				AvailabilityZone: store.GetStringPtr("availabilityZone"),
 				IOPS:             store.GetInt64Ptr("iops"),
@@ -314,6 +344,8 @@ func (r *router) volumeCreate(
 			return nil, err
 		}
 
+		tenants.Volume(ctx, v)
+
 		if OnVolume != nil {
 			ok, err := OnVolume(ctx, req, store, v)
 			if err != nil {
@@ -327,6 +359,10 @@ func (r *router) volumeCreate(
 		return v, nil
 	}
 
+	run = services.QuotaRunFunc(
+		&quota.Request{Volumes: 1, Size: store.GetInt64Ptr("size")}, run)
+	run = services.EventRunFunc(types.EventVolumeCreate, "", "", run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -348,16 +384,26 @@ func (r *router) volumeCopy(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.VolumeCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("volumeID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		v, err := svc.Driver().VolumeCopy(
 			ctx,
 			store.GetString("volumeID"),
-			store.GetString("volumeName"),
+			tenants.VolumeName(ctx, store.GetString("volumeName")),
 			store)
 
 		if err != nil {
 			return nil, err
 		}
 
+		tenants.Volume(ctx, v)
+
 		if OnVolume != nil {
 			ok, err := OnVolume(ctx, req, store, v)
 			if err != nil {
@@ -371,6 +417,11 @@ func (r *router) volumeCopy(
 		return v, nil
 	}
 
+	run = services.QuotaRunFunc(
+		&quota.Request{Volumes: 1, VolumeID: store.GetString("volumeID")}, run)
+	run = services.EventRunFunc(
+		types.EventVolumeCopy, store.GetString("volumeID"), "", run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -380,6 +431,76 @@ func (r *router) volumeCopy(
 		http.StatusCreated)
 }
 
+func (r *router) volumeResize(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	service := context.MustService(ctx)
+	if err := services.CheckVolumeSize(
+		service, store.GetInt64Ptr("size")); err != nil {
+		return err
+	}
+
+	run := func(
+		ctx types.Context,
+		svc types.StorageService) (interface{}, error) {
+
+		if err := tenants.VolumeCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("volumeID"),
+			store); err != nil {
+			return nil, err
+		}
+
+		v, err := svc.Driver().VolumeResize(
+			ctx,
+			store.GetString("volumeID"),
+			&types.VolumeResizeOpts{
+				Size:  store.GetInt64("size"),
+				Force: store.GetBool("force"),
+				Opts:  store,
+			})
+
+		if err != nil {
+			return nil, err
+		}
+
+		tenants.Volume(ctx, v)
+
+		if OnVolume != nil {
+			ok, err := OnVolume(ctx, req, store, v)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, utils.NewNotFoundError(v.ID)
+			}
+		}
+
+		return v, nil
+	}
+
+	run = services.QuotaRunFunc(
+		&quota.Request{
+			VolumeID: store.GetString("volumeID"),
+			Size:     store.GetInt64Ptr("size"),
+			Resize:   true,
+		}, run)
+	run = services.EventRunFunc(
+		types.EventVolumeResize, store.GetString("volumeID"), "", run)
+
+	return httputils.WriteTask(
+		ctx,
+		r.config,
+		w,
+		store,
+		service.TaskExecute(ctx, run, schema.VolumeSchema),
+		http.StatusOK)
+}
+
 func (r *router) volumeSnapshot(
 	ctx types.Context,
 	w http.ResponseWriter,
@@ -392,6 +513,14 @@ func (r *router) volumeSnapshot(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.VolumeCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("volumeID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		return svc.Driver().VolumeSnapshot(
 			ctx,
 			store.GetString("volumeID"),
@@ -399,6 +528,10 @@ func (r *router) volumeSnapshot(
 			store)
 	}
 
+	run = services.QuotaRunFunc(&quota.Request{Snapshots: 1}, run)
+	run = services.EventRunFunc(
+		types.EventVolumeSnapshot, store.GetString("volumeID"), "", run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -423,6 +556,21 @@ func (r *router) volumeAttach(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.VolumeCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("volumeID"),
+			store); err != nil {
+			return nil, err
+		}
+
+		if !store.GetBool("force") {
+			if err := checkMultiAttach(
+				ctx, svc, store.GetString("volumeID"), store); err != nil {
+				return nil, err
+			}
+		}
+
 		v, attTokn, err := svc.Driver().VolumeAttach(
 			ctx,
 			store.GetString("volumeID"),
@@ -436,6 +584,8 @@ func (r *router) volumeAttach(
 			return nil, err
 		}
 
+		tenants.Volume(ctx, v)
+
 		if OnVolume != nil {
 			ok, err := OnVolume(ctx, req, store, v)
 			if err != nil {
@@ -452,6 +602,9 @@ func (r *router) volumeAttach(
 		}, nil
 	}
 
+	run = services.EventRunFunc(
+		types.EventVolumeAttach, store.GetString("volumeID"), "", run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -461,6 +614,39 @@ func (r *router) volumeAttach(
 		http.StatusOK)
 }
 
+// checkMultiAttach returns a not implemented error if the service's driver
+// does not support attaching a volume to more than one instance and the volume
+// is attached to an instance other than the one in the context.
+func checkMultiAttach(
+	ctx types.Context,
+	svc types.StorageService,
+	volumeID string,
+	store types.Store) error {
+
+	if services.Capabilities(svc).Supports(types.StorageCapabilityMultiAttach) {
+		return nil
+	}
+
+	iid, ok := context.InstanceID(ctx)
+	if !ok {
+		return nil
+	}
+
+	v, err := svc.Driver().VolumeInspect(
+		ctx, volumeID, &types.VolumeInspectOpts{Attachments: true, Opts: store})
+	if err != nil {
+		return err
+	}
+
+	for _, a := range v.Attachments {
+		if a.InstanceID != nil && !strings.EqualFold(a.InstanceID.ID, iid.ID) {
+			return utils.NewNotImplementedErr(
+				svc.Name(), types.StorageCapabilityMultiAttach)
+		}
+	}
+	return nil
+}
+
 func (r *router) volumeDetach(
 	ctx types.Context,
 	w http.ResponseWriter,
@@ -476,6 +662,14 @@ func (r *router) volumeDetach(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.VolumeCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("volumeID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		v, err := svc.Driver().VolumeDetach(
 			ctx,
 			store.GetString("volumeID"),
@@ -488,6 +682,8 @@ func (r *router) volumeDetach(
 			return nil, err
 		}
 
+		tenants.Volume(ctx, v)
+
 		if v != nil && OnVolume != nil {
 			ok, err := OnVolume(ctx, req, store, v)
 			if err != nil {
@@ -501,12 +697,15 @@ func (r *router) volumeDetach(
 		return v, nil
 	}
 
+	run = services.EventRunFunc(
+		types.EventVolumeDetach, store.GetString("volumeID"), "", run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
 		w,
 		store,
-		service.TaskExecute(ctx, run, nil),
+		service.TaskEnqueue(ctx, run, nil, types.TaskPriorityHigh),
 		http.StatusResetContent)
 }
 
@@ -526,6 +725,10 @@ func (r *router) volumeDetachAll(
 
 	for service := range services.StorageServices(ctx) {
 
+		if !context.Authorized(ctx, service.Name(), types.AuthVerbAttach) {
+			continue
+		}
+
 		run := func(
 			ctx types.Context,
 			svc types.StorageService) (interface{}, error) {
@@ -553,6 +756,9 @@ func (r *router) volumeDetachAll(
 			}()
 
 			for _, volume := range volumes {
+				if !tenants.Volume(ctx, volume) {
+					continue
+				}
 				v, err := driver.VolumeDetach(
 					ctx,
 					volume.ID,
@@ -560,6 +766,9 @@ func (r *router) volumeDetachAll(
 						Force: store.GetBool("force"),
 						Opts:  store,
 					})
+				services.EventPublish(
+					ctx, types.EventVolumeDetach, svc.Name(), volume.ID, "",
+					v, err)
 				if err != nil {
 					return nil, err
 				}
@@ -584,7 +793,7 @@ func (r *router) volumeDetachAll(
 			return nil, nil
 		}
 
-		task := service.TaskExecute(ctx, run, nil)
+		task := service.TaskEnqueue(ctx, run, nil, types.TaskPriorityHigh)
 		taskIDs = append(taskIDs, task.ID)
 		tasks[service.Name()] = task
 	}
@@ -633,6 +842,9 @@ func (r *router) volumeDetachAllForService(
 		}
 
 		for _, volume := range volumes {
+			if !tenants.Volume(ctx, volume) {
+				continue
+			}
 			v, err := driver.VolumeDetach(
 				ctx,
 				volume.ID,
@@ -640,6 +852,8 @@ func (r *router) volumeDetachAllForService(
 					Force: store.GetBool("force"),
 					Opts:  store,
 				})
+			services.EventPublish(
+				ctx, types.EventVolumeDetach, svc.Name(), volume.ID, "", v, err)
 			if err != nil {
 				return nil, utils.NewBatchProcessErr(reply, err)
 			}
@@ -669,7 +883,8 @@ func (r *router) volumeDetachAllForService(
 		r.config,
 		w,
 		store,
-		service.TaskExecute(ctx, run, schema.VolumeMapSchema),
+		service.TaskEnqueue(
+			ctx, run, schema.VolumeMapSchema, types.TaskPriorityHigh),
 		http.StatusResetContent)
 }
 
@@ -685,12 +900,24 @@ func (r *router) volumeRemove(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
+		if err := tenants.VolumeCheck(
+			ctx,
+			svc.Driver(),
+			store.GetString("volumeID"),
+			store); err != nil {
+			return nil, err
+		}
+
 		return nil, svc.Driver().VolumeRemove(
 			ctx,
 			store.GetString("volumeID"),
 			store)
 	}
 
+	run = services.QuotaReleaseRunFunc(run)
+	run = services.EventRunFunc(
+		types.EventVolumeRemove, store.GetString("volumeID"), "", run)
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -699,15 +926,3 @@ func (r *router) volumeRemove(
 		service.TaskExecute(ctx, run, nil),
 		http.StatusNoContent)
 }
-
-func parseFilter(store types.Store) (*types.Filter, error) {
-	if !store.IsSet("filter") {
-		return nil, nil
-	}
-	fsz := store.GetString("filter")
-	filter, err := filters.CompileFilter(fsz)
-	if err != nil {
-		return nil, utils.NewBadFilterErr(fsz, err)
-	}
-	return filter, nil
-}
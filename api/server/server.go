@@ -16,6 +16,8 @@ import (
 	"github.com/akutz/gofig"
 
 	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/audit"
+	"github.com/emccode/libstorage/api/server/idempotency"
 	"github.com/emccode/libstorage/api/server/services"
 	"github.com/emccode/libstorage/api/types"
 	"github.com/emccode/libstorage/api/utils"
@@ -43,9 +45,8 @@ type server struct {
 	closedSignal chan int
 	closeOnce    *sync.Once
 
-	routers        []types.Router
-	routeHandlers  map[string][]types.Middleware
-	globalHandlers []types.Middleware
+	routers       []types.Router
+	routeHandlers map[string][]types.Middleware
 
 	logHTTPEnabled   bool
 	logHTTPRequests  bool
@@ -53,9 +54,20 @@ type server struct {
 
 	stdOut io.WriteCloser
 	stdErr io.WriteCloser
+
+	auditLog    *audit.Log
+	idempotency *idempotency.Cache
+
+	// validateOnly is a flag indicating whether or not the server is
+	// initialized only to validate its configuration, in which case its
+	// endpoints' listeners are not opened
+	validateOnly bool
 }
 
-func newServer(goCtx gocontext.Context, config gofig.Config) (*server, error) {
+func newServer(
+	goCtx gocontext.Context,
+	config gofig.Config,
+	validateOnly bool) (*server, error) {
 
 	adminTokenUUID, err := types.NewUUID()
 	if err != nil {
@@ -84,12 +96,15 @@ func newServer(goCtx gocontext.Context, config gofig.Config) (*server, error) {
 		closeSignal:  make(chan int),
 		closedSignal: make(chan int),
 		closeOnce:    &sync.Once{},
+		validateOnly: validateOnly,
 	}
 
-	if logger, ok := s.ctx.Value(context.LoggerKey).(*log.Logger); ok {
-		s.PrintServerStartupHeader(logger.Out)
-	} else {
-		s.PrintServerStartupHeader(os.Stdout)
+	if !validateOnly {
+		if logger, ok := s.ctx.Value(context.LoggerKey).(*log.Logger); ok {
+			s.PrintServerStartupHeader(logger.Out)
+		} else {
+			s.PrintServerStartupHeader(os.Stdout)
+		}
 	}
 
 	if lvl, err := log.ParseLevel(
@@ -110,6 +125,18 @@ func newServer(goCtx gocontext.Context, config gofig.Config) (*server, error) {
 
 	s.ctx.Info("initializing server")
 
+	if err := s.initAuditLog(); err != nil {
+		return nil, err
+	}
+
+	idempotencyConfig, err := idempotency.ParseConfig(s.config)
+	if err != nil {
+		return nil, err
+	}
+	if idempotencyConfig != nil {
+		s.idempotency = idempotency.New(idempotencyConfig)
+	}
+
 	if err := s.initEndpoints(s.ctx); err != nil {
 		return nil, err
 	}
@@ -128,17 +155,32 @@ func newServer(goCtx gocontext.Context, config gofig.Config) (*server, error) {
 		s.stdErr = getLogIO(logConfig.Stderr, types.ConfigLogStderr)
 	}
 
-	s.initGlobalMiddleware()
+	if err := s.initProfiles(); err != nil {
+		return nil, err
+	}
 
 	if err := s.initRouters(); err != nil {
 		return nil, err
 	}
 
-	servers = append(servers, s)
+	if !validateOnly {
+		servers = append(servers, s)
+	}
 
 	return s, nil
 }
 
+// Validate validates a server configuration by initializing the server's
+// endpoints, services, and storage drivers without opening the endpoints'
+// listeners. An error is returned if the server cannot be initialized.
+func Validate(goCtx gocontext.Context, config gofig.Config) error {
+	s, err := newServer(goCtx, config, true)
+	if err != nil {
+		return err
+	}
+	return s.close()
+}
+
 // Serve starts serving the configured libStorage endpoints. This function
 // returns a channel on which errors are received. Reading this channel is
 // also the prescribed manner for clients wishing to block until the server is
@@ -147,7 +189,7 @@ func Serve(
 	goCtx gocontext.Context,
 	config gofig.Config) (types.Server, <-chan error, error) {
 
-	s, err := newServer(goCtx, config)
+	s, err := newServer(goCtx, config, false)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -156,7 +198,7 @@ func Serve(
 	srvErrs := make(chan error, len(s.servers))
 
 	for _, srv := range s.servers {
-		srv.srv.Handler = s.createMux(srv.ctx)
+		srv.srv.Handler = s.createMux(srv)
 		go func(srv *HTTPServer) {
 			srv.ctx.Info("api listening")
 			if err := srv.Serve(); err != nil {
@@ -224,6 +266,9 @@ func (s *server) close() error {
 	s.ctx.Info("shutting down server")
 
 	for _, srv := range s.servers {
+		if srv.l == nil {
+			continue
+		}
 		srv.ctx.Info("shutting down endpoint")
 		if err := srv.Close(); err != nil {
 			srv.ctx.Error(err)
@@ -249,6 +294,12 @@ func (s *server) close() error {
 		}
 	}
 
+	if s.auditLog != nil {
+		if err := s.auditLog.Close(); err != nil {
+			log.Error(err)
+		}
+	}
+
 	s.ctx.Debug("shutdown server complete")
 
 	return nil
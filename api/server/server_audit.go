@@ -0,0 +1,27 @@
+package server
+
+import (
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/audit"
+)
+
+// initAuditLog opens the audit log if it is enabled. The log is added to the
+// server's context so that the audit router may query it.
+func (s *server) initAuditLog() error {
+	c, err := audit.ParseConfig(s.config)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return nil
+	}
+
+	l, err := audit.New(c)
+	if err != nil {
+		return err
+	}
+	s.auditLog = l
+	s.ctx = s.ctx.WithValue(context.AuditLogKey, l)
+	s.ctx.WithField("file", c.File).Info("opened audit log")
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/goof"
@@ -132,10 +133,23 @@ func (s *server) initEndpoints(ctx types.Context) error {
 
 		ctx.WithFields(logFields).Info("configured endpoint")
 
-		srv, err := s.newHTTPServer(proto, addr, tlsConfig)
+		// the listener of an endpoint is not opened when the server's
+		// configuration is only validated
+		if s.validateOnly {
+			s.servers = append(s.servers, &HTTPServer{
+				ctx: s.ctx.WithValue(
+					context.HostKey, fmt.Sprintf("%s://%s", proto, addr)),
+				endpoint: endpoint,
+			})
+			continue
+		}
+
+		srv, err := s.newHTTPServer(
+			proto, addr, tlsConfig, parseSocketOptions(s.config, endpoint))
 		if err != nil {
 			return err
 		}
+		srv.endpoint = endpoint
 
 		ctx.Info("server created")
 		s.servers = append(s.servers, srv)
@@ -166,13 +180,33 @@ func (s *server) addRouter(r types.Router) {
 
 func (s *server) makeHTTPHandler(
 	ctx types.Context,
+	p *profile,
 	route types.Route) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, req *http.Request) {
 
+		mw := &metricsWriter{ResponseWriter: w}
+		defer observeRequest(route, mw, time.Now())
+
 		w.Header().Set(types.ServerNameHeader, s.name)
 
-		ctx := context.WithRequestRoute(ctx, req, route)
+		ctx, cancel := context.WithCancel(
+			context.WithRequestRoute(ctx, req, route))
+		defer cancel()
+
+		// cancel the request's context if the client disconnects before
+		// the response is written
+		if cn, ok := w.(http.CloseNotifier); ok {
+			closed := cn.CloseNotify()
+			go func() {
+				select {
+				case <-closed:
+					ctx.Info("http client disconnected")
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+		}
 
 		if req.TLS != nil {
 			if len(req.TLS.PeerCertificates) > 0 {
@@ -181,6 +215,10 @@ func (s *server) makeHTTPHandler(
 			}
 		}
 
+		if pc, ok := parsePeerCredAddr(req.RemoteAddr); ok {
+			ctx = ctx.WithValue(context.PeerCredentialsKey, pc)
+		}
+
 		ctx.Info("http request")
 
 		vars := mux.Vars(req)
@@ -189,22 +227,27 @@ func (s *server) makeHTTPHandler(
 		}
 		store := utils.NewStoreWithVars(vars)
 
-		handlerFunc := s.handleWithMiddleware(ctx, route)
-		if err := handlerFunc(ctx, w, req, store); err != nil {
+		handlerFunc := s.handleWithMiddleware(ctx, p, route)
+		if err := handlerFunc(ctx, mw, req, store); err != nil {
 			ctx.Error(err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(mw, err.Error(), http.StatusInternalServerError)
 		}
 	}
 }
 
-func (s *server) createMux(ctx types.Context) *mux.Router {
+func (s *server) createMux(srv *HTTPServer) *mux.Router {
 	m := mux.NewRouter()
 	for _, apiRouter := range s.routers {
 		for _, r := range apiRouter.Routes() {
 
-			ctx := ctx.WithValue(context.RouteKey, r)
+			ctx := srv.ctx.WithValue(context.RouteKey, r)
+
+			if !srv.profile.exposes(r) {
+				ctx.Debug("route not exposed by endpoint")
+				continue
+			}
 
-			f := s.makeHTTPHandler(ctx, r)
+			f := s.makeHTTPHandler(ctx, srv.profile, r)
 			mr := m.Path(r.GetPath())
 			mr = mr.Name(r.GetName())
 			mr = mr.Methods(r.GetMethod())
@@ -227,21 +270,25 @@ func (s *server) createMux(ctx types.Context) *mux.Router {
 }
 
 func (s *server) newHTTPServer(
-	proto, laddr string, tlsConfig *tls.Config) (*HTTPServer, error) {
+	proto, laddr string,
+	tlsConfig *tls.Config,
+	sockOpts *socketOptions) (*HTTPServer, error) {
 
-	var (
-		l   net.Listener
-		err error
-	)
+	l, err := net.Listen(proto, laddr)
+	if err != nil {
+		return nil, err
+	}
 
-	if tlsConfig != nil {
-		l, err = tls.Listen(proto, laddr, tlsConfig)
-	} else {
-		l, err = net.Listen(proto, laddr)
+	if proto == "unix" {
+		if err := sockOpts.apply(laddr); err != nil {
+			l.Close()
+			return nil, err
+		}
+		l = &peerCredListener{l}
 	}
 
-	if err != nil {
-		return nil, err
+	if tlsConfig != nil {
+		l = tls.NewListener(l, tlsConfig)
 	}
 
 	host := fmt.Sprintf("%s://%s", proto, laddr)
@@ -268,10 +315,18 @@ func (s *server) newHTTPServer(
 //
 // l   net.Listener, is a TCP or Socket listener that dispatches incoming
 // request to the router.
+//
+// endpoint string, is the config key of the endpoint the server serves.
+//
+// profile *profile, is the endpoint's security profile that determines the
+// middleware that handles its requests and the routes it exposes.
 type HTTPServer struct {
 	srv *http.Server
 	l   net.Listener
 	ctx types.Context
+
+	endpoint string
+	profile  *profile
 }
 
 // Serve starts listening for inbound requests.
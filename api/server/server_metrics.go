@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils/metrics"
+)
+
+var (
+	httpRequests = metrics.NewCounter(
+		"libstorage_http_requests_total",
+		"The number of HTTP requests by route, method, and status code.",
+		"route", "method", "code")
+
+	httpRequestDuration = metrics.NewHistogram(
+		"libstorage_http_request_duration_seconds",
+		"The latency of the HTTP requests by route and method.",
+		nil,
+		"route", "method")
+)
+
+// metricsWriter is a ResponseWriter that records the status code of a
+// response for the request metrics.
+type metricsWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *metricsWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *metricsWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends any buffered data to the client.
+func (w *metricsWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// observeRequest records the metrics of a completed HTTP request.
+func observeRequest(route types.Route, w *metricsWriter, start time.Time) {
+	code := w.code
+	if code == 0 {
+		code = http.StatusOK
+	}
+	httpRequests.Inc(
+		route.GetName(), route.GetMethod(), strconv.Itoa(code))
+	httpRequestDuration.ObserveSince(
+		start, route.GetName(), route.GetMethod())
+}
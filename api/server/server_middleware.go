@@ -5,22 +5,30 @@ import (
 	"github.com/emccode/libstorage/api/types"
 )
 
-func (s *server) initGlobalMiddleware() {
+// initGlobalMiddleware initializes the global middleware of an endpoint's
+// profile.
+func (s *server) initGlobalMiddleware(p *profile) {
 
-	s.addGlobalMiddleware(handlers.NewQueryParamsHandler())
+	p.addGlobalMiddleware(handlers.NewQueryParamsHandler())
 
-	if s.logHTTPEnabled {
-		s.addGlobalMiddleware(handlers.NewLoggingHandler(
+	if s.logHTTPEnabled && !p.isDisabled(middlewareLogging) {
+		p.addGlobalMiddleware(handlers.NewLoggingHandler(
 			s.stdOut,
 			s.logHTTPRequests,
 			s.logHTTPResponses))
 	}
 
-	s.addGlobalMiddleware(handlers.NewTransactionHandler())
-	s.addGlobalMiddleware(handlers.NewErrorHandler())
-	s.addGlobalMiddleware(handlers.NewInstanceIDHandler())
-	s.addGlobalMiddleware(handlers.NewLocalDevicesHandler())
-	s.addGlobalMiddleware(handlers.NewOnRequestHandler())
+	p.addGlobalMiddleware(handlers.NewTransactionHandler())
+	p.addGlobalMiddleware(handlers.NewErrorHandler())
+	p.addGlobalMiddleware(handlers.NewAuditHandler(s.auditLog))
+	p.addGlobalMiddleware(handlers.NewAuthHandler(p.authConfig))
+	p.addGlobalMiddleware(handlers.NewTenantHandler(p.tenantConfig))
+	p.addGlobalMiddleware(handlers.NewInstanceIDHandler(p.signingConfig))
+	p.addGlobalMiddleware(handlers.NewLocalDevicesHandler(p.signingConfig))
+	p.addGlobalMiddleware(handlers.NewRateLimitHandler(p.rateLimitConfig))
+	p.addGlobalMiddleware(
+		handlers.NewIdempotencyHandler(s.idempotency, s.config))
+	p.addGlobalMiddleware(handlers.NewOnRequestHandler())
 }
 
 func (s *server) initRouteMiddleware() {
@@ -46,12 +54,13 @@ func (s *server) addRouterMiddleware(
 	s.routeHandlers[r.GetName()] = middlewaresForRouteName
 }
 
-func (s *server) addGlobalMiddleware(m types.Middleware) {
-	s.globalHandlers = append(s.globalHandlers, m)
+func (p *profile) addGlobalMiddleware(m types.Middleware) {
+	p.globalHandlers = append(p.globalHandlers, m)
 }
 
 func (s *server) handleWithMiddleware(
 	ctx types.Context,
+	p *profile,
 	route types.Route) types.APIFunc {
 
 	/*if route.GetMethod() == "HEAD" {
@@ -72,7 +81,7 @@ func (s *server) handleWithMiddleware(
 	}
 
 	// add the global handlers
-	for h := range reverse(s.globalHandlers) {
+	for h := range reverse(p.globalHandlers) {
 		handler = h.Handler(handler)
 		ctx.WithField(
 			"middleware", h.Name()).Debug("added global middleware")
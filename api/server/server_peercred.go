@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+const peerCredAddrFormat = "peercred:pid=%d,uid=%d,gid=%d"
+
+// peerCredListener is a UNIX socket listener whose connections report the
+// credentials of the processes on their other ends as their remote
+// addresses. The HTTP server copies a connection's remote address into the
+// RemoteAddr field of each of the connection's requests, from which the
+// credentials are parsed.
+type peerCredListener struct {
+	net.Listener
+}
+
+func (l *peerCredListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	uc, ok := c.(*net.UnixConn)
+	if !ok {
+		return c, nil
+	}
+	pc, err := getPeerCredentials(uc)
+	if err != nil || pc == nil {
+		return c, nil
+	}
+	return &peerCredConn{Conn: c, addr: &peerCredAddr{pc}}, nil
+}
+
+type peerCredConn struct {
+	net.Conn
+	addr net.Addr
+}
+
+func (c *peerCredConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+type peerCredAddr struct {
+	pc *types.PeerCredentials
+}
+
+func (a *peerCredAddr) Network() string {
+	return "unix"
+}
+
+func (a *peerCredAddr) String() string {
+	return fmt.Sprintf(peerCredAddrFormat, a.pc.PID, a.pc.UID, a.pc.GID)
+}
+
+// parsePeerCredAddr parses the peer credentials from a request's remote
+// address.
+func parsePeerCredAddr(addr string) (*types.PeerCredentials, bool) {
+	pc := &types.PeerCredentials{}
+	if n, err := fmt.Sscanf(
+		addr, peerCredAddrFormat, &pc.PID, &pc.UID, &pc.GID); err != nil ||
+		n != 3 {
+		return nil, false
+	}
+	return pc, true
+}
+
+// socketOptions are the owner, group, and mode of an endpoint's UNIX socket
+// file.
+type socketOptions struct {
+	owner string
+	group string
+	mode  string
+}
+
+func parseSocketOptions(config gofig.Config, endpoint string) *socketOptions {
+	return &socketOptions{
+		owner: config.GetString(fmt.Sprintf("%s.socket.owner", endpoint)),
+		group: config.GetString(fmt.Sprintf("%s.socket.group", endpoint)),
+		mode:  config.GetString(fmt.Sprintf("%s.socket.mode", endpoint)),
+	}
+}
+
+// apply sets the owner, group, and mode of the socket file.
+func (o *socketOptions) apply(path string) error {
+
+	if o.owner != "" || o.group != "" {
+		uid, gid := -1, -1
+		if o.owner != "" {
+			var err error
+			if uid, err = utils.LookupUID(o.owner); err != nil {
+				return err
+			}
+		}
+		if o.group != "" {
+			var err error
+			if gid, _, err = utils.LookupGroup(o.group); err != nil {
+				return err
+			}
+		}
+		if err := os.Chown(path, uid, gid); err != nil {
+			return err
+		}
+	}
+
+	if o.mode != "" {
+		mode, err := strconv.ParseUint(o.mode, 8, 32)
+		if err != nil {
+			return err
+		}
+		if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
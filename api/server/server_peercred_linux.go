@@ -0,0 +1,35 @@
+// +build linux
+
+package server
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// getPeerCredentials reads the credentials of the process on the other end
+// of the connection with the SO_PEERCRED socket option. Prior to Go 1.11,
+// duplicating the connection's file descriptor places the connection in
+// blocking mode, which the HTTP server tolerates since it does not set
+// deadlines on the connection.
+func getPeerCredentials(c *net.UnixConn) (*types.PeerCredentials, error) {
+	f, err := c.File()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cred, err := syscall.GetsockoptUcred(
+		int(f.Fd()), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PeerCredentials{
+		PID: int(cred.Pid),
+		UID: int(cred.Uid),
+		GID: int(cred.Gid),
+	}, nil
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package server
+
+import (
+	"net"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// getPeerCredentials returns a nil value since reading the credentials of
+// the process on the other end of a connection is supported only on Linux.
+func getPeerCredentials(c *net.UnixConn) (*types.PeerCredentials, error) {
+	return nil, nil
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/emccode/libstorage/api/server/auth"
+	"github.com/emccode/libstorage/api/server/ratelimit"
+	"github.com/emccode/libstorage/api/server/tenants"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/signing"
+)
+
+const (
+	// the names of the global middleware that may be disabled for an
+	// endpoint
+	middlewareLogging   = "logging"
+	middlewareAuth      = "auth"
+	middlewareSigning   = "signing"
+	middlewareRateLimit = "rateLimit"
+	middlewareTenant    = "tenant"
+)
+
+// profile is an endpoint's security profile: the global middleware that
+// handles the endpoint's requests and the routes the endpoint exposes.
+//
+// The authentication, header signing, rate limit, and tenant configurations
+// are parsed from the endpoint's config scope, so an endpoint may override any
+// of the properties beneath libstorage.server.auth, libstorage.server.signing,
+// libstorage.server.rateLimit, and libstorage.server.tenants.
+type profile struct {
+	globalHandlers []types.Middleware
+
+	disabled []string
+	readOnly bool
+	allow    []string
+	deny     []string
+
+	authConfig      *auth.Config
+	signingConfig   *signing.Config
+	rateLimitConfig *ratelimit.Config
+	tenantConfig    *tenants.Config
+}
+
+// initProfiles initializes the security profiles of the endpoints.
+func (s *server) initProfiles() error {
+	for _, srv := range s.servers {
+		p, err := s.newProfile(srv.endpoint)
+		if err != nil {
+			return err
+		}
+		srv.profile = p
+	}
+	return nil
+}
+
+func (s *server) newProfile(endpoint string) (*profile, error) {
+
+	config := s.config.Scope(endpoint)
+	key := func(k string) string { return fmt.Sprintf("%s.%s", endpoint, k) }
+
+	p := &profile{
+		disabled: utils.ConfigStrings(s.config, key("middleware.disabled")),
+		readOnly: s.config.GetBool(key("routes.readOnly")),
+		allow:    utils.ConfigStrings(s.config, key("routes.allow")),
+		deny:     utils.ConfigStrings(s.config, key("routes.deny")),
+	}
+
+	var err error
+
+	if !p.isDisabled(middlewareAuth) {
+		if p.authConfig, err = auth.ParseConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.isDisabled(middlewareSigning) {
+		if p.signingConfig, err = signing.ParseServerConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.isDisabled(middlewareRateLimit) {
+		if p.rateLimitConfig, err = ratelimit.ParseConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.isDisabled(middlewareTenant) {
+		if p.tenantConfig, err = tenants.ParseConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	s.initGlobalMiddleware(p)
+
+	s.ctx.WithFields(log.Fields{
+		"endpoint":        endpoint,
+		"disabled":        p.disabled,
+		"auth":            p.authConfig != nil,
+		"signing":         p.signingConfig != nil,
+		"rateLimit":       p.rateLimitConfig != nil,
+		"tenant":          p.tenantConfig != nil,
+		"routes.readOnly": p.readOnly,
+		"routes.allow":    p.allow,
+		"routes.deny":     p.deny,
+		"len(handlers)":   len(p.globalHandlers),
+	}).Info("configured endpoint profile")
+
+	return p, nil
+}
+
+// isDisabled returns a flag indicating whether or not the global middleware
+// with the provided name is disabled for the endpoint.
+func (p *profile) isDisabled(name string) bool {
+	for _, d := range p.disabled {
+		if strings.EqualFold(d, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// exposes returns a flag indicating whether or not the endpoint exposes the
+// route. A route is exposed if it is not a mutating route on a read-only
+// endpoint, its name matches one of the allowed patterns or there are no
+// allowed patterns, and its name matches none of the denied patterns.
+func (p *profile) exposes(r types.Route) bool {
+	if p.readOnly {
+		switch r.GetMethod() {
+		case "GET", "HEAD":
+		default:
+			return false
+		}
+	}
+	if matchRoute(p.deny, r.GetName()) {
+		return false
+	}
+	return len(p.allow) == 0 || matchRoute(p.allow, r.GetName())
+}
+
+// matchRoute returns a flag indicating whether or not the route name matches
+// one of the glob patterns. The match is case-insensitive.
+func matchRoute(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
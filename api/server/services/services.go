@@ -9,7 +9,9 @@ import (
 	"github.com/akutz/goof"
 
 	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/webhooks"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils/signing"
 )
 
 var (
@@ -21,6 +23,7 @@ type serviceContainer struct {
 	config          gofig.Config
 	storageServices map[string]types.StorageService
 	taskService     *globalTaskService
+	eventBus        *eventBus
 }
 
 // Init initializes the types.
@@ -36,6 +39,7 @@ func Init(ctx types.Context, config gofig.Config) error {
 	sc := &serviceContainer{
 		taskService:     &globalTaskService{name: "global-task-service"},
 		storageServices: map[string]types.StorageService{},
+		eventBus:        newEventBus(),
 	}
 
 	if err := sc.Init(ctx, config); err != nil {
@@ -60,6 +64,29 @@ func (sc *serviceContainer) Init(ctx types.Context, config gofig.Config) error {
 		return err
 	}
 
+	if err := sc.initWebhooks(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// initWebhooks starts delivering the server's events to the configured
+// webhooks.
+func (sc *serviceContainer) initWebhooks(ctx types.Context) error {
+	whConfig, err := webhooks.ParseConfig(sc.config)
+	if err != nil {
+		return err
+	}
+	if whConfig == nil {
+		return nil
+	}
+
+	d := webhooks.NewDispatcher(ctx, whConfig)
+	d.Start(sc.eventBus.subscribe(ctx, nil))
+
+	ctx.WithField("len(hooks)", len(whConfig.Hooks)).Info(
+		"initialized webhooks")
 	return nil
 }
 
@@ -98,6 +125,25 @@ func StorageServices(ctx types.Context) <-chan types.StorageService {
 	return c
 }
 
+// SigningKeys returns the keys with which the signatures of the headers for
+// the provided driver are verified, one for each of the storage services
+// that use the driver and have a key.
+func SigningKeys(ctx types.Context, driver string) []*signing.Key {
+	servicesByServerRWL.RLock()
+	defer servicesByServerRWL.RUnlock()
+	keys := []*signing.Key{}
+	for _, v := range getStorageServices(ctx) {
+		s, ok := v.(*storageService)
+		if !ok || s.signingKey == nil {
+			continue
+		}
+		if strings.EqualFold(s.driver.Name(), driver) {
+			keys = append(keys, s.signingKey)
+		}
+	}
+	return keys
+}
+
 func (sc *serviceContainer) initStorageServices(ctx types.Context) error {
 	if ctx == nil {
 		panic("ctx is nil")
@@ -186,6 +232,41 @@ func TaskInspect(ctx types.Context, taskID int) *types.Task {
 	return getTaskService(ctx).TaskInspect(taskID)
 }
 
+// TaskCancel cancels the task with the specified ID.
+func TaskCancel(ctx types.Context, taskID int) *types.Task {
+	return getTaskService(ctx).TaskCancel(taskID)
+}
+
+// TaskOperation returns the name of the service on which the specified task
+// operates and the verb of its operation. A false value is returned if the
+// task is not tracked.
+func TaskOperation(
+	ctx types.Context, taskID int) (string, types.AuthVerb, bool) {
+	return getTaskService(ctx).TaskOperation(taskID)
+}
+
+// TaskCompleted returns a flag indicating whether or not the specified task
+// is completed without waiting for it to complete.
+func TaskCompleted(ctx types.Context, taskID int) bool {
+	return getTaskService(ctx).TaskCompleted(taskID)
+}
+
+// TaskQueues returns information about the task queues of the storage
+// services, keyed by the services' names.
+func TaskQueues(ctx types.Context) map[string]*types.TaskQueueInfo {
+	queues := map[string]*types.TaskQueueInfo{}
+	for service := range StorageServices(ctx) {
+		queues[service.Name()] = service.TaskQueueInfo()
+	}
+	return queues
+}
+
+// TaskWatch returns a channel on which a copy of a task is received each time
+// the task's state changes. The channel is closed when the context is done.
+func TaskWatch(ctx types.Context) <-chan *types.Task {
+	return getTaskService(ctx).TaskWatch(ctx)
+}
+
 // TaskWait blocks until the specified task is completed.
 func TaskWait(ctx types.Context, taskID int) {
 	getTaskService(ctx).TaskWait(taskID)
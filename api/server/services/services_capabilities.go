@@ -0,0 +1,60 @@
+package services
+
+import (
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// initCapabilities reads the capabilities of the service's driver. The
+// capabilities are nil if the driver does not describe them.
+func (s *storageService) initCapabilities(ctx types.Context) error {
+	sd, ok := s.driver.(types.StorageDriverCapabilities)
+	if !ok {
+		return nil
+	}
+
+	caps, err := sd.Capabilities(ctx)
+	if err != nil {
+		return goof.WithFieldE(
+			"service", s.name, "error getting driver capabilities", err)
+	}
+	if caps == nil {
+		return nil
+	}
+
+	// the capabilities are copied so that the driver's value is not modified
+	c := *caps
+	if c.Type == "" {
+		if c.Type, err = s.driver.Type(ctx); err != nil {
+			return goof.WithFieldE(
+				"service", s.name, "error getting driver type", err)
+		}
+	}
+	s.capabilities = &c
+	return nil
+}
+
+// Capabilities returns the capabilities of the service's driver. A nil value
+// is returned if the driver does not describe its capabilities.
+func Capabilities(svc types.StorageService) *types.StorageCapabilities {
+	if s, ok := svc.(*storageService); ok {
+		return s.capabilities
+	}
+	return nil
+}
+
+// CheckVolumeSize returns an ErrVolumeTooLarge error if a volume size exceeds
+// the maximum volume size of the service's driver. A nil size is not checked.
+func CheckVolumeSize(svc types.StorageService, size *int64) error {
+	caps := Capabilities(svc)
+	if caps == nil || caps.MaxVolumeSize == 0 || size == nil {
+		return nil
+	}
+	if *size > caps.MaxVolumeSize {
+		return utils.NewVolumeTooLargeErr(
+			svc.Name(), *size, caps.MaxVolumeSize)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+// eventBufferLen is the number of events that are buffered for a subscriber.
+const eventBufferLen = 100
+
+// eventBus publishes the server's events to its subscribers.
+type eventBus struct {
+	sync.RWMutex
+	lastID      int64
+	subscribers map[chan *types.Event]*types.EventsOpts
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		subscribers: map[chan *types.Event]*types.EventsOpts{},
+	}
+}
+
+// subscribe returns a channel on which the events that match the provided
+// options are received. The channel is closed when the context is done.
+func (b *eventBus) subscribe(
+	ctx types.Context, opts *types.EventsOpts) <-chan *types.Event {
+
+	if opts == nil {
+		opts = &types.EventsOpts{}
+	}
+
+	c := make(chan *types.Event, eventBufferLen)
+
+	b.Lock()
+	b.subscribers[c] = opts
+	b.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.Lock()
+		delete(b.subscribers, c)
+		close(c)
+		b.Unlock()
+	}()
+
+	return c
+}
+
+// publish assigns the event its ID and sends it to the subscribers whose
+// options it matches. A subscriber that is not keeping up with the events
+// misses the event rather than blocking the publisher.
+func (b *eventBus) publish(e *types.Event) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+
+	for c, opts := range b.subscribers {
+		if !matchEvent(opts, e) {
+			continue
+		}
+		select {
+		case c <- e:
+		default:
+			log.WithField("eventID", e.ID).Warn(
+				"event subscriber is full; dropped event")
+		}
+	}
+}
+
+func matchEvent(opts *types.EventsOpts, e *types.Event) bool {
+	if len(opts.Services) > 0 {
+		ok := false
+		for _, s := range opts.Services {
+			if strings.EqualFold(s, e.Service) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(opts.Types) > 0 {
+		ok := false
+		for _, t := range opts.Types {
+			if strings.EqualFold(string(t), string(e.Type)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func getEventBus(ctx types.Context) *eventBus {
+
+	serverName, ok := context.Server(ctx)
+	if !ok {
+		panic("ctx is missing ServerName")
+	}
+
+	servicesByServerRWL.RLock()
+	defer servicesByServerRWL.RUnlock()
+
+	return servicesByServer[serverName].eventBus
+}
+
+// EventSubscribe returns a channel on which the server's events that match
+// the provided options are received. The channel is closed when the context
+// is done.
+func EventSubscribe(
+	ctx types.Context, opts *types.EventsOpts) <-chan *types.Event {
+	return getEventBus(ctx).subscribe(ctx, opts)
+}
+
+// EventPublish publishes an event of the provided type that describes the
+// outcome of an operation performed by a storage service. The IDs of the
+// volume and snapshot are taken from the operation's result when they are
+// not provided. The event's instance ID, transaction ID, and task ID are
+// taken from the context.
+func EventPublish(
+	ctx types.Context,
+	eventType types.EventType,
+	service, volumeID, snapshotID string,
+	result interface{},
+	err error) {
+
+	e := &types.Event{
+		Type:       eventType,
+		Time:       time.Now().Unix(),
+		Service:    service,
+		VolumeID:   volumeID,
+		SnapshotID: snapshotID,
+		Outcome:    types.EventOutcomeSuccess,
+	}
+
+	switch tr := result.(type) {
+	case *types.Volume:
+		if e.VolumeID == "" && tr != nil {
+			e.VolumeID = tr.ID
+		}
+	case *types.VolumeAttachResponse:
+		if e.VolumeID == "" && tr != nil && tr.Volume != nil {
+			e.VolumeID = tr.Volume.ID
+		}
+	case *types.Snapshot:
+		if tr != nil {
+			if e.SnapshotID == "" {
+				e.SnapshotID = tr.ID
+			}
+			if e.VolumeID == "" {
+				e.VolumeID = tr.VolumeID
+			}
+		}
+	}
+
+	if err != nil {
+		e.Outcome = types.EventOutcomeError
+		e.Error = err.Error()
+	}
+
+	if iid, ok := context.InstanceID(ctx); ok {
+		e.InstanceID = iid
+	}
+	if tx, ok := context.Transaction(ctx); ok && tx.ID != nil {
+		e.TransactionID = tx.ID.String()
+	}
+	if v, ok := ctx.Value(context.TaskKey).(string); ok {
+		e.TaskID, _ = strconv.Atoi(v)
+	}
+
+	getEventBus(ctx).publish(e)
+}
+
+// EventRunFunc returns a run function that publishes an event of the provided
+// type once the provided run function returns.
+func EventRunFunc(
+	eventType types.EventType,
+	volumeID, snapshotID string,
+	run types.StorageTaskRunFunc) types.StorageTaskRunFunc {
+
+	return func(
+		ctx types.Context,
+		svc types.StorageService) (interface{}, error) {
+
+		result, err := run(ctx, svc)
+		EventPublish(
+			ctx, eventType, svc.Name(), volumeID, snapshotID, result, err)
+		return result, err
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/quota"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// initQuotas creates the enforcer of the service's quotas. The quotas may be
+// specified beneath the service's config scope.
+func (s *storageService) initQuotas(ctx types.Context) error {
+	c, err := quota.ParseConfig(s.config)
+	if err != nil {
+		return goof.WithFieldE("service", s.name, "invalid quotas", err)
+	}
+
+	volumesFunc := func(ctx types.Context) ([]*types.Volume, error) {
+		return s.driver.Volumes(
+			ctx, &types.VolumesOpts{Opts: utils.NewStore()})
+	}
+	snapshotsFunc := func(ctx types.Context) ([]*types.Snapshot, error) {
+		return s.driver.Snapshots(ctx, utils.NewStore())
+	}
+
+	e, err := quota.NewEnforcer(
+		c,
+		types.Lib.Join("quotas", fmt.Sprintf("%s.json", s.name)),
+		volumesFunc,
+		snapshotsFunc)
+	if err != nil {
+		return goof.WithFieldE("service", s.name, "invalid quotas", err)
+	}
+	s.quotas = e
+	return nil
+}
+
+// quotaOwner returns the tenant and instance on whose behalf the context's
+// request is made. The tenant is the name of the context's principal if the
+// request is not made on behalf of a tenant namespace.
+func quotaOwner(ctx types.Context) *quota.Owner {
+	o := &quota.Owner{}
+	if t, ok := context.Tenant(ctx); ok {
+		o.Tenant = t.Name
+	} else if p, ok := context.Principal(ctx); ok {
+		o.Tenant = p.Name
+	}
+	if iid, ok := context.InstanceID(ctx); ok {
+		o.InstanceID = iid.ID
+	}
+	return o
+}
+
+func getQuotaEnforcer(svc types.StorageService) *quota.Enforcer {
+	if s, ok := svc.(*storageService); ok {
+		return s.quotas
+	}
+	return nil
+}
+
+// QuotaRunFunc returns a run function that returns an error if the resources
+// described by the request would exceed one of the service's quotas, and
+// otherwise invokes the provided run function and records the owner of the
+// volume or snapshot it creates. The cached usage is invalidated once a volume
+// is resized.
+func QuotaRunFunc(
+	req *quota.Request,
+	run types.StorageTaskRunFunc) types.StorageTaskRunFunc {
+
+	return func(
+		ctx types.Context,
+		svc types.StorageService) (interface{}, error) {
+
+		e := getQuotaEnforcer(svc)
+		if e == nil {
+			return run(ctx, svc)
+		}
+
+		release, err := e.Reserve(ctx, quotaOwner(ctx), req)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := run(ctx, svc)
+		if err != nil {
+			release(nil)
+			return nil, err
+		}
+		release(result)
+		return result, nil
+	}
+}
+
+// QuotaReleaseRunFunc returns a run function that invalidates the service's
+// cached quota usage once the provided run function removes a volume or
+// snapshot.
+func QuotaReleaseRunFunc(
+	run types.StorageTaskRunFunc) types.StorageTaskRunFunc {
+
+	return func(
+		ctx types.Context,
+		svc types.StorageService) (interface{}, error) {
+
+		result, err := run(ctx, svc)
+		if e := getQuotaEnforcer(svc); e != nil && err == nil {
+			e.Release()
+		}
+		return result, err
+	}
+}
+
+// Quotas returns the service's quota as well as the quotas of the tenant and
+// instance on whose behalf the context's request is made.
+func Quotas(
+	ctx types.Context, svc types.StorageService) ([]*types.Quota, error) {
+
+	e := getQuotaEnforcer(svc)
+	if e == nil {
+		return []*types.Quota{}, nil
+	}
+	return e.Quotas(ctx, quotaOwner(ctx))
+}
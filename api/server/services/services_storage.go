@@ -1,19 +1,32 @@
 package services
 
 import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/gofig"
 	"github.com/akutz/goof"
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/registry"
+	"github.com/emccode/libstorage/api/server/quota"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/signing"
 )
 
 type storageService struct {
-	name          string
-	driver        types.StorageDriver
-	config        gofig.Config
-	taskExecQueue chan *task
+	name       string
+	driver     types.StorageDriver
+	config     gofig.Config
+	taskQueue  *taskQueue
+	retryAfter int
+	signingKey *signing.Key
+	quotas     *quota.Enforcer
+
+	// capabilities are the capabilities of the service's driver. They are
+	// nil if the driver does not describe its capabilities.
+	capabilities *types.StorageCapabilities
 }
 
 func (s *storageService) Init(ctx types.Context, config gofig.Config) error {
@@ -23,15 +36,65 @@ func (s *storageService) Init(ctx types.Context, config gofig.Config) error {
 		return err
 	}
 
-	s.taskExecQueue = make(chan *task)
-	go func() {
-		for t := range s.taskExecQueue {
-			execTask(t)
-		}
-	}()
+	if err := s.initCapabilities(ctx); err != nil {
+		return err
+	}
+
+	if err := s.initSigningKey(); err != nil {
+		return err
+	}
+
+	if err := s.initQuotas(ctx); err != nil {
+		return err
+	}
+
+	s.initTaskQueue(ctx)
+	return nil
+}
+
+// initSigningKey parses the key with which the signatures of the headers
+// sent by the clients of the service's driver are verified. The key may be
+// specified beneath the service's config scope.
+func (s *storageService) initSigningKey() error {
+	if !s.config.GetBool(types.ConfigServerSigningEnabled) {
+		return nil
+	}
+	k, err := signing.ParseServerKey(s.config)
+	if err != nil {
+		return goof.WithFieldE("service", s.name, "invalid signing key", err)
+	}
+	s.signingKey = k
 	return nil
 }
 
+// initTaskQueue creates the service's task queue. The queue's settings may
+// be overridden for a service by specifying them beneath the service's
+// config scope.
+func (s *storageService) initTaskQueue(ctx types.Context) {
+	s.taskQueue = newTaskQueue(
+		s.config.GetInt(types.ConfigServerTasksQueueWorkers),
+		s.config.GetInt(types.ConfigServerTasksQueueDepth),
+		s.config.GetBool(types.ConfigServerTasksQueuePrioritize))
+
+	retryAfter, err := time.ParseDuration(
+		s.config.GetString(types.ConfigServerTasksQueueRetryAfter))
+	if err != nil {
+		retryAfter = time.Duration(time.Second * 5)
+	}
+	s.retryAfter = int(retryAfter / time.Second)
+	if s.retryAfter < 1 {
+		s.retryAfter = 1
+	}
+
+	s.taskQueue.start()
+
+	ctx.WithFields(log.Fields{
+		"workers":    s.taskQueue.workers,
+		"depth":      s.taskQueue.depth,
+		"prioritize": s.taskQueue.prioritize,
+	}).Debug("configured task queue")
+}
+
 func (s *storageService) initStorageDriver(ctx types.Context) error {
 	driverName := s.config.GetString("driver")
 	if driverName == "" {
@@ -74,11 +137,27 @@ func (s *storageService) TaskExecute(
 	run types.StorageTaskRunFunc,
 	schema []byte) *types.Task {
 
+	return s.TaskEnqueue(ctx, run, schema, types.TaskPriorityNormal)
+}
+
+func (s *storageService) TaskEnqueue(
+	ctx types.Context,
+	run types.StorageTaskRunFunc,
+	schema []byte,
+	priority types.TaskPriority) *types.Task {
+
 	t := newStorageServiceTask(ctx, run, s, schema)
-	go func() { s.taskExecQueue <- t }()
+	if !s.taskQueue.enqueue(t, priority) {
+		rejectTask(t, utils.NewQueueFullErr(
+			s.name, s.taskQueue.depth, s.retryAfter))
+	}
 	return &t.Task
 }
 
+func (s *storageService) TaskQueueInfo() *types.TaskQueueInfo {
+	return s.taskQueue.info()
+}
+
 func (s *storageService) Name() string {
 	return s.name
 }
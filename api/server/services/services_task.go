@@ -11,25 +11,48 @@ import (
 	"github.com/akutz/goof"
 
 	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/registry"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/metrics"
 	"github.com/emccode/libstorage/api/utils/schema"
 )
 
+var (
+	tasksCompleted = metrics.NewCounter(
+		"libstorage_tasks_total",
+		"The number of completed tasks by their final state.",
+		"service", "state")
+
+	taskStateDuration = metrics.NewHistogram(
+		"libstorage_task_state_duration_seconds",
+		"The amount of time tasks spend in the queued and running states.",
+		nil,
+		"service", "state")
+)
+
 type task struct {
 	types.Task
 	ctx                           types.Context
+	cancel                        func()
+	verb                          types.AuthVerb
+	store                         types.TaskStore
+	svc                           *globalTaskService
 	runFunc                       types.TaskRunFunc
 	storRunFunc                   types.StorageTaskRunFunc
 	storService                   types.StorageService
 	resultSchema                  []byte
 	resultSchemaValidationEnabled bool
 	done                          chan int
+	created                       time.Time
+	started                       time.Time
 }
 
 func newTask(ctx types.Context, schema []byte) *task {
 	t := getTaskService(ctx).taskTrack(ctx)
 	t.resultSchema = schema
 	t.done = make(chan int)
+	t.created = time.Now()
 	return t
 }
 
@@ -58,18 +81,37 @@ func newStorageServiceTask(
 func execTask(t *task) {
 	defer func() {
 		t.CompleteTime = time.Now().Unix()
-		if t.Error != nil {
+
+		// a task that completes successfully despite being canceled is
+		// still considered a success since its operation was not aborted
+		if t.Error != nil && t.ctx.Err() != nil {
+			t.Error = utils.NewTaskCanceledErr(t.ID, t.Error)
+			t.ctx.Info(t.Error)
+			t.State = types.TaskStateCanceled
+		} else if t.Error != nil {
 			t.ctx.Error(t.Error)
 			t.State = types.TaskStateError
 		} else {
 			t.State = types.TaskStateSuccess
 		}
+		t.save()
+		t.observe()
 		close(t.done)
+
+		// release the resources associated with the task's context
+		t.cancel()
 		t.ctx.Debug("task completed")
 	}()
 
+	// do not run a task that was canceled while it was queued
+	if t.Error = t.ctx.Err(); t.Error != nil {
+		return
+	}
+
+	t.started = time.Now()
 	t.State = types.TaskStateRunning
-	t.StartTime = time.Now().Unix()
+	t.StartTime = t.started.Unix()
+	t.save()
 
 	t.ctx.Info("executing task")
 
@@ -111,19 +153,80 @@ func execTask(t *task) {
 	}
 }
 
+// rejectTask completes a task that is never executed.
+func rejectTask(t *task, err error) {
+	t.ctx.Warn(err)
+	t.Error = err
+	t.State = types.TaskStateError
+	t.CompleteTime = time.Now().Unix()
+	t.save()
+	t.observe()
+	close(t.done)
+	t.cancel()
+}
+
+// observe records the metrics of a completed task.
+func (t *task) observe() {
+	service := ""
+	if t.storService != nil {
+		service = t.storService.Name()
+	}
+
+	tasksCompleted.Inc(service, string(t.State))
+
+	if t.started.IsZero() {
+		taskStateDuration.ObserveSince(
+			t.created, service, string(types.TaskStateQueued))
+		return
+	}
+	taskStateDuration.Observe(
+		t.started.Sub(t.created).Seconds(),
+		service, string(types.TaskStateQueued))
+	taskStateDuration.ObserveSince(
+		t.started, service, string(types.TaskStateRunning))
+}
+
+// save persists the task to the task store. A task is saved each time its
+// state changes, so the task is also sent to the task watchers.
+func (t *task) save() {
+	if err := t.store.Save(&t.Task); err != nil {
+		t.ctx.WithError(err).Error("error saving task")
+	}
+	t.svc.notifyWatchers(t.Task)
+}
+
+// taskWatchBufferLen is the number of state changes that are buffered for a
+// task watcher.
+const taskWatchBufferLen = 100
+
 type globalTaskService struct {
 	sync.RWMutex
 	name                          string
 	config                        gofig.Config
 	tasks                         map[int]*task
+	store                         types.TaskStore
 	resultSchemaValidationEnabled bool
+	watchers                      map[chan *types.Task]bool
+	watchersRWL                   sync.RWMutex
 }
 
 // Init initializes the service.
 func (s *globalTaskService) Init(ctx types.Context, config gofig.Config) error {
 	s.tasks = map[int]*task{}
+	s.watchers = map[chan *types.Task]bool{}
 	s.config = config
 
+	storeType := config.GetString(types.ConfigServerTasksStoreType)
+	store, err := registry.NewTaskStore(storeType)
+	if err != nil {
+		return err
+	}
+	if err := store.Init(ctx, config); err != nil {
+		return err
+	}
+	s.store = store
+	ctx.WithField("store", storeType).Debug("configured task store")
+
 	s.resultSchemaValidationEnabled = config.GetBool(
 		types.ConfigSchemaResponseValidationEnabled)
 	ctx.WithField("enabled", s.resultSchemaValidationEnabled).Debug(
@@ -143,6 +246,17 @@ func (s *globalTaskService) Tasks() <-chan *types.Task {
 	for _, v := range s.tasks {
 		tasks = append(tasks, &v.Task)
 	}
+
+	// include the tasks that are no longer tracked but remain in the store
+	stored, err := s.store.List()
+	if err != nil {
+		log.WithError(err).Error("error listing stored tasks")
+	}
+	for _, v := range stored {
+		if _, ok := s.tasks[v.ID]; !ok {
+			tasks = append(tasks, v)
+		}
+	}
 	s.RUnlock()
 
 	c := make(chan *types.Task)
@@ -162,23 +276,39 @@ func (s *globalTaskService) TaskTrack(ctx types.Context) *types.Task {
 func (s *globalTaskService) taskTrack(ctx types.Context) *task {
 
 	now := time.Now().Unix()
-	s.RLock()
-	taskID := len(s.tasks)
-	s.RUnlock()
+	taskID := s.store.NextID()
+
+	// the task's context is not canceled along with the request that
+	// created it so that asynchronous tasks outlive their requests
+	ctx, cancel := context.WithDetachedCancel(
+		ctx.WithValue(context.TaskKey, fmt.Sprintf("%d", taskID)))
 
 	t := &task{
 		Task: types.Task{
 			ID:        taskID,
 			QueueTime: now,
+			State:     types.TaskStateQueued,
 		},
 		resultSchemaValidationEnabled: s.resultSchemaValidationEnabled,
-		ctx: ctx.WithValue(context.TaskKey, fmt.Sprintf("%d", taskID)),
+		ctx:                           ctx,
+		cancel:                        cancel,
+		store:                         s.store,
+		svc:                           s,
 	}
 
+	// the task's service and verb are used to authorize the principals that
+	// list, watch, and cancel the task
+	if svc, ok := context.Service(ctx); ok {
+		t.Service = svc.Name()
+	}
+	t.verb, _ = context.AuthVerb(ctx)
+
 	s.Lock()
 	s.tasks[taskID] = t
 	s.Unlock()
 
+	t.save()
+
 	return t
 }
 
@@ -200,7 +330,94 @@ func (s *globalTaskService) TaskInspect(taskID int) *types.Task {
 	if t, ok := s.tasks[taskID]; ok {
 		return &t.Task
 	}
-	return nil
+	t, err := s.store.Get(taskID)
+	if err != nil {
+		log.WithError(err).WithField(
+			"taskID", taskID).Error("error getting stored task")
+		return nil
+	}
+	return t
+}
+
+// TaskCancel cancels the task with the specified ID.
+func (s *globalTaskService) TaskCancel(taskID int) *types.Task {
+	s.RLock()
+	t, ok := s.tasks[taskID]
+	s.RUnlock()
+	if !ok {
+		return nil
+	}
+	t.ctx.Info("canceling task")
+	t.cancel()
+	return &t.Task
+}
+
+// TaskOperation returns the name of the service on which the specified task
+// operates and the verb of its operation.
+func (s *globalTaskService) TaskOperation(
+	taskID int) (string, types.AuthVerb, bool) {
+
+	s.RLock()
+	t, ok := s.tasks[taskID]
+	s.RUnlock()
+	if !ok {
+		return "", "", false
+	}
+	return t.Service, t.verb, true
+}
+
+// TaskCompleted returns a flag indicating whether or not the specified task
+// is completed without waiting for it to complete.
+func (s *globalTaskService) TaskCompleted(taskID int) bool {
+	s.RLock()
+	t, ok := s.tasks[taskID]
+	s.RUnlock()
+	if !ok {
+		return true
+	}
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// TaskWatch returns a channel on which a copy of a task is received each
+// time the task's state changes. The channel is closed when the provided
+// context is done.
+func (s *globalTaskService) TaskWatch(ctx types.Context) <-chan *types.Task {
+	c := make(chan *types.Task, taskWatchBufferLen)
+
+	s.watchersRWL.Lock()
+	s.watchers[c] = true
+	s.watchersRWL.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.watchersRWL.Lock()
+		delete(s.watchers, c)
+		close(c)
+		s.watchersRWL.Unlock()
+	}()
+
+	return c
+}
+
+// notifyWatchers sends a copy of the task to the task watchers. A watcher
+// that is not keeping up with the state changes misses the task rather than
+// blocking the task's execution.
+func (s *globalTaskService) notifyWatchers(t types.Task) {
+	s.watchersRWL.RLock()
+	defer s.watchersRWL.RUnlock()
+	for c := range s.watchers {
+		select {
+		case c <- &t:
+		default:
+			log.WithField("taskID", t.ID).Warn(
+				"task watcher is full; dropped task")
+		}
+	}
 }
 
 // TaskWait blocks until the specified task is completed.
@@ -259,6 +476,9 @@ func (s *globalTaskService) taskRemoveAfter(t *task) {
 
 		// delete the task
 		delete(s.tasks, t.ID)
+		if err := s.store.Evict(t.ID); err != nil {
+			t.ctx.WithError(err).Error("error evicting task")
+		}
 
 		t.ctx.WithField("tasksLen", len(s.tasks)).Debug("removed task")
 	}()
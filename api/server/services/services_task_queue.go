@@ -0,0 +1,146 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// taskQueue is a bounded queue of storage service tasks that are executed by
+// a fixed number of workers, or by a goroutine per task if the number of
+// workers is zero. High priority tasks are dequeued before normal ones when
+// prioritization is enabled; otherwise all tasks are dequeued in the order in
+// which they were enqueued.
+type taskQueue struct {
+	sync.Mutex
+	cond       *sync.Cond
+	workers    int
+	depth      int
+	prioritize bool
+	high       []*queuedTask
+	normal     []*queuedTask
+	running    int
+	avgWait    time.Duration
+}
+
+type queuedTask struct {
+	t      *task
+	queued time.Time
+}
+
+func newTaskQueue(workers, depth int, prioritize bool) *taskQueue {
+	if workers < 0 {
+		workers = 0
+	}
+	if depth < 0 {
+		depth = 0
+	}
+	q := &taskQueue{
+		workers:    workers,
+		depth:      depth,
+		prioritize: prioritize,
+	}
+	q.cond = sync.NewCond(q)
+	return q
+}
+
+// start starts the queue's workers. A queue without workers executes each
+// task in its own goroutine as soon as it is dequeued.
+func (q *taskQueue) start() {
+	if q.workers == 0 {
+		go func() {
+			for {
+				go q.exec(q.dequeue())
+			}
+		}()
+		return
+	}
+	for i := 0; i < q.workers; i++ {
+		go func() {
+			for {
+				q.exec(q.dequeue())
+			}
+		}()
+	}
+}
+
+// exec executes a dequeued task.
+func (q *taskQueue) exec(t *task) {
+	execTask(t)
+	q.Lock()
+	q.running--
+	q.Unlock()
+}
+
+// enqueue adds a task to the queue. A false value is returned if the queue
+// is full.
+func (q *taskQueue) enqueue(t *task, priority types.TaskPriority) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.depth > 0 && len(q.high)+len(q.normal) >= q.depth {
+		return false
+	}
+
+	qt := &queuedTask{t: t, queued: time.Now()}
+	if q.prioritize && priority == types.TaskPriorityHigh {
+		q.high = append(q.high, qt)
+	} else {
+		q.normal = append(q.normal, qt)
+	}
+	q.cond.Signal()
+	return true
+}
+
+// dequeue blocks until a task is available and then removes it from the
+// queue.
+func (q *taskQueue) dequeue() *task {
+	q.Lock()
+	defer q.Unlock()
+
+	for len(q.high) == 0 && len(q.normal) == 0 {
+		q.cond.Wait()
+	}
+
+	var qt *queuedTask
+	if len(q.high) > 0 {
+		qt, q.high = q.high[0], q.high[1:]
+	} else {
+		qt, q.normal = q.normal[0], q.normal[1:]
+	}
+	q.running++
+
+	// the average wait time is a moving average that favors recent tasks
+	q.avgWait += (time.Since(qt.queued) - q.avgWait) / 8
+
+	return qt.t
+}
+
+// info returns information about the queue.
+func (q *taskQueue) info() *types.TaskQueueInfo {
+	q.Lock()
+	defer q.Unlock()
+
+	info := &types.TaskQueueInfo{
+		Workers:     q.workers,
+		Depth:       q.depth,
+		Queued:      len(q.high) + len(q.normal),
+		Running:     q.running,
+		AvgWaitTime: int64(q.avgWait / time.Millisecond),
+	}
+
+	var oldest time.Time
+	if len(q.high) > 0 {
+		oldest = q.high[0].queued
+	}
+	if len(q.normal) > 0 &&
+		(oldest.IsZero() || q.normal[0].queued.Before(oldest)) {
+		oldest = q.normal[0].queued
+	}
+	if !oldest.IsZero() {
+		info.OldestWaitTime = int64(time.Since(oldest) / time.Millisecond)
+	}
+
+	return info
+}
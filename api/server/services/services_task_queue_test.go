@@ -0,0 +1,204 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func newTestTaskService(t *testing.T) *globalTaskService {
+	config := gofig.New()
+	config.Set(types.ConfigServerTasksStoreType, memTaskStoreName)
+
+	s := &globalTaskService{name: "test-task-service"}
+	if err := s.Init(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newTestTask(s *globalTaskService, run types.TaskRunFunc) *task {
+	t := s.taskTrack(context.Background())
+	t.runFunc = run
+	t.done = make(chan int)
+	t.created = time.Now()
+	return t
+}
+
+func TestTaskQueueDefaults(t *testing.T) {
+	q := newTaskQueue(-1, -1, false)
+	assert.Equal(t, 0, q.workers)
+	assert.Equal(t, 0, q.depth)
+
+	// a queue without a depth is unbounded
+	for i := 0; i < 100; i++ {
+		assert.True(t, q.enqueue(&task{}, types.TaskPriorityNormal))
+	}
+	assert.Equal(t, 100, q.info().Queued)
+}
+
+func TestTaskQueueDepth(t *testing.T) {
+	q := newTaskQueue(1, 2, false)
+
+	t1, t2, t3 := &task{}, &task{}, &task{}
+	assert.True(t, q.enqueue(t1, types.TaskPriorityNormal))
+	assert.True(t, q.enqueue(t2, types.TaskPriorityNormal))
+	assert.False(t, q.enqueue(t3, types.TaskPriorityNormal))
+	assert.False(t, q.enqueue(t3, types.TaskPriorityHigh))
+
+	info := q.info()
+	assert.Equal(t, 1, info.Workers)
+	assert.Equal(t, 2, info.Depth)
+	assert.Equal(t, 2, info.Queued)
+	assert.Equal(t, 0, info.Running)
+
+	// a dequeued task no longer counts against the queue's depth
+	assert.Equal(t, t1, q.dequeue())
+	info = q.info()
+	assert.Equal(t, 1, info.Queued)
+	assert.Equal(t, 1, info.Running)
+	assert.True(t, q.enqueue(t3, types.TaskPriorityNormal))
+
+	assert.Equal(t, t2, q.dequeue())
+	assert.Equal(t, t3, q.dequeue())
+	assert.Equal(t, 0, q.info().Queued)
+}
+
+func TestTaskQueueNoPriority(t *testing.T) {
+	q := newTaskQueue(1, 0, false)
+
+	create, detach := &task{}, &task{}
+	assert.True(t, q.enqueue(create, types.TaskPriorityNormal))
+	assert.True(t, q.enqueue(detach, types.TaskPriorityHigh))
+
+	// without prioritization tasks are dequeued in the order they were
+	// enqueued regardless of their priorities
+	assert.Equal(t, create, q.dequeue())
+	assert.Equal(t, detach, q.dequeue())
+}
+
+func TestTaskQueuePriority(t *testing.T) {
+	q := newTaskQueue(1, 0, true)
+
+	create1, create2 := &task{}, &task{}
+	detach1, detach2 := &task{}, &task{}
+	assert.True(t, q.enqueue(create1, types.TaskPriorityNormal))
+	assert.True(t, q.enqueue(detach1, types.TaskPriorityHigh))
+	assert.True(t, q.enqueue(create2, types.TaskPriorityNormal))
+	assert.True(t, q.enqueue(detach2, types.TaskPriorityHigh))
+	assert.Equal(t, 4, q.info().Queued)
+
+	// detaches are dequeued before creates, and tasks of the same priority
+	// are dequeued in the order they were enqueued
+	assert.Equal(t, detach1, q.dequeue())
+	assert.Equal(t, detach2, q.dequeue())
+	assert.Equal(t, create1, q.dequeue())
+	assert.Equal(t, create2, q.dequeue())
+}
+
+func TestTaskQueueWorkers(t *testing.T) {
+	s := newTestTaskService(t)
+	q := newTaskQueue(2, 0, false)
+	q.start()
+
+	var (
+		started = make(chan int, 4)
+		release = make(chan bool)
+		wg      sync.WaitGroup
+	)
+
+	tasks := []*task{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		tk := newTestTask(s, func(ctx types.Context) (interface{}, error) {
+			defer wg.Done()
+			started <- 1
+			<-release
+			return nil, nil
+		})
+		tasks = append(tasks, tk)
+		assert.True(t, q.enqueue(tk, types.TaskPriorityNormal))
+	}
+
+	// only as many tasks as there are workers are run at once
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for task to start")
+		}
+	}
+	select {
+	case <-started:
+		t.Fatal("more tasks running than workers")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	info := q.info()
+	assert.Equal(t, 2, info.Running)
+	assert.Equal(t, 2, info.Queued)
+
+	close(release)
+	wg.Wait()
+
+	for _, tk := range tasks {
+		select {
+		case <-tk.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for task to complete")
+		}
+		assert.EqualValues(t, types.TaskStateSuccess, tk.State)
+	}
+}
+
+func TestTaskQueueUnboundedWorkers(t *testing.T) {
+	s := newTestTaskService(t)
+	q := newTaskQueue(0, 0, false)
+	q.start()
+
+	var (
+		started = make(chan int, 4)
+		release = make(chan bool)
+	)
+
+	tasks := []*task{}
+	for i := 0; i < 4; i++ {
+		tk := newTestTask(s, func(ctx types.Context) (interface{}, error) {
+			started <- 1
+			<-release
+			return nil, nil
+		})
+		tasks = append(tasks, tk)
+		assert.True(t, q.enqueue(tk, types.TaskPriorityNormal))
+	}
+
+	// a queue without workers runs every task at once
+	for i := 0; i < 4; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for task to start")
+		}
+	}
+
+	info := q.info()
+	assert.Equal(t, 0, info.Workers)
+	assert.Equal(t, 4, info.Running)
+	assert.Equal(t, 0, info.Queued)
+
+	close(release)
+	for _, tk := range tasks {
+		select {
+		case <-tk.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for task to complete")
+		}
+		assert.EqualValues(t, types.TaskStateSuccess, tk.State)
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/registry"
+	"github.com/emccode/libstorage/api/types"
+)
+
+const memTaskStoreName = "mem"
+
+func init() {
+	registry.RegisterTaskStore(memTaskStoreName, newMemTaskStore)
+}
+
+// memTaskStore is a TaskStore that keeps tasks in memory. A task is removed
+// from the store as soon as it is evicted.
+type memTaskStore struct {
+	sync.RWMutex
+	lastID int
+	tasks  map[int]*types.Task
+}
+
+func newMemTaskStore() types.TaskStore {
+	return &memTaskStore{}
+}
+
+func (s *memTaskStore) Name() string {
+	return memTaskStoreName
+}
+
+func (s *memTaskStore) Init(ctx types.Context, config gofig.Config) error {
+	s.lastID = -1
+	s.tasks = map[int]*types.Task{}
+	return nil
+}
+
+func (s *memTaskStore) NextID() int {
+	s.Lock()
+	defer s.Unlock()
+	s.lastID++
+	return s.lastID
+}
+
+func (s *memTaskStore) Save(task *types.Task) error {
+	s.Lock()
+	defer s.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *memTaskStore) Get(taskID int) (*types.Task, error) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.tasks[taskID], nil
+}
+
+func (s *memTaskStore) List() ([]*types.Task, error) {
+	s.RLock()
+	defer s.RUnlock()
+	tasks := []*types.Task{}
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+func (s *memTaskStore) Evict(taskID int) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.tasks, taskID)
+	return nil
+}
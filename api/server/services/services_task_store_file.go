@@ -0,0 +1,288 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/registry"
+	"github.com/emccode/libstorage/api/types"
+)
+
+const (
+	fileTaskStoreName = "file"
+	lastIDFileName    = "lastID"
+	taskFileExt       = ".json"
+)
+
+var errTaskInterrupted = goof.New("task interrupted by server restart")
+
+func init() {
+	registry.RegisterTaskStore(fileTaskStoreName, newFileTaskStore)
+}
+
+// fileTaskStore is a TaskStore that persists each task as a JSON file so
+// that tasks survive a server restart. A task is retained after it is
+// evicted until its retention period elapses.
+type fileTaskStore struct {
+	sync.RWMutex
+	ctx       types.Context
+	dir       string
+	retention time.Duration
+	lastID    int
+}
+
+// fileTask is the persisted form of a task. A task's error cannot be
+// unmarshaled into an error interface, so it is stored as raw JSON along
+// with its message.
+type fileTask struct {
+	types.Task
+	Error        json.RawMessage `json:"error,omitempty"`
+	ErrorMessage string          `json:"errorMessage,omitempty"`
+}
+
+func newFileTaskStore() types.TaskStore {
+	return &fileTaskStore{}
+}
+
+func (s *fileTaskStore) Name() string {
+	return fileTaskStoreName
+}
+
+func (s *fileTaskStore) Init(ctx types.Context, config gofig.Config) error {
+	s.ctx = ctx
+
+	s.dir = config.GetString(types.ConfigServerTasksStorePath)
+	if s.dir == "" {
+		s.dir = types.Lib.Join("tasks")
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return goof.WithFieldE("dir", s.dir, "error creating task store", err)
+	}
+
+	retention, err := time.ParseDuration(
+		config.GetString(types.ConfigServerTasksStoreRetention))
+	if err != nil {
+		retention = time.Duration(time.Hour * 24)
+	}
+	s.retention = retention
+
+	s.lastID = -1
+	if buf, err := ioutil.ReadFile(
+		path.Join(s.dir, lastIDFileName)); err == nil {
+		if id, err := strconv.Atoi(strings.TrimSpace(string(buf))); err == nil {
+			s.lastID = id
+		}
+	}
+
+	tasks, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	for _, t := range tasks {
+		if t.ID > s.lastID {
+			s.lastID = t.ID
+		}
+
+		// tasks that were queued or running when the server stopped will
+		// never complete
+		if t.State == types.TaskStateQueued ||
+			t.State == types.TaskStateRunning {
+			t.State = types.TaskStateError
+			t.Error = errTaskInterrupted
+			t.CompleteTime = now
+			if err := s.Save(t); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.purge()
+
+	ctx.WithFields(log.Fields{
+		"dir":        s.dir,
+		"retention":  s.retention,
+		"lastID":     s.lastID,
+		"len(tasks)": len(tasks),
+	}).Info("initialized task store")
+
+	return nil
+}
+
+func (s *fileTaskStore) NextID() int {
+	s.Lock()
+	defer s.Unlock()
+	s.lastID++
+
+	// the last ID is persisted so IDs are not reused after a restart even if
+	// all of the tasks have been purged
+	if err := ioutil.WriteFile(
+		path.Join(s.dir, lastIDFileName),
+		[]byte(strconv.Itoa(s.lastID)),
+		0644); err != nil {
+		s.ctx.WithError(err).Error("error persisting last task ID")
+	}
+
+	return s.lastID
+}
+
+func (s *fileTaskStore) Save(task *types.Task) error {
+	ft := &fileTask{Task: *task}
+	if task.Error != nil {
+		ft.ErrorMessage = task.Error.Error()
+		buf, err := json.Marshal(task.Error)
+		if err != nil || string(buf) == "{}" {
+			buf, _ = json.Marshal(goof.New(ft.ErrorMessage))
+		}
+		ft.Error = buf
+	}
+
+	buf, err := json.Marshal(ft)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// write the task to a temporary file and then rename it so a task file
+	// is never partially written
+	taskPath := s.taskPath(task.ID)
+	tmpPath := fmt.Sprintf("%s.tmp", taskPath)
+	if err := ioutil.WriteFile(tmpPath, buf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, taskPath)
+}
+
+func (s *fileTaskStore) Get(taskID int) (*types.Task, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	t, err := readTask(s.taskPath(taskID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return t, err
+}
+
+func (s *fileTaskStore) List() ([]*types.Task, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	taskPaths, err := filepath.Glob(path.Join(s.dir, "*"+taskFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*types.Task{}
+	for _, p := range taskPaths {
+		t, err := readTask(p)
+		if err != nil {
+			s.ctx.WithError(err).WithField(
+				"path", p).Warn("error reading task")
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+// Evict removes the task once its retention period elapses. The tasks that
+// are still retained when the server stops are purged by Init.
+func (s *fileTaskStore) Evict(taskID int) error {
+	t, err := s.Get(taskID)
+	if err != nil {
+		return err
+	}
+	if t == nil || t.CompleteTime == 0 {
+		return nil
+	}
+
+	retained := time.Unix(t.CompleteTime, 0).Add(s.retention).Sub(time.Now())
+	if retained <= 0 {
+		return s.remove(taskID)
+	}
+
+	time.AfterFunc(retained, func() {
+		if err := s.remove(taskID); err != nil {
+			s.ctx.WithError(err).WithField(
+				"taskID", taskID).Warn("error evicting task")
+		}
+	})
+	return nil
+}
+
+// purge removes the completed tasks whose retention period has elapsed.
+func (s *fileTaskStore) purge() {
+	tasks, err := s.List()
+	if err != nil {
+		s.ctx.WithError(err).Error("error purging tasks")
+		return
+	}
+
+	expired := time.Now().Add(-s.retention).Unix()
+
+	for _, t := range tasks {
+		if t.CompleteTime == 0 || t.CompleteTime > expired {
+			continue
+		}
+		if err := s.remove(t.ID); err != nil {
+			s.ctx.WithError(err).WithField(
+				"taskID", t.ID).Warn("error purging task")
+		}
+	}
+}
+
+// remove removes the task's file if it exists.
+func (s *fileTaskStore) remove(taskID int) error {
+	s.Lock()
+	defer s.Unlock()
+	if err := os.Remove(s.taskPath(taskID)); err != nil &&
+		!os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *fileTaskStore) taskPath(taskID int) string {
+	return path.Join(s.dir, fmt.Sprintf("%d%s", taskID, taskFileExt))
+}
+
+func readTask(taskPath string) (*types.Task, error) {
+	buf, err := ioutil.ReadFile(taskPath)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &types.Task{}
+	if err := json.Unmarshal(buf, t); err != nil {
+		return nil, err
+	}
+
+	// a task's error is unmarshaled as a TaskError, but not all errors
+	// marshal their messages, so the stored message is used instead
+	var ft struct {
+		ErrorMessage string `json:"errorMessage"`
+	}
+	if err := json.Unmarshal(buf, &ft); err != nil {
+		return nil, err
+	}
+	if te, ok := t.Error.(*types.TaskError); ok && ft.ErrorMessage != "" {
+		te.Message = ft.ErrorMessage
+	}
+	return t, nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func newTestFileTaskStore(
+	t *testing.T, dir, retention string) types.TaskStore {
+
+	config := gofig.New()
+	config.Set(types.ConfigServerTasksStorePath, dir)
+	config.Set(types.ConfigServerTasksStoreRetention, retention)
+
+	s := newFileTaskStore()
+	if err := s.Init(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newTestFileTaskStoreDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "libstorage-tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestFileTaskStoreReopen(t *testing.T) {
+	dir := newTestFileTaskStoreDir(t)
+	defer os.RemoveAll(dir)
+
+	s := newTestFileTaskStore(t, dir, "1h")
+	now := time.Now().Unix()
+
+	t1 := &types.Task{
+		ID:           s.NextID(),
+		User:         "alice",
+		QueueTime:    now,
+		StartTime:    now,
+		CompleteTime: now,
+		State:        types.TaskStateSuccess,
+		Result:       "vfs-000",
+	}
+	t2 := &types.Task{
+		ID:           s.NextID(),
+		QueueTime:    now,
+		StartTime:    now,
+		CompleteTime: now,
+		State:        types.TaskStateError,
+		Error:        errors.New("volume not found"),
+	}
+	assert.NoError(t, s.Save(t1))
+	assert.NoError(t, s.Save(t2))
+
+	s = newTestFileTaskStore(t, dir, "1h")
+
+	tasks, err := s.List()
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	t1r, err := s.Get(t1.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, t1r) {
+		assert.Equal(t, "alice", t1r.User)
+		assert.EqualValues(t, types.TaskStateSuccess, t1r.State)
+		assert.Equal(t, "vfs-000", t1r.Result)
+		assert.Nil(t, t1r.Error)
+	}
+
+	t2r, err := s.Get(t2.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, t2r) {
+		assert.EqualValues(t, types.TaskStateError, t2r.State)
+		assert.EqualError(t, t2r.Error, "volume not found")
+	}
+
+	t3r, err := s.Get(1000000)
+	assert.NoError(t, err)
+	assert.Nil(t, t3r)
+}
+
+func TestFileTaskStoreIDsNotReused(t *testing.T) {
+	dir := newTestFileTaskStoreDir(t)
+	defer os.RemoveAll(dir)
+
+	// with no retention a completed task is removed as soon as it is evicted
+	s := newTestFileTaskStore(t, dir, "0s")
+	now := time.Now().Unix()
+
+	id := s.NextID()
+	assert.NoError(t, s.Save(&types.Task{
+		ID:           id,
+		QueueTime:    now,
+		CompleteTime: now,
+		State:        types.TaskStateSuccess,
+	}))
+	assert.NoError(t, s.Evict(id))
+
+	tasks, err := s.List()
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 0)
+	assert.Equal(t, id+1, s.NextID())
+
+	s = newTestFileTaskStore(t, dir, "0s")
+	assert.Equal(t, id+2, s.NextID())
+}
+
+func TestFileTaskStoreInterrupted(t *testing.T) {
+	dir := newTestFileTaskStoreDir(t)
+	defer os.RemoveAll(dir)
+
+	s := newTestFileTaskStore(t, dir, "1h")
+	now := time.Now().Unix()
+
+	queued := &types.Task{
+		ID:        s.NextID(),
+		QueueTime: now,
+		State:     types.TaskStateQueued,
+	}
+	running := &types.Task{
+		ID:        s.NextID(),
+		QueueTime: now,
+		StartTime: now,
+		State:     types.TaskStateRunning,
+	}
+	assert.NoError(t, s.Save(queued))
+	assert.NoError(t, s.Save(running))
+
+	s = newTestFileTaskStore(t, dir, "1h")
+
+	for _, id := range []int{queued.ID, running.ID} {
+		task, err := s.Get(id)
+		assert.NoError(t, err)
+		if !assert.NotNil(t, task) {
+			continue
+		}
+		assert.EqualValues(t, types.TaskStateError, task.State)
+		assert.EqualError(t, task.Error, errTaskInterrupted.Error())
+		assert.NotZero(t, task.CompleteTime)
+	}
+}
+
+func TestFileTaskStoreRetention(t *testing.T) {
+	dir := newTestFileTaskStoreDir(t)
+	defer os.RemoveAll(dir)
+
+	s := newTestFileTaskStore(t, dir, "1h")
+	now := time.Now()
+
+	old := &types.Task{
+		ID:           s.NextID(),
+		QueueTime:    now.Add(-3 * time.Hour).Unix(),
+		CompleteTime: now.Add(-2 * time.Hour).Unix(),
+		State:        types.TaskStateSuccess,
+	}
+	recent := &types.Task{
+		ID:           s.NextID(),
+		QueueTime:    now.Unix(),
+		CompleteTime: now.Unix(),
+		State:        types.TaskStateSuccess,
+	}
+	running := &types.Task{
+		ID:        s.NextID(),
+		QueueTime: now.Add(-3 * time.Hour).Unix(),
+		StartTime: now.Add(-3 * time.Hour).Unix(),
+		State:     types.TaskStateRunning,
+	}
+	assert.NoError(t, s.Save(old))
+	assert.NoError(t, s.Save(recent))
+	assert.NoError(t, s.Save(running))
+
+	// evicting a task does not remove the other tasks
+	assert.NoError(t, s.Evict(recent.ID))
+	task, err := s.Get(old.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, task)
+
+	assert.NoError(t, s.Evict(old.ID))
+	assert.NoError(t, s.Evict(running.ID))
+
+	task, err = s.Get(old.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+
+	task, err = s.Get(recent.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, task)
+
+	task, err = s.Get(running.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, task)
+
+	// the expired tasks that were never evicted are purged by Init
+	old.ID = s.NextID()
+	assert.NoError(t, s.Save(old))
+	s = newTestFileTaskStore(t, dir, "1h")
+
+	task, err = s.Get(old.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+
+	task, err = s.Get(recent.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, task)
+}
+
+func TestFileTaskStoreEvictAfterRetention(t *testing.T) {
+	dir := newTestFileTaskStoreDir(t)
+	defer os.RemoveAll(dir)
+
+	s := newTestFileTaskStore(t, dir, "1s")
+	now := time.Now().Unix()
+
+	task := &types.Task{
+		ID:           s.NextID(),
+		QueueTime:    now,
+		CompleteTime: now,
+		State:        types.TaskStateSuccess,
+	}
+	assert.NoError(t, s.Save(task))
+	assert.NoError(t, s.Evict(task.ID))
+
+	time.Sleep(1500 * time.Millisecond)
+
+	rt, err := s.Get(task.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, rt)
+}
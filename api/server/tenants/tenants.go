@@ -0,0 +1,213 @@
+/*
+Package tenants provides the namespaces that separate the names of the
+volumes created on behalf of different tenants.
+
+A request's tenant is the name of its authenticated principal or, if the
+request is not authenticated, the value of its Libstorage-Tenant header. The
+server prefixes the name of each volume it creates on behalf of a tenant with
+the tenant's namespace, which is the tenant's name with the separator
+escaped, and a separator. It shows a tenant only the volumes whose names have
+the tenant's prefix, without the prefix, and it rejects the requests of a
+tenant for the volumes and snapshots outside of the tenant's namespace.
+Because the names are rewritten around the calls to the storage drivers, the
+namespaces work with every driver.
+*/
+package tenants
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/auth"
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+)
+
+// Config is the configuration of the tenant namespaces.
+type Config struct {
+
+	// Separator separates a tenant's name from the names of its volumes.
+	Separator string
+
+	// Admins are the names of the principals whose requests are not made on
+	// behalf of a tenant unless they include the Libstorage-Tenant header.
+	// The principal authenticated by the server's admin token is always an
+	// admin.
+	Admins []string
+}
+
+// ParseConfig parses the configuration of the tenant namespaces from the
+// libstorage.server.tenants section of the server's configuration. A nil
+// value is returned if the namespaces are not enabled.
+func ParseConfig(config gofig.Config) (*Config, error) {
+	if !config.GetBool(types.ConfigServerTenantsEnabled) {
+		return nil, nil
+	}
+	c := &Config{
+		Separator: config.GetString(types.ConfigServerTenantsSeparator),
+		Admins:    utils.ConfigStrings(config, types.ConfigServerTenantsAdmins),
+	}
+	if c.Separator == "" {
+		c.Separator = "."
+	}
+	if strings.Contains(c.Separator, "%") {
+		return nil, goof.WithField(
+			"separator", c.Separator, "invalid tenant separator")
+	}
+	return c, nil
+}
+
+// Tenant returns the tenant on whose behalf the request is made. A nil value
+// is returned if the request is made by an admin or neither has a principal
+// nor includes the Libstorage-Tenant header, in which case the request is
+// not confined to a namespace.
+func (c *Config) Tenant(
+	ctx types.Context, req *http.Request) (*types.Tenant, error) {
+
+	// a principal's name may contain the separator, such as a principal
+	// named for a host or an email address, and is escaped in the tenant's
+	// namespace, but a tenant named by a header may not
+	if p, ok := context.Principal(ctx); ok && !c.isAdmin(p) {
+		return &types.Tenant{Name: p.Name, Separator: c.Separator}, nil
+	}
+	name := req.Header.Get(types.TenantHeader)
+	if name == "" {
+		return nil, nil
+	}
+	if strings.Contains(name, c.Separator) {
+		return nil, utils.NewInvalidTenantErr(name)
+	}
+	return &types.Tenant{Name: name, Separator: c.Separator}, nil
+}
+
+func (c *Config) isAdmin(p *types.Principal) bool {
+	if p.Method == auth.MethodAdmin {
+		return true
+	}
+	for _, a := range c.Admins {
+		if strings.EqualFold(a, p.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// VolumeName returns the name in the namespace of the context's tenant of
+// the volume with the provided name. The name is returned unaltered if the
+// context does not have a tenant.
+func VolumeName(ctx types.Context, name string) string {
+	if t, ok := context.Tenant(ctx); ok {
+		return t.VolumeName(name)
+	}
+	return name
+}
+
+// VolumeNamePtr is like VolumeName but for an optional name.
+func VolumeNamePtr(ctx types.Context, name *string) *string {
+	if name == nil {
+		return nil
+	}
+	n := VolumeName(ctx, *name)
+	return &n
+}
+
+// Volume returns a flag indicating whether or not the volume belongs to the
+// context's tenant. If it does, the tenant's prefix is removed from the
+// volume's name. True is returned for every volume if the context does not
+// have a tenant.
+func Volume(ctx types.Context, v *types.Volume) bool {
+	if v == nil {
+		return true
+	}
+	t, ok := context.Tenant(ctx)
+	if !ok {
+		return true
+	}
+	name, ok := t.LocalVolumeName(v.Name)
+	if !ok {
+		return false
+	}
+	v.Name = name
+	return true
+}
+
+// VolumeCheck returns an error if the volume with the provided ID does not
+// belong to the context's tenant. The volume is inspected only if the
+// context has a tenant, and a volume outside of the tenant's namespace is
+// not found.
+func VolumeCheck(
+	ctx types.Context,
+	driver types.StorageDriver,
+	volumeID string,
+	opts types.Store) error {
+
+	if _, ok := context.Tenant(ctx); !ok {
+		return nil
+	}
+	v, err := driver.VolumeInspect(
+		ctx, volumeID, &types.VolumeInspectOpts{Opts: opts})
+	if err != nil {
+		return err
+	}
+	if v == nil || !Volume(ctx, v) {
+		return utils.NewNotFoundError(volumeID)
+	}
+	return nil
+}
+
+// SnapshotCheck returns an error if the volume of the snapshot with the
+// provided ID does not belong to the context's tenant. The snapshot is
+// inspected only if the context has a tenant, and a snapshot of a volume
+// outside of the tenant's namespace is not found.
+func SnapshotCheck(
+	ctx types.Context,
+	driver types.StorageDriver,
+	snapshotID string,
+	opts types.Store) error {
+
+	if _, ok := context.Tenant(ctx); !ok {
+		return nil
+	}
+	s, err := driver.SnapshotInspect(ctx, snapshotID, opts)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return utils.NewNotFoundError(snapshotID)
+	}
+	if err := VolumeCheck(ctx, driver, s.VolumeID, opts); err != nil {
+		if _, ok := err.(*types.ErrNotFound); ok {
+			return utils.NewNotFoundError(snapshotID)
+		}
+		return err
+	}
+	return nil
+}
+
+// VolumeIDs returns the IDs of the volumes that belong to the context's
+// tenant. A nil value is returned if the context does not have a tenant.
+func VolumeIDs(
+	ctx types.Context,
+	driver types.StorageDriver,
+	opts types.Store) (map[string]bool, error) {
+
+	t, ok := context.Tenant(ctx)
+	if !ok {
+		return nil, nil
+	}
+	vols, err := driver.Volumes(ctx, &types.VolumesOpts{Opts: opts})
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]bool{}
+	for _, v := range vols {
+		if _, ok := t.LocalVolumeName(v.Name); ok {
+			ids[v.ID] = true
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,95 @@
+package tenants
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/server/auth"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func newRequest(tenant string) *http.Request {
+	req, _ := http.NewRequest("GET", "/volumes", nil)
+	if tenant != "" {
+		req.Header.Set(types.TenantHeader, tenant)
+	}
+	return req
+}
+
+func TestTenant(t *testing.T) {
+	c := &Config{Separator: ".", Admins: []string{"ops"}}
+	ctx := context.Background()
+
+	tenant, err := c.Tenant(ctx, newRequest(""))
+	assert.NoError(t, err)
+	assert.Nil(t, tenant)
+
+	tenant, err = c.Tenant(ctx, newRequest("acme"))
+	assert.NoError(t, err)
+	assert.Equal(t, &types.Tenant{Name: "acme", Separator: "."}, tenant)
+
+	_, err = c.Tenant(ctx, newRequest("acme.dev"))
+	assert.IsType(t, &types.ErrForbidden{}, err)
+
+	// a principal's tenant is its name regardless of the header
+	alice := ctx.WithValue(context.PrincipalKey, &types.Principal{
+		Name: "alice", Method: auth.MethodToken})
+	tenant, err = c.Tenant(alice, newRequest("acme"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", tenant.Name)
+
+	// a principal's name may contain the separator, which is escaped in the
+	// tenant's namespace
+	host := ctx.WithValue(context.PrincipalKey, &types.Principal{
+		Name: "client.example.com", Method: auth.MethodToken})
+	tenant, err = c.Tenant(host, newRequest(""))
+	assert.NoError(t, err)
+	assert.Equal(t, "client.example.com", tenant.Name)
+	assert.Equal(t, "client%2Eexample%2Ecom", tenant.Namespace())
+
+	// admins are not confined to a tenant unless they ask to be
+	for _, p := range []*types.Principal{
+		{Name: auth.AdminPrincipal, Method: auth.MethodAdmin},
+		{Name: "ops", Method: auth.MethodToken},
+	} {
+		admin := ctx.WithValue(context.PrincipalKey, p)
+		tenant, err = c.Tenant(admin, newRequest(""))
+		assert.NoError(t, err)
+		assert.Nil(t, tenant)
+
+		tenant, err = c.Tenant(admin, newRequest("acme"))
+		assert.NoError(t, err)
+		assert.Equal(t, "acme", tenant.Name)
+	}
+}
+
+func TestVolume(t *testing.T) {
+	ctx := context.Background()
+
+	v := &types.Volume{Name: "acme.db"}
+	assert.Equal(t, "db", VolumeName(ctx, "db"))
+	assert.True(t, Volume(ctx, v))
+	assert.Equal(t, "acme.db", v.Name)
+
+	ctx = ctx.WithValue(
+		context.TenantKey, &types.Tenant{Name: "acme", Separator: "."})
+	assert.Equal(t, "acme.db", VolumeName(ctx, "db"))
+	assert.True(t, Volume(ctx, v))
+	assert.Equal(t, "db", v.Name)
+	assert.False(t, Volume(ctx, &types.Volume{Name: "globex.db"}))
+	assert.False(t, Volume(ctx, &types.Volume{Name: "acme"}))
+
+	// the namespaces of tenants whose names contain the separator do not
+	// overlap the namespaces of other tenants
+	ctx = ctx.WithValue(context.TenantKey, &types.Tenant{
+		Name: "acme.dev", Separator: "."})
+	assert.Equal(t, "acme%2Edev.db", VolumeName(ctx, "db"))
+	assert.False(t, Volume(ctx, &types.Volume{Name: "acme.dev.db"}))
+	ctx = ctx.WithValue(context.TenantKey, &types.Tenant{
+		Name: "acme%2Edev", Separator: "."})
+	assert.Equal(t, "acme%252Edev.db", VolumeName(ctx, "db"))
+	assert.False(t, Volume(ctx, &types.Volume{Name: "acme%2Edev.db"}))
+}
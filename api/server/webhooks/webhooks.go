@@ -0,0 +1,259 @@
+/*
+Package webhooks delivers the server's events to HTTP endpoints.
+
+Each event is POSTed as JSON to the URL of every hook whose filters the event
+matches. A delivery that fails is retried with an exponential backoff, and a
+delivery that fails every attempt is written to the dead-letter log.
+*/
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// maxBackoff is the longest amount of time between two delivery attempts.
+const maxBackoff = time.Duration(time.Minute * 5)
+
+// Hook is an HTTP endpoint to which events are delivered.
+type Hook struct {
+	// URL is the URL to which events are POSTed.
+	URL string
+
+	// Events are the types of the events delivered to the hook. All types
+	// of events are delivered if the list is empty.
+	Events []types.EventType
+
+	// Services are the names of the services whose events are delivered to
+	// the hook. All services' events are delivered if the list is empty.
+	Services []string
+
+	// Secret is the key with which the hook's payloads are signed. The
+	// payloads are not signed if the secret is empty.
+	Secret string
+}
+
+// Config is the configuration of a Dispatcher.
+type Config struct {
+	// Hooks are the endpoints to which events are delivered.
+	Hooks []*Hook
+
+	// Retries is the number of times a failed delivery is retried.
+	Retries int
+
+	// Backoff is the amount of time before the first retry. The amount of
+	// time doubles with each subsequent retry.
+	Backoff time.Duration
+
+	// Timeout is the amount of time after which a delivery attempt fails.
+	Timeout time.Duration
+
+	// DeadLetterLog is the writer to which the deliveries that failed every
+	// attempt are written.
+	DeadLetterLog io.Writer
+}
+
+// Dispatcher delivers events to hooks.
+type Dispatcher struct {
+	ctx            types.Context
+	config         *Config
+	client         *http.Client
+	deadLetterLock sync.Mutex
+	wg             sync.WaitGroup
+}
+
+// deadLetter is an entry in the dead-letter log.
+type deadLetter struct {
+	Time     int64        `json:"time"`
+	URL      string       `json:"url"`
+	Attempts int          `json:"attempts"`
+	Error    string       `json:"error"`
+	Event    *types.Event `json:"event"`
+}
+
+// NewDispatcher returns a new Dispatcher.
+func NewDispatcher(ctx types.Context, config *Config) *Dispatcher {
+	return &Dispatcher{
+		ctx:    ctx,
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Start delivers the events received on the channel until the channel is
+// closed.
+func (d *Dispatcher) Start(events <-chan *types.Event) {
+	go func() {
+		for e := range events {
+			d.Dispatch(e)
+		}
+	}()
+}
+
+// Dispatch delivers the event to the hooks whose filters it matches. The
+// deliveries are made asynchronously.
+func (d *Dispatcher) Dispatch(e *types.Event) {
+	for _, h := range d.config.Hooks {
+		if !h.matches(e) {
+			continue
+		}
+		d.wg.Add(1)
+		go func(h *Hook) {
+			defer d.wg.Done()
+			d.deliver(h, e)
+		}(h)
+	}
+}
+
+// Wait blocks until all of the dispatched deliveries are complete.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (h *Hook) matches(e *types.Event) bool {
+	if len(h.Events) > 0 {
+		ok := false
+		for _, t := range h.Events {
+			if strings.EqualFold(string(t), string(e.Type)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(h.Services) > 0 {
+		ok := false
+		for _, s := range h.Services {
+			if strings.EqualFold(s, e.Service) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Dispatcher) deliver(h *Hook, e *types.Event) {
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		d.ctx.WithError(err).Error("error marshaling webhook event")
+		return
+	}
+
+	fields := log.Fields{"url": h.URL, "eventID": e.ID}
+	backoff := d.config.Backoff
+	attempts := d.config.Retries + 1
+
+	for attempt := 1; ; attempt++ {
+		if err = d.post(h, e, payload); err == nil {
+			d.ctx.WithFields(fields).Debug("delivered webhook event")
+			return
+		}
+
+		d.ctx.WithFields(fields).WithError(err).WithField(
+			"attempt", attempt).Warn("error delivering webhook event")
+
+		if attempt >= attempts {
+			break
+		}
+
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	d.writeDeadLetter(&deadLetter{
+		Time:     time.Now().Unix(),
+		URL:      h.URL,
+		Attempts: attempts,
+		Error:    err.Error(),
+		Event:    e,
+	})
+}
+
+func (d *Dispatcher) post(h *Hook, e *types.Event, payload []byte) error {
+
+	req, err := http.NewRequest("POST", h.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(types.WebhookEventHeader, string(e.Type))
+	req.Header.Set(types.WebhookDeliveryHeader, strconv.FormatInt(e.ID, 10))
+	if h.Secret != "" {
+		req.Header.Set(types.WebhookSignatureHeader, Sign(h.Secret, payload))
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode > 299 {
+		return goof.WithField("status", res.StatusCode, "webhook error")
+	}
+	return nil
+}
+
+func (d *Dispatcher) writeDeadLetter(dl *deadLetter) {
+	d.ctx.WithFields(log.Fields{
+		"url":     dl.URL,
+		"eventID": dl.Event.ID,
+	}).Error("webhook event undeliverable")
+
+	if d.config.DeadLetterLog == nil {
+		return
+	}
+
+	buf, err := json.Marshal(dl)
+	if err != nil {
+		d.ctx.WithError(err).Error("error marshaling dead letter")
+		return
+	}
+
+	d.deadLetterLock.Lock()
+	defer d.deadLetterLock.Unlock()
+	if _, err := fmt.Fprintln(d.config.DeadLetterLog, string(buf)); err != nil {
+		d.ctx.WithError(err).Error("error writing dead letter")
+	}
+}
+
+// Sign returns the signature of a payload as it appears in the
+// Libstorage-Signature header.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Verify returns a flag indicating whether or not the signature is the valid
+// signature of the payload.
+func Verify(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
@@ -0,0 +1,134 @@
+package webhooks
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// defaultDeadLetterLog is the name of the dead-letter log in the libStorage
+// log directory.
+const defaultDeadLetterLog = "webhooks-deadletter.log"
+
+// ParseConfig parses the dispatcher's configuration from the
+// libstorage.server.webhooks section of the server's configuration. A nil
+// value is returned if no hooks are configured.
+func ParseConfig(config gofig.Config) (*Config, error) {
+
+	hooks, err := parseHooks(
+		config.Get(types.ConfigServerWebhooksHooks),
+		config.GetString(types.ConfigServerWebhooksSecret))
+	if err != nil {
+		return nil, err
+	}
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	c := &Config{
+		Hooks:   hooks,
+		Retries: config.GetInt(types.ConfigServerWebhooksRetries),
+	}
+
+	if c.Backoff, err = time.ParseDuration(
+		config.GetString(types.ConfigServerWebhooksBackoff)); err != nil {
+		c.Backoff = time.Duration(time.Second * 1)
+	}
+	if c.Timeout, err = time.ParseDuration(
+		config.GetString(types.ConfigServerWebhooksTimeout)); err != nil {
+		c.Timeout = time.Duration(time.Second * 10)
+	}
+
+	deadLetterPath := config.GetString(types.ConfigServerWebhooksDeadLetterLog)
+	if deadLetterPath == "" {
+		deadLetterPath = types.Log.Join(defaultDeadLetterLog)
+	}
+	if c.DeadLetterLog, err = os.OpenFile(
+		deadLetterPath,
+		os.O_CREATE|os.O_APPEND|os.O_WRONLY,
+		0644); err != nil {
+		return nil, goof.WithFieldE(
+			"path", deadLetterPath, "error opening dead-letter log", err)
+	}
+
+	return c, nil
+}
+
+// parseHooks parses the list of hooks. Each hook is either a URL or a map
+// with the keys url, events, services, and secret. A hook without a secret
+// uses the default secret.
+func parseHooks(v interface{}, defaultSecret string) ([]*Hook, error) {
+
+	if v == nil {
+		return nil, nil
+	}
+
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, goof.WithField(
+			"configKey", types.ConfigServerWebhooksHooks, "invalid format")
+	}
+
+	hooks := []*Hook{}
+	for _, lv := range list {
+		h := &Hook{Secret: defaultSecret}
+
+		switch tv := lv.(type) {
+		case string:
+			h.URL = tv
+		case map[string]interface{}:
+			parseHook(h, tv)
+		case map[interface{}]interface{}:
+			m := map[string]interface{}{}
+			for k, v := range tv {
+				m[fmt.Sprintf("%v", k)] = v
+			}
+			parseHook(h, m)
+		default:
+			return nil, goof.WithField(
+				"configKey", types.ConfigServerWebhooksHooks, "invalid format")
+		}
+
+		if h.URL == "" {
+			return nil, goof.WithField(
+				"configKey", types.ConfigServerWebhooksHooks, "missing url")
+		}
+		hooks = append(hooks, h)
+	}
+
+	return hooks, nil
+}
+
+func parseHook(h *Hook, m map[string]interface{}) {
+	if v, ok := m["url"].(string); ok {
+		h.URL = v
+	}
+	if v, ok := m["secret"].(string); ok {
+		h.Secret = v
+	}
+	for _, v := range toStrings(m["events"]) {
+		h.Events = append(h.Events, types.EventType(v))
+	}
+	h.Services = toStrings(m["services"])
+}
+
+func toStrings(v interface{}) []string {
+	switch tv := v.(type) {
+	case string:
+		return []string{tv}
+	case []string:
+		return tv
+	case []interface{}:
+		s := []string{}
+		for _, iv := range tv {
+			s = append(s, fmt.Sprintf("%v", iv))
+		}
+		return s
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+type receiver struct {
+	sync.Mutex
+	failures int
+	events   []*types.Event
+	headers  []http.Header
+	payloads [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	buf, _ := ioutil.ReadAll(req.Body)
+	e := &types.Event{}
+	json.Unmarshal(buf, e)
+
+	r.events = append(r.events, e)
+	r.headers = append(r.headers, req.Header)
+	r.payloads = append(r.payloads, buf)
+}
+
+func newEvent(id int64, eventType types.EventType) *types.Event {
+	return &types.Event{
+		ID:       id,
+		Type:     eventType,
+		Time:     time.Now().Unix(),
+		Service:  "vfs",
+		VolumeID: "vfs-000",
+		Outcome:  types.EventOutcomeSuccess,
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	r := &receiver{}
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	d := NewDispatcher(context.Background(), &Config{
+		Hooks: []*Hook{
+			&Hook{
+				URL:    s.URL,
+				Events: []types.EventType{types.EventVolumeCreate},
+				Secret: "secret",
+			},
+		},
+		Timeout: time.Second * 5,
+	})
+
+	d.Dispatch(newEvent(1, types.EventVolumeCreate))
+	d.Dispatch(newEvent(2, types.EventVolumeRemove))
+	d.Wait()
+
+	if !assert.Len(t, r.events, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, int64(1), r.events[0].ID)
+	assert.Equal(t, "vfs-000", r.events[0].VolumeID)
+	assert.Equal(t, "volumeCreate", r.headers[0].Get(types.WebhookEventHeader))
+	assert.Equal(t, "1", r.headers[0].Get(types.WebhookDeliveryHeader))
+	assert.True(t, Verify(
+		"secret",
+		r.payloads[0],
+		r.headers[0].Get(types.WebhookSignatureHeader)))
+	assert.False(t, Verify(
+		"wrong",
+		r.payloads[0],
+		r.headers[0].Get(types.WebhookSignatureHeader)))
+}
+
+func TestDispatchRetry(t *testing.T) {
+	r := &receiver{failures: 2}
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	deadLetters := &bytes.Buffer{}
+	d := NewDispatcher(context.Background(), &Config{
+		Hooks:         []*Hook{&Hook{URL: s.URL}},
+		Retries:       2,
+		Backoff:       time.Millisecond,
+		Timeout:       time.Second * 5,
+		DeadLetterLog: deadLetters,
+	})
+
+	d.Dispatch(newEvent(1, types.EventVolumeAttach))
+	d.Wait()
+
+	assert.Len(t, r.events, 1)
+	assert.Equal(t, 0, deadLetters.Len())
+}
+
+func TestDispatchDeadLetter(t *testing.T) {
+	r := &receiver{failures: 3}
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	deadLetters := &bytes.Buffer{}
+	d := NewDispatcher(context.Background(), &Config{
+		Hooks:         []*Hook{&Hook{URL: s.URL}},
+		Retries:       2,
+		Backoff:       time.Millisecond,
+		Timeout:       time.Second * 5,
+		DeadLetterLog: deadLetters,
+	})
+
+	d.Dispatch(newEvent(1, types.EventVolumeDetach))
+	d.Wait()
+
+	assert.Len(t, r.events, 0)
+
+	dl := &deadLetter{}
+	if !assert.NoError(t, json.Unmarshal(deadLetters.Bytes(), dl)) {
+		t.FailNow()
+	}
+	assert.Equal(t, s.URL, dl.URL)
+	assert.Equal(t, 3, dl.Attempts)
+	assert.Equal(t, int64(1), dl.Event.ID)
+}
+
+func TestParseHooks(t *testing.T) {
+	hooks, err := parseHooks([]interface{}{
+		"http://localhost:8080/a",
+		map[interface{}]interface{}{
+			"url":      "http://localhost:8080/b",
+			"events":   []interface{}{"volumeCreate", "volumeRemove"},
+			"services": "vfs",
+			"secret":   "b",
+		},
+	}, "default")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.Len(t, hooks, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, "http://localhost:8080/a", hooks[0].URL)
+	assert.Equal(t, "default", hooks[0].Secret)
+	assert.Len(t, hooks[0].Events, 0)
+	assert.Equal(t, "http://localhost:8080/b", hooks[1].URL)
+	assert.Equal(t, "b", hooks[1].Secret)
+	assert.Equal(t, []types.EventType{
+		types.EventVolumeCreate, types.EventVolumeRemove}, hooks[1].Events)
+	assert.Equal(t, []string{"vfs"}, hooks[1].Services)
+
+	_, err = parseHooks([]interface{}{map[string]interface{}{}}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package types
+
+import "time"
+
+// AuditOutcome is the outcome of an audited operation.
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess is the outcome of an operation that succeeded.
+	AuditOutcomeSuccess AuditOutcome = "success"
+
+	// AuditOutcomeError is the outcome of an operation that failed or was
+	// rejected.
+	AuditOutcomeError AuditOutcome = "error"
+
+	// AuditOutcomeCanceled is the outcome of an operation that was canceled.
+	AuditOutcomeCanceled AuditOutcome = "canceled"
+)
+
+// AuditRecord is the record of an operation that mutates a storage service's
+// volumes or snapshots.
+type AuditRecord struct {
+
+	// Time is the time at which the operation was requested.
+	Time time.Time `json:"time" yaml:"time"`
+
+	// TransactionID is the ID of the request's transaction.
+	TransactionID string `json:"txID,omitempty" yaml:"txID,omitempty"`
+
+	// Principal is the name of the principal that made the request.
+	Principal string `json:"principal,omitempty" yaml:"principal,omitempty"`
+
+	// RemoteAddr is the address from which the request was made.
+	RemoteAddr string `json:"remoteAddr,omitempty" yaml:"remoteAddr,omitempty"`
+
+	// InstanceID is the ID of the instance on whose behalf the request was
+	// made.
+	InstanceID string `json:"instanceID,omitempty" yaml:"instanceID,omitempty"`
+
+	// Service is the name of the storage service.
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+
+	// Operation is the name of the route of the request.
+	Operation string `json:"operation" yaml:"operation"`
+
+	// VolumeID is the ID of the volume on which the operation was performed
+	// or, if the operation created a volume or snapshot, the ID of the
+	// created volume or of the snapshot's volume.
+	VolumeID string `json:"volumeID,omitempty" yaml:"volumeID,omitempty"`
+
+	// SnapshotID is the ID of the snapshot on which the operation was
+	// performed or the ID of the snapshot the operation created.
+	SnapshotID string `json:"snapshotID,omitempty" yaml:"snapshotID,omitempty"`
+
+	// TaskID is the ID of the task that performed the operation.
+	TaskID int `json:"taskID,omitempty" yaml:"taskID,omitempty"`
+
+	// Body is the request's body.
+	Body interface{} `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// Outcome is the operation's outcome.
+	Outcome AuditOutcome `json:"outcome" yaml:"outcome"`
+
+	// Status is the HTTP status of the response.
+	Status int `json:"status" yaml:"status"`
+
+	// Error is the error with which the operation failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Duration is the number of seconds the operation took to complete.
+	Duration float64 `json:"duration" yaml:"duration"`
+}
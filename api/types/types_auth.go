@@ -0,0 +1,122 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AuthVerb is a class of operations that a principal may be allowed to
+// perform on a storage service.
+type AuthVerb string
+
+const (
+	// AuthVerbRead is the verb for inspecting services, volumes, and
+	// snapshots.
+	AuthVerbRead AuthVerb = "read"
+
+	// AuthVerbCreate is the verb for creating, copying, and resizing volumes.
+	AuthVerbCreate = "create"
+
+	// AuthVerbAttach is the verb for attaching and detaching volumes.
+	AuthVerbAttach = "attach"
+
+	// AuthVerbRemove is the verb for removing volumes and snapshots.
+	AuthVerbRemove = "remove"
+
+	// AuthVerbSnapshot is the verb for snapshotting volumes and copying
+	// snapshots.
+	AuthVerbSnapshot = "snapshot"
+
+	// AuthWildcard matches any service or verb in an AuthRule.
+	AuthWildcard = "*"
+)
+
+// AuthRule allows a principal to perform verbs on services.
+type AuthRule struct {
+	// Services are the names of the services to which the rule applies.
+	Services []string `json:"services" yaml:"services"`
+
+	// Verbs are the verbs the rule allows.
+	Verbs []AuthVerb `json:"verbs" yaml:"verbs"`
+}
+
+// Allows returns a flag indicating whether or not the rule allows the verb
+// to be performed on the service. An empty service name matches any service
+// in the rule.
+func (r *AuthRule) Allows(service string, verb AuthVerb) bool {
+	serviceOK := service == ""
+	for _, s := range r.Services {
+		if s == AuthWildcard || strings.EqualFold(s, service) {
+			serviceOK = true
+			break
+		}
+	}
+	if !serviceOK {
+		return false
+	}
+	for _, v := range r.Verbs {
+		if v == AuthWildcard || strings.EqualFold(string(v), string(verb)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal is the authenticated identity on whose behalf a request is
+// made.
+type Principal struct {
+	// Name is the principal's name.
+	Name string `json:"name" yaml:"name"`
+
+	// Method is the name of the method with which the principal was
+	// authenticated.
+	Method string `json:"method" yaml:"method"`
+
+	// Claims are the claims of the token with which the principal was
+	// authenticated if the token is a JWT, or the identities of the
+	// certificate with which the principal was authenticated.
+	Claims map[string]interface{} `json:"claims,omitempty" yaml:"claims,omitempty"`
+
+	// InstanceID is the ID of the only instance on whose behalf the
+	// principal may make requests. The principal may make requests on
+	// behalf of any instance if the ID is empty.
+	InstanceID string `json:"instanceID,omitempty" yaml:"instanceID,omitempty"`
+
+	// Rules are the rules that define what the principal is allowed to do.
+	Rules []*AuthRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// String returns the principal's name.
+func (p *Principal) String() string {
+	return p.Name
+}
+
+// Authorized returns a flag indicating whether or not the principal is
+// allowed to perform the verb on the service. An empty service name asks
+// whether the principal is allowed to perform the verb on any service.
+func (p *Principal) Authorized(service string, verb AuthVerb) bool {
+	for _, r := range p.Rules {
+		if r.Allows(service, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// PeerCredentials are the credentials of the process on the other end of a
+// UNIX socket connection.
+type PeerCredentials struct {
+	// PID is the process's ID.
+	PID int `json:"pid" yaml:"pid"`
+
+	// UID is the ID of the process's user.
+	UID int `json:"uid" yaml:"uid"`
+
+	// GID is the ID of the process's group.
+	GID int `json:"gid" yaml:"gid"`
+}
+
+// String returns the credentials as "pid=PID,uid=UID,gid=GID".
+func (c *PeerCredentials) String() string {
+	return fmt.Sprintf("pid=%d,uid=%d,gid=%d", c.PID, c.UID, c.GID)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"io"
 	"strings"
+	"time"
 )
 
 // ClientType is a client's type.
@@ -72,6 +73,15 @@ type ProvidesAPIClient interface {
 	API() APIClient
 }
 
+// HeaderSigner signs the values of the headers that identify a client's
+// instance.
+type HeaderSigner interface {
+
+	// SignHeader returns the value of the signature header for a value of
+	// the header with the provided name.
+	SignHeader(name, value string) (string, error)
+}
+
 // APIClient is the libStorage API client used for communicating with a remote
 // libStorage endpoint.
 type APIClient interface {
@@ -88,6 +98,27 @@ type APIClient interface {
 	// LogResponses enables or disables the logging of client HTTP responses.
 	LogResponses(enabled bool)
 
+	// AuthToken sets the bearer token with which the client authenticates
+	// its requests. Requests are not authenticated if the token is empty.
+	AuthToken(token string)
+
+	// Tenant sets the name of the tenant on whose behalf the client makes its
+	// requests if the server does not derive the tenant from the client's
+	// credentials. Requests are not made on behalf of a tenant if the name
+	// is empty.
+	Tenant(name string)
+
+	// SignHeaders sets the signer with which the client signs the headers
+	// that identify its instance. The headers are not signed if the signer
+	// is nil.
+	SignHeaders(signer HeaderSigner)
+
+	// Retries sets the maximum number of times the client retries a request
+	// rejected with a 429 or 503 status and a Retry-After header, as well as
+	// the longest Retry-After wait the client honors. Requests are not
+	// retried if max is zero.
+	Retries(max int, maxWait time.Duration)
+
 	// Root returns a list of root resources.
 	Root(ctx Context) ([]string, error)
 
@@ -114,6 +145,21 @@ type APIClient interface {
 		service string,
 		attachments bool) (VolumeMap, error)
 
+	// VolumesIterator returns an iterator over all Volumes for all Services.
+	// The volumes are retrieved from the server one page at a time.
+	VolumesIterator(
+		ctx Context,
+		attachments bool,
+		opts *PageOpts) (VolumeIterator, error)
+
+	// VolumesByServiceIterator returns an iterator over all Volumes for a
+	// service. The volumes are retrieved from the server one page at a time.
+	VolumesByServiceIterator(
+		ctx Context,
+		service string,
+		attachments bool,
+		opts *PageOpts) (VolumeIterator, error)
+
 	// VolumeInspect gets information about a single volume.
 	VolumeInspect(
 		ctx Context,
@@ -138,6 +184,12 @@ type APIClient interface {
 		service, volumeID string,
 		request *VolumeCopyRequest) (*Volume, error)
 
+	// VolumeResize expands a single volume.
+	VolumeResize(
+		ctx Context,
+		service, volumeID string,
+		request *VolumeResizeRequest) (*Volume, error)
+
 	// VolumeRemove removes a single volume.
 	VolumeRemove(
 		ctx Context,
@@ -182,6 +234,21 @@ type APIClient interface {
 	SnapshotsByService(
 		ctx Context, service string) (SnapshotMap, error)
 
+	// SnapshotsIterator returns an iterator over all Snapshots for all
+	// services. The snapshots are retrieved from the server one page at a
+	// time.
+	SnapshotsIterator(
+		ctx Context,
+		opts *PageOpts) (SnapshotIterator, error)
+
+	// SnapshotsByServiceIterator returns an iterator over all Snapshots for a
+	// single service. The snapshots are retrieved from the server one page
+	// at a time.
+	SnapshotsByServiceIterator(
+		ctx Context,
+		service string,
+		opts *PageOpts) (SnapshotIterator, error)
+
 	// SnapshotInspect gets information about a single snapshot.
 	SnapshotInspect(
 		ctx Context,
@@ -198,6 +265,41 @@ type APIClient interface {
 		service, snapshotID string,
 		request *SnapshotCopyRequest) (*Snapshot, error)
 
+	// Tasks returns all of the server's tasks, keyed by their IDs.
+	Tasks(ctx Context) (map[string]*Task, error)
+
+	// TasksIterator returns an iterator over the server's tasks. The tasks
+	// are retrieved from the server one page at a time.
+	TasksIterator(
+		ctx Context,
+		opts *PageOpts) (TaskIterator, error)
+
+	// TaskInspect gets information about a single task.
+	TaskInspect(ctx Context, taskID int) (*Task, error)
+
+	// TaskWait blocks until the task is complete or the timeout expires and
+	// then returns the task. The task's state should be checked to
+	// determine whether or not it is complete.
+	TaskWait(
+		ctx Context,
+		taskID int,
+		timeout time.Duration) (*Task, error)
+
+	// Events subscribes to the server's events. The events that match the
+	// provided options are received on the returned channel, which is closed
+	// when the context is canceled or the server closes the connection.
+	Events(
+		ctx Context,
+		opts *EventsOpts) (<-chan *Event, error)
+
+	// Quotas returns the quotas of all of the services, keyed by the
+	// services' names. A service's quotas are its own quota as well as the
+	// quotas of the client's tenant and instance.
+	Quotas(ctx Context) (ServiceQuotaMap, error)
+
+	// QuotasByService returns the quotas of a single service.
+	QuotasByService(ctx Context, service string) ([]*Quota, error)
+
 	// Executors returns information about the executors.
 	Executors(
 		ctx Context) (map[string]*ExecutorInfo, error)
@@ -123,4 +123,208 @@ const (
 
 	// ConfigServerTasksLogTimeout is a config key.
 	ConfigServerTasksLogTimeout = ConfigServerTasks + ".logTimeout"
+
+	// ConfigServerTasksQueue is a config key.
+	ConfigServerTasksQueue = ConfigServerTasks + ".queue"
+
+	// ConfigServerTasksQueueWorkers is a config key.
+	ConfigServerTasksQueueWorkers = ConfigServerTasksQueue + ".workers"
+
+	// ConfigServerTasksQueueDepth is a config key.
+	ConfigServerTasksQueueDepth = ConfigServerTasksQueue + ".depth"
+
+	// ConfigServerTasksQueuePrioritize is a config key.
+	ConfigServerTasksQueuePrioritize = ConfigServerTasksQueue + ".prioritize"
+
+	// ConfigServerTasksQueueRetryAfter is a config key.
+	ConfigServerTasksQueueRetryAfter = ConfigServerTasksQueue + ".retryAfter"
+
+	// ConfigServerTasksStore is a config key.
+	ConfigServerTasksStore = ConfigServerTasks + ".store"
+
+	// ConfigServerTasksStoreType is a config key.
+	ConfigServerTasksStoreType = ConfigServerTasksStore + ".type"
+
+	// ConfigServerTasksStorePath is a config key.
+	ConfigServerTasksStorePath = ConfigServerTasksStore + ".path"
+
+	// ConfigServerTasksStoreRetention is a config key.
+	ConfigServerTasksStoreRetention = ConfigServerTasksStore + ".retention"
+
+	// ConfigServerWebhooks is a config key.
+	ConfigServerWebhooks = ConfigServer + ".webhooks"
+
+	// ConfigServerWebhooksHooks is a config key.
+	ConfigServerWebhooksHooks = ConfigServerWebhooks + ".hooks"
+
+	// ConfigServerWebhooksSecret is a config key.
+	ConfigServerWebhooksSecret = ConfigServerWebhooks + ".secret"
+
+	// ConfigServerWebhooksRetries is a config key.
+	ConfigServerWebhooksRetries = ConfigServerWebhooks + ".retries"
+
+	// ConfigServerWebhooksBackoff is a config key.
+	ConfigServerWebhooksBackoff = ConfigServerWebhooks + ".backoff"
+
+	// ConfigServerWebhooksTimeout is a config key.
+	ConfigServerWebhooksTimeout = ConfigServerWebhooks + ".timeout"
+
+	// ConfigServerWebhooksDeadLetterLog is a config key.
+	ConfigServerWebhooksDeadLetterLog = ConfigServerWebhooks + ".deadLetterLog"
+
+	// ConfigServerMetrics is a config key.
+	ConfigServerMetrics = ConfigServer + ".metrics"
+
+	// ConfigServerMetricsToken is a config key.
+	ConfigServerMetricsToken = ConfigServerMetrics + ".token"
+
+	// ConfigServerMetricsAnonymous is a config key.
+	ConfigServerMetricsAnonymous = ConfigServerMetrics + ".anonymous"
+
+	// ConfigServerAuth is a config key.
+	ConfigServerAuth = ConfigServer + ".auth"
+
+	// ConfigServerAuthTokens is a config key.
+	ConfigServerAuthTokens = ConfigServerAuth + ".tokens"
+
+	// ConfigServerAuthPrincipals is a config key.
+	ConfigServerAuthPrincipals = ConfigServerAuth + ".principals"
+
+	// ConfigServerAuthAnonymousRoutes is a config key.
+	ConfigServerAuthAnonymousRoutes = ConfigServerAuth + ".anonymousRoutes"
+
+	// ConfigServerAuthCertificates is a config key.
+	ConfigServerAuthCertificates = ConfigServerAuth + ".certificates"
+
+	// ConfigServerAuthPeers is a config key.
+	ConfigServerAuthPeers = ConfigServerAuth + ".peers"
+
+	// ConfigServerAuthJWT is a config key.
+	ConfigServerAuthJWT = ConfigServerAuth + ".jwt"
+
+	// ConfigServerAuthJWTKey is a config key.
+	ConfigServerAuthJWTKey = ConfigServerAuthJWT + ".key"
+
+	// ConfigServerAuthJWTPublicKey is a config key.
+	ConfigServerAuthJWTPublicKey = ConfigServerAuthJWT + ".publicKey"
+
+	// ConfigServerAuthJWTIssuer is a config key.
+	ConfigServerAuthJWTIssuer = ConfigServerAuthJWT + ".issuer"
+
+	// ConfigServerAuthJWTAudience is a config key.
+	ConfigServerAuthJWTAudience = ConfigServerAuthJWT + ".audience"
+
+	// ConfigServerAuthJWTPrincipalClaim is a config key.
+	ConfigServerAuthJWTPrincipalClaim = ConfigServerAuthJWT + ".principalClaim"
+
+	// ConfigServerSigning is a config key.
+	ConfigServerSigning = ConfigServer + ".signing"
+
+	// ConfigServerSigningEnabled is a config key.
+	ConfigServerSigningEnabled = ConfigServerSigning + ".enabled"
+
+	// ConfigServerSigningKey is a config key.
+	ConfigServerSigningKey = ConfigServerSigning + ".key"
+
+	// ConfigServerSigningPublicKey is a config key.
+	ConfigServerSigningPublicKey = ConfigServerSigning + ".publicKey"
+
+	// ConfigServerSigningInstances is a config key.
+	ConfigServerSigningInstances = ConfigServerSigning + ".instances"
+
+	// ConfigServerSigningMaxAge is a config key.
+	ConfigServerSigningMaxAge = ConfigServerSigning + ".maxAge"
+
+	// ConfigClientSigningKey is a config key.
+	ConfigClientSigningKey = ConfigClient + ".signing.key"
+
+	// ConfigClientSigningPrivateKey is a config key.
+	ConfigClientSigningPrivateKey = ConfigClient + ".signing.privateKey"
+
+	// ConfigClientAuthToken is a config key.
+	ConfigClientAuthToken = ConfigClient + ".auth.token"
+
+	// ConfigServerRateLimit is a config key.
+	ConfigServerRateLimit = ConfigServer + ".rateLimit"
+
+	// ConfigServerRateLimitKey is a config key.
+	ConfigServerRateLimitKey = ConfigServerRateLimit + ".key"
+
+	// ConfigServerRateLimitReadRate is a config key.
+	ConfigServerRateLimitReadRate = ConfigServerRateLimit + ".read.rate"
+
+	// ConfigServerRateLimitReadBurst is a config key.
+	ConfigServerRateLimitReadBurst = ConfigServerRateLimit + ".read.burst"
+
+	// ConfigServerRateLimitMutateRate is a config key.
+	ConfigServerRateLimitMutateRate = ConfigServerRateLimit + ".mutate.rate"
+
+	// ConfigServerRateLimitMutateBurst is a config key.
+	ConfigServerRateLimitMutateBurst = ConfigServerRateLimit + ".mutate.burst"
+
+	// ConfigServerDriverCalls is a config key.
+	ConfigServerDriverCalls = ConfigServer + ".driverCalls"
+
+	// ConfigServerDriverCallsMax is a config key.
+	ConfigServerDriverCallsMax = ConfigServerDriverCalls + ".max"
+
+	// ConfigServerDriverCallsMaxWait is a config key.
+	ConfigServerDriverCallsMaxWait = ConfigServerDriverCalls + ".maxWait"
+
+	// ConfigServerQuotas is a config key.
+	ConfigServerQuotas = ConfigServer + ".quotas"
+
+	// ConfigServerQuotasCacheTTL is a config key.
+	ConfigServerQuotasCacheTTL = ConfigServerQuotas + ".cacheTTL"
+
+	// ConfigServerQuotasService is a config key.
+	ConfigServerQuotasService = ConfigServerQuotas + ".service"
+
+	// ConfigServerQuotasTenants is a config key.
+	ConfigServerQuotasTenants = ConfigServerQuotas + ".tenants"
+
+	// ConfigServerQuotasInstances is a config key.
+	ConfigServerQuotasInstances = ConfigServerQuotas + ".instances"
+
+	// ConfigServerTenants is a config key.
+	ConfigServerTenants = ConfigServer + ".tenants"
+
+	// ConfigServerTenantsEnabled is a config key.
+	ConfigServerTenantsEnabled = ConfigServerTenants + ".enabled"
+
+	// ConfigServerTenantsSeparator is a config key.
+	ConfigServerTenantsSeparator = ConfigServerTenants + ".separator"
+
+	// ConfigServerTenantsAdmins is a config key.
+	ConfigServerTenantsAdmins = ConfigServerTenants + ".admins"
+
+	// ConfigServerAudit is a config key.
+	ConfigServerAudit = ConfigServer + ".audit"
+
+	// ConfigServerAuditEnabled is a config key.
+	ConfigServerAuditEnabled = ConfigServerAudit + ".enabled"
+
+	// ConfigServerAuditFile is a config key.
+	ConfigServerAuditFile = ConfigServerAudit + ".file"
+
+	// ConfigServerAuditMaxSize is a config key.
+	ConfigServerAuditMaxSize = ConfigServerAudit + ".maxSize"
+
+	// ConfigServerAuditMaxFiles is a config key.
+	ConfigServerAuditMaxFiles = ConfigServerAudit + ".maxFiles"
+
+	// ConfigServerIdempotency is a config key.
+	ConfigServerIdempotency = ConfigServer + ".idempotency"
+
+	// ConfigServerIdempotencyWindow is a config key.
+	ConfigServerIdempotencyWindow = ConfigServerIdempotency + ".window"
+
+	// ConfigClientTenant is a config key.
+	ConfigClientTenant = ConfigClient + ".tenant"
+
+	// ConfigClientRetries is a config key.
+	ConfigClientRetries = ConfigClient + ".retries"
+
+	// ConfigClientRetryMaxWait is a config key.
+	ConfigClientRetryMaxWait = ConfigClient + ".retryMaxWait"
 )
@@ -65,6 +65,15 @@ type ClientDriver interface {
 	// VolumeCopyAfter provides an opportunity to inspect/mutate the result.
 	VolumeCopyAfter(ctx Context, result *Volume)
 
+	// VolumeResizeBefore may return an error, preventing the operation.
+	VolumeResizeBefore(
+		ctx *Context,
+		service, volumeID string,
+		request *VolumeResizeRequest) error
+
+	// VolumeResizeAfter provides an opportunity to inspect/mutate the result.
+	VolumeResizeAfter(ctx Context, result *Volume)
+
 	// VolumeRemoveBefore may return an error, preventing the operation.
 	VolumeRemoveBefore(
 		ctx *Context,
@@ -93,4 +93,11 @@ type IntegrationDriver interface {
 		ctx Context,
 		volumeName string,
 		opts *VolumeDetachOpts) error
+
+	// Resize will expand a volume based on volumeName and, if the volume is
+	// mounted on this instance, grow its file system to the new size.
+	Resize(
+		ctx Context,
+		volumeName string,
+		opts *VolumeResizeOpts) (*Volume, error)
 }
@@ -17,6 +17,12 @@ type DeviceFormatOpts struct {
 	Opts        Store
 }
 
+// DeviceResizeOpts are options when growing a device's file system.
+type DeviceResizeOpts struct {
+	MountPoint string
+	Opts       Store
+}
+
 // OSDriverManager is the management wrapper for an OSDriver.
 type OSDriverManager interface {
 	OSDriver
@@ -59,4 +65,12 @@ type OSDriver interface {
 		ctx Context,
 		deviceName string,
 		opts *DeviceFormatOpts) error
+
+	// Resize grows a device's file system to fill the device. File systems
+	// that may only be grown while they are mounted, such as XFS, require
+	// the mount point.
+	Resize(
+		ctx Context,
+		deviceName string,
+		opts *DeviceResizeOpts) error
 }
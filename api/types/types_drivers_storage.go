@@ -40,6 +40,51 @@ type VolumeDetachOpts struct {
 	Opts  Store
 }
 
+// VolumeResizeOpts are options for resizing a volume.
+type VolumeResizeOpts struct {
+	Size  int64
+	Force bool
+	Opts  Store
+}
+
+// StorageCapability is an operation that a storage driver may not support.
+type StorageCapability string
+
+const (
+	// StorageCapabilitySnapshots is the capability to snapshot volumes and
+	// to list, inspect, and remove snapshots.
+	StorageCapabilitySnapshots StorageCapability = "snapshots"
+
+	// StorageCapabilityVolumeCopy is the capability to copy volumes.
+	StorageCapabilityVolumeCopy StorageCapability = "volumeCopy"
+
+	// StorageCapabilitySnapshotCopy is the capability to copy snapshots.
+	StorageCapabilitySnapshotCopy StorageCapability = "snapshotCopy"
+
+	// StorageCapabilityCreateFromSnapshot is the capability to create volumes
+	// from snapshots.
+	StorageCapabilityCreateFromSnapshot StorageCapability = "createFromSnapshot"
+
+	// StorageCapabilityResize is the capability to resize volumes.
+	StorageCapabilityResize StorageCapability = "resize"
+
+	// StorageCapabilityMultiAttach is the capability to attach a volume to
+	// more than one instance at a time.
+	StorageCapabilityMultiAttach StorageCapability = "multiAttach"
+)
+
+// StorageDriverCapabilities is an optional interface that a StorageDriver
+// implements to describe the operations it supports. The server rejects the
+// requests for the operations a driver does not support rather than invoking
+// the driver. The operations of a driver that does not implement the
+// interface are not restricted.
+type StorageDriverCapabilities interface {
+
+	// Capabilities returns the driver's capabilities. The capabilities are
+	// read once, after the driver is initialized.
+	Capabilities(ctx Context) (*StorageCapabilities, error)
+}
+
 // StorageDriverManager is the management wrapper for a StorageDriver.
 type StorageDriverManager interface {
 	StorageDriver
@@ -103,6 +148,12 @@ type StorageDriver interface {
 		volumeName string,
 		opts Store) (*Volume, error)
 
+	// VolumeResize expands a volume to a new size. A volume may not be shrunk.
+	VolumeResize(
+		ctx Context,
+		volumeID string,
+		opts *VolumeResizeOpts) (*Volume, error)
+
 	// VolumeSnapshot snapshots a volume.
 	VolumeSnapshot(
 		ctx Context,
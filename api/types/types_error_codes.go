@@ -0,0 +1,352 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/akutz/goof"
+)
+
+// ErrorCode is a stable, machine-readable string that identifies the kind of
+// an error returned by the libStorage API. A response's error code is
+// included in the JSON error body as the value of the "code" key.
+type ErrorCode string
+
+const (
+	// ErrorCodeNotImplemented is the code of an ErrUnimplemented error.
+	ErrorCodeNotImplemented ErrorCode = "NOT_IMPLEMENTED"
+
+	// ErrorCodeInvalidRequest is the code of an ErrInvalidRequest error.
+	ErrorCodeInvalidRequest ErrorCode = "INVALID_REQUEST"
+
+	// ErrorCodeInvalidFilter is the code of an ErrBadFilter error.
+	ErrorCodeInvalidFilter ErrorCode = "INVALID_FILTER"
+
+	// ErrorCodeInvalidPageOpts is the code of an ErrBadPageOpts error.
+	ErrorCodeInvalidPageOpts ErrorCode = "INVALID_PAGE_OPTS"
+
+	// ErrorCodeMissingInstanceID is the code of an ErrMissingInstanceID
+	// error.
+	ErrorCodeMissingInstanceID ErrorCode = "MISSING_INSTANCE_ID"
+
+	// ErrorCodeBadAdminToken is the code of an ErrBadAdminToken error.
+	ErrorCodeBadAdminToken ErrorCode = "BAD_ADMIN_TOKEN"
+
+	// ErrorCodeUnauthenticated is the code of an ErrUnauthenticated error.
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+
+	// ErrorCodeBadSignature is the code of an ErrBadSignature error.
+	ErrorCodeBadSignature ErrorCode = "BAD_SIGNATURE"
+
+	// ErrorCodeForbidden is the code of an ErrForbidden error.
+	ErrorCodeForbidden ErrorCode = "FORBIDDEN"
+
+	// ErrorCodeNotFound is the code of an ErrNotFound error.
+	ErrorCodeNotFound ErrorCode = "NOT_FOUND"
+
+	// ErrorCodeVolumeInUse is the code of an ErrVolumeInUse error.
+	ErrorCodeVolumeInUse ErrorCode = "VOLUME_IN_USE"
+
+	// ErrorCodeQuotaExceeded is the code of an ErrQuotaExceeded error.
+	ErrorCodeQuotaExceeded ErrorCode = "QUOTA_EXCEEDED"
+
+	// ErrorCodeVolumeShrink is the code of an ErrVolumeShrink error.
+	ErrorCodeVolumeShrink ErrorCode = "VOLUME_SHRINK"
+
+	// ErrorCodeVolumeTooLarge is the code of an ErrVolumeTooLarge error.
+	ErrorCodeVolumeTooLarge ErrorCode = "VOLUME_TOO_LARGE"
+
+	// ErrorCodeIdempotencyKeyReused is the code of an ErrIdempotencyKeyReused
+	// error.
+	ErrorCodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+
+	// ErrorCodeTooManyRequests is the code of an ErrTooManyRequests error.
+	ErrorCodeTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
+
+	// ErrorCodeQueueFull is the code of an ErrQueueFull error.
+	ErrorCodeQueueFull ErrorCode = "QUEUE_FULL"
+
+	// ErrorCodeTaskCanceled is the code of an ErrTaskCanceled error.
+	ErrorCodeTaskCanceled ErrorCode = "TASK_CANCELED"
+
+	// ErrorCodeUnsupportedForClientType is the code of an
+	// ErrUnsupportedForClientType error.
+	ErrorCodeUnsupportedForClientType ErrorCode = "UNSUPPORTED_FOR_CLIENT_TYPE"
+)
+
+// statusUnprocessableEntity is the HTTP status of a request that is well
+// formed but cannot be processed. The net/http package does not define the
+// status until Go 1.7.
+const statusUnprocessableEntity = 422
+
+var errorCodeStatuses = map[ErrorCode]int{
+	ErrorCodeNotImplemented:           http.StatusNotImplemented,
+	ErrorCodeInvalidRequest:           http.StatusBadRequest,
+	ErrorCodeInvalidFilter:            http.StatusBadRequest,
+	ErrorCodeInvalidPageOpts:          http.StatusBadRequest,
+	ErrorCodeMissingInstanceID:        http.StatusBadRequest,
+	ErrorCodeBadAdminToken:            http.StatusUnauthorized,
+	ErrorCodeUnauthenticated:          http.StatusUnauthorized,
+	ErrorCodeBadSignature:             http.StatusUnauthorized,
+	ErrorCodeForbidden:                http.StatusForbidden,
+	ErrorCodeNotFound:                 http.StatusNotFound,
+	ErrorCodeVolumeInUse:              http.StatusConflict,
+	ErrorCodeQuotaExceeded:            http.StatusConflict,
+	ErrorCodeVolumeShrink:             statusUnprocessableEntity,
+	ErrorCodeVolumeTooLarge:           statusUnprocessableEntity,
+	ErrorCodeIdempotencyKeyReused:     statusUnprocessableEntity,
+	ErrorCodeTooManyRequests:          http.StatusTooManyRequests,
+	ErrorCodeQueueFull:                http.StatusServiceUnavailable,
+	ErrorCodeTaskCanceled:             http.StatusInternalServerError,
+	ErrorCodeUnsupportedForClientType: http.StatusNotImplemented,
+}
+
+// String returns the code's string representation.
+func (c ErrorCode) String() string {
+	return string(c)
+}
+
+// Status returns the HTTP status of a response to a request that fails with
+// an error that has the code. The status of an unknown or empty code is 500.
+func (c ErrorCode) Status() int {
+	if s, ok := errorCodeStatuses[c]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorCoder is an error that has an error code.
+type ErrorCoder interface {
+	error
+
+	// Code returns the error's code.
+	Code() ErrorCode
+}
+
+// ErrorCodeOf returns the code of an error. An empty string is returned if
+// the error does not have a code.
+func ErrorCodeOf(err error) ErrorCode {
+	if e, ok := err.(ErrorCoder); ok {
+		return e.Code()
+	}
+	return ""
+}
+
+// NewErrorFromCode returns a new error of the type identified by the code
+// that wraps the provided error. A nil value is returned if the code is
+// unknown. The error's Status function returns the status of the wrapped
+// error if it is a goof.HTTPError, such as an error decoded from a response,
+// and otherwise the status of the code.
+func NewErrorFromCode(code ErrorCode, g goof.Goof) error {
+	switch code {
+	case ErrorCodeNotImplemented:
+		return &ErrUnimplemented{g}
+	case ErrorCodeInvalidRequest:
+		return &ErrInvalidRequest{g}
+	case ErrorCodeInvalidFilter:
+		return &ErrBadFilter{g}
+	case ErrorCodeInvalidPageOpts:
+		return &ErrBadPageOpts{g}
+	case ErrorCodeMissingInstanceID:
+		return &ErrMissingInstanceID{g}
+	case ErrorCodeBadAdminToken:
+		return &ErrBadAdminToken{g}
+	case ErrorCodeUnauthenticated:
+		return &ErrUnauthenticated{g}
+	case ErrorCodeBadSignature:
+		return &ErrBadSignature{g}
+	case ErrorCodeForbidden:
+		return &ErrForbidden{g}
+	case ErrorCodeNotFound:
+		return &ErrNotFound{g}
+	case ErrorCodeVolumeInUse:
+		return &ErrVolumeInUse{g}
+	case ErrorCodeQuotaExceeded:
+		return &ErrQuotaExceeded{g}
+	case ErrorCodeVolumeShrink:
+		return &ErrVolumeShrink{g}
+	case ErrorCodeVolumeTooLarge:
+		return &ErrVolumeTooLarge{g}
+	case ErrorCodeIdempotencyKeyReused:
+		return &ErrIdempotencyKeyReused{g}
+	case ErrorCodeTooManyRequests:
+		return &ErrTooManyRequests{g}
+	case ErrorCodeQueueFull:
+		return &ErrQueueFull{g}
+	case ErrorCodeTaskCanceled:
+		return &ErrTaskCanceled{g}
+	case ErrorCodeUnsupportedForClientType:
+		return &ErrUnsupportedForClientType{g}
+	}
+	return nil
+}
+
+// Code returns the error's code.
+func (e *ErrUnimplemented) Code() ErrorCode { return ErrorCodeNotImplemented }
+
+// Code returns the error's code.
+func (e *ErrInvalidRequest) Code() ErrorCode { return ErrorCodeInvalidRequest }
+
+// Code returns the error's code.
+func (e *ErrBadFilter) Code() ErrorCode { return ErrorCodeInvalidFilter }
+
+// Code returns the error's code.
+func (e *ErrBadPageOpts) Code() ErrorCode { return ErrorCodeInvalidPageOpts }
+
+// Code returns the error's code.
+func (e *ErrMissingInstanceID) Code() ErrorCode {
+	return ErrorCodeMissingInstanceID
+}
+
+// Code returns the error's code.
+func (e *ErrBadAdminToken) Code() ErrorCode { return ErrorCodeBadAdminToken }
+
+// Code returns the error's code.
+func (e *ErrUnauthenticated) Code() ErrorCode {
+	return ErrorCodeUnauthenticated
+}
+
+// Code returns the error's code.
+func (e *ErrBadSignature) Code() ErrorCode { return ErrorCodeBadSignature }
+
+// Code returns the error's code.
+func (e *ErrForbidden) Code() ErrorCode { return ErrorCodeForbidden }
+
+// Code returns the error's code.
+func (e *ErrNotFound) Code() ErrorCode { return ErrorCodeNotFound }
+
+// Code returns the error's code.
+func (e *ErrVolumeInUse) Code() ErrorCode { return ErrorCodeVolumeInUse }
+
+// Code returns the error's code.
+func (e *ErrQuotaExceeded) Code() ErrorCode { return ErrorCodeQuotaExceeded }
+
+// Code returns the error's code.
+func (e *ErrVolumeShrink) Code() ErrorCode { return ErrorCodeVolumeShrink }
+
+// Code returns the error's code.
+func (e *ErrVolumeTooLarge) Code() ErrorCode { return ErrorCodeVolumeTooLarge }
+
+// Code returns the error's code.
+func (e *ErrIdempotencyKeyReused) Code() ErrorCode {
+	return ErrorCodeIdempotencyKeyReused
+}
+
+// Code returns the error's code.
+func (e *ErrTooManyRequests) Code() ErrorCode {
+	return ErrorCodeTooManyRequests
+}
+
+// Code returns the error's code.
+func (e *ErrQueueFull) Code() ErrorCode { return ErrorCodeQueueFull }
+
+// Code returns the error's code.
+func (e *ErrTaskCanceled) Code() ErrorCode { return ErrorCodeTaskCanceled }
+
+// Code returns the error's code.
+func (e *ErrUnsupportedForClientType) Code() ErrorCode {
+	return ErrorCodeUnsupportedForClientType
+}
+
+// httpStatus returns the HTTP status of the error wrapped by a typed error if
+// the wrapped error has one, and otherwise the status of the error's code.
+func httpStatus(g goof.Goof, code ErrorCode) int {
+	if e, ok := g.(goof.HTTPError); ok {
+		return e.Status()
+	}
+	return code.Status()
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrUnimplemented) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrInvalidRequest) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrBadFilter) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrBadPageOpts) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrMissingInstanceID) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrBadAdminToken) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrUnauthenticated) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrBadSignature) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrForbidden) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrNotFound) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrVolumeInUse) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrQuotaExceeded) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrVolumeShrink) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrVolumeTooLarge) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrIdempotencyKeyReused) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrTooManyRequests) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrQueueFull) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrTaskCanceled) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
+
+// Status returns the error's HTTP status.
+func (e *ErrUnsupportedForClientType) Status() int {
+	return httpStatus(e.Goof, e.Code())
+}
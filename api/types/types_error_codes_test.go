@@ -0,0 +1,50 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/akutz/goof"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodes(t *testing.T) {
+
+	assert.Equal(t, ErrorCodeNotImplemented, ErrorCodeOf(ErrNotImplemented))
+	assert.Equal(t, 501, ErrorCodeOf(ErrNotImplemented).Status())
+
+	assert.EqualValues(t, "", ErrorCodeOf(errors.New("error")))
+	assert.Equal(t, 500, ErrorCodeOf(errors.New("error")).Status())
+	assert.Equal(t, 500, ErrorCode("UNKNOWN").Status())
+
+	for code, status := range errorCodeStatuses {
+		err := NewErrorFromCode(code, goof.New(code.String()))
+		if !assert.NotNil(t, err, code.String()) {
+			continue
+		}
+		assert.Equal(t, code, ErrorCodeOf(err))
+		assert.Equal(t, status, ErrorCodeOf(err).Status())
+		assert.Equal(t, code.String(), err.Error())
+		if httpErr, ok := err.(goof.HTTPError); assert.True(t, ok) {
+			assert.Equal(t, status, httpErr.Status())
+		}
+	}
+	assert.Nil(t, NewErrorFromCode("UNKNOWN", goof.New("error")))
+
+	err := NewErrorFromCode(ErrorCodeVolumeInUse, goof.New("in use"))
+	switch err.(type) {
+	case *ErrVolumeInUse:
+	default:
+		t.Errorf("unexpected error type %T", err)
+	}
+
+	// a typed error that wraps an HTTP error, such as an error decoded from
+	// a response, has the HTTP error's status
+	err = NewErrorFromCode(
+		ErrorCodeNotFound, goof.NewHTTPError(goof.New("gone"), 410))
+	if httpErr, ok := err.(goof.HTTPError); assert.True(t, ok) {
+		assert.Equal(t, 410, httpErr.Status())
+		assert.Equal(t, "gone", httpErr.Error())
+	}
+	assert.Equal(t, ErrorCodeNotFound, ErrorCodeOf(err))
+}
@@ -6,7 +6,10 @@ import (
 
 // ErrNotImplemented is the error that Driver implementations should return if
 // a function is not implemented.
-var ErrNotImplemented = goof.New("not implemented")
+var ErrNotImplemented error = &ErrUnimplemented{goof.New("not implemented")}
+
+// ErrUnimplemented occurs when an operation is not implemented by a Driver.
+type ErrUnimplemented struct{ goof.Goof }
 
 // ErrUnsupportedForClientType is the error that occurs when an operation is
 // invoked that is unsupported for the current client type.
@@ -44,3 +47,55 @@ type ErrBatchProcess struct{ goof.Goof }
 // ErrBadFilter occurs when a bad filter is supplied via the filter query
 // string.
 type ErrBadFilter struct{ goof.Goof }
+
+// ErrBadPageOpts occurs when invalid paging or sorting options are supplied
+// via the query string.
+type ErrBadPageOpts struct{ goof.Goof }
+
+// ErrVolumeShrink occurs when a volume resize operation requests a size that
+// is smaller than the volume's current size.
+type ErrVolumeShrink struct{ goof.Goof }
+
+// ErrVolumeTooLarge occurs when a volume create or resize operation requests
+// a size that is larger than the storage driver's maximum volume size.
+type ErrVolumeTooLarge struct{ goof.Goof }
+
+// ErrVolumeInUse occurs when an operation cannot be performed on a volume
+// because the volume is attached.
+type ErrVolumeInUse struct{ goof.Goof }
+
+// ErrInvalidRequest occurs when a request's payload cannot be read or does
+// not conform to the request's schema.
+type ErrInvalidRequest struct{ goof.Goof }
+
+// ErrTaskCanceled occurs when a task is canceled before it completes.
+type ErrTaskCanceled struct{ goof.Goof }
+
+// ErrQueueFull occurs when a task cannot be enqueued because the storage
+// service's task queue is full.
+type ErrQueueFull struct{ goof.Goof }
+
+// ErrQuotaExceeded occurs when a request is rejected because the resources
+// it would create exceed a quota.
+type ErrQuotaExceeded struct{ goof.Goof }
+
+// ErrTooManyRequests occurs when a request is rejected because its client
+// has exceeded its rate limit or because too many calls to a storage
+// service's driver are in flight.
+type ErrTooManyRequests struct{ goof.Goof }
+
+// ErrIdempotencyKeyReused occurs when a request's idempotency key was
+// already used by a request that is not the same as the request.
+type ErrIdempotencyKeyReused struct{ goof.Goof }
+
+// ErrUnauthenticated occurs when a request's credentials are missing or
+// invalid.
+type ErrUnauthenticated struct{ goof.Goof }
+
+// ErrForbidden occurs when a principal is not allowed to perform an
+// operation.
+type ErrForbidden struct{ goof.Goof }
+
+// ErrBadSignature occurs when the signature of a header that identifies a
+// client's instance is missing or invalid.
+type ErrBadSignature struct{ goof.Goof }
@@ -0,0 +1,107 @@
+package types
+
+// EventType is the type of an event.
+type EventType string
+
+const (
+	// EventVolumeCreate is the type of the event published when a volume is
+	// created.
+	EventVolumeCreate EventType = "volumeCreate"
+
+	// EventVolumeCreateFromSnapshot is the type of the event published when
+	// a volume is created from a snapshot.
+	EventVolumeCreateFromSnapshot = "volumeCreateFromSnapshot"
+
+	// EventVolumeCopy is the type of the event published when a volume is
+	// copied.
+	EventVolumeCopy = "volumeCopy"
+
+	// EventVolumeResize is the type of the event published when a volume is
+	// resized.
+	EventVolumeResize = "volumeResize"
+
+	// EventVolumeAttach is the type of the event published when a volume is
+	// attached.
+	EventVolumeAttach = "volumeAttach"
+
+	// EventVolumeDetach is the type of the event published when a volume is
+	// detached.
+	EventVolumeDetach = "volumeDetach"
+
+	// EventVolumeRemove is the type of the event published when a volume is
+	// removed.
+	EventVolumeRemove = "volumeRemove"
+
+	// EventVolumeSnapshot is the type of the event published when a volume
+	// is snapshotted.
+	EventVolumeSnapshot = "volumeSnapshot"
+
+	// EventSnapshotCopy is the type of the event published when a snapshot
+	// is copied.
+	EventSnapshotCopy = "snapshotCopy"
+
+	// EventSnapshotRemove is the type of the event published when a snapshot
+	// is removed.
+	EventSnapshotRemove = "snapshotRemove"
+)
+
+// EventOutcome is the outcome of the operation described by an event.
+type EventOutcome string
+
+const (
+	// EventOutcomeSuccess is the outcome of an operation that succeeded.
+	EventOutcomeSuccess EventOutcome = "success"
+
+	// EventOutcomeError is the outcome of an operation that failed.
+	EventOutcomeError = "error"
+)
+
+// Event describes a volume or snapshot operation performed by the server.
+type Event struct {
+	// ID is the event's ID. The IDs of a server's events increase in the
+	// order in which the events are published.
+	ID int64 `json:"id" yaml:"id"`
+
+	// Type is the event's type.
+	Type EventType `json:"type" yaml:"type"`
+
+	// Time is the time stamp when the event was published.
+	Time int64 `json:"time" yaml:"time"`
+
+	// Service is the name of the service that performed the operation.
+	Service string `json:"service" yaml:"service"`
+
+	// VolumeID is the ID of the volume on which the operation was performed.
+	VolumeID string `json:"volumeID,omitempty" yaml:"volumeID,omitempty"`
+
+	// SnapshotID is the ID of the snapshot on which the operation was
+	// performed.
+	SnapshotID string `json:"snapshotID,omitempty" yaml:"snapshotID,omitempty"`
+
+	// InstanceID is the ID of the instance that requested the operation.
+	InstanceID *InstanceID `json:"instanceID,omitempty" yaml:"instanceID,omitempty"`
+
+	// TransactionID is the ID of the transaction that requested the
+	// operation.
+	TransactionID string `json:"txID,omitempty" yaml:"txID,omitempty"`
+
+	// TaskID is the ID of the task that performed the operation.
+	TaskID int `json:"taskID" yaml:"taskID"`
+
+	// Outcome is the outcome of the operation.
+	Outcome EventOutcome `json:"outcome" yaml:"outcome"`
+
+	// Error is the error message if the operation failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// EventsOpts are options for subscribing to events.
+type EventsOpts struct {
+	// Services are the names of the services whose events are received. All
+	// services' events are received if the list is empty.
+	Services []string
+
+	// Types are the types of the events that are received. All types of
+	// events are received if the list is empty.
+	Types []EventType
+}
@@ -9,6 +9,14 @@ const (
 	// LocalDevicesHeader is the HTTP header that contains a local device pair.
 	LocalDevicesHeader = "Libstorage-Localdevices"
 
+	// InstanceIDSignatureHeader is the HTTP header that contains the
+	// signature of an InstanceIDHeader value.
+	InstanceIDSignatureHeader = "Libstorage-Instanceid-Signature"
+
+	// LocalDevicesSignatureHeader is the HTTP header that contains the
+	// signature of a LocalDevicesHeader value.
+	LocalDevicesSignatureHeader = "Libstorage-Localdevices-Signature"
+
 	// TransactionHeader is the HTTP header that contains the transaction
 	// sent from the client.
 	TransactionHeader = "Libstorage-Tx"
@@ -18,4 +26,47 @@ const (
 	// for the first time. This header is provided with every response sent
 	// from the server.
 	ServerNameHeader = "Libstorage-Servername"
+
+	// NextPageTokenHeader is the HTTP header that contains the continuation
+	// token used to request the next page of a paged list resource. The
+	// header is omitted when the response contains the last page.
+	NextPageTokenHeader = "Libstorage-Nextpagetoken"
+
+	// WebhookEventHeader is the HTTP header that contains the type of the
+	// event delivered by a webhook.
+	WebhookEventHeader = "Libstorage-Event"
+
+	// WebhookDeliveryHeader is the HTTP header that contains the ID of the
+	// event delivered by a webhook. A receiver may use it to detect an event
+	// that is delivered more than once due to a retry.
+	WebhookDeliveryHeader = "Libstorage-Delivery"
+
+	// WebhookSignatureHeader is the HTTP header that contains the signature
+	// of the payload delivered by a webhook. The signature is the
+	// hex-encoded HMAC-SHA256 of the payload, prefixed with "sha256=".
+	WebhookSignatureHeader = "Libstorage-Signature"
+
+	// TenantHeader is the HTTP header that contains the name of the tenant
+	// on whose behalf a request is made when the request's principal does not
+	// determine its tenant.
+	TenantHeader = "Libstorage-Tenant"
+
+	// IdempotencyKeyHeader is the HTTP header that contains the key with
+	// which a client identifies a mutating request so that the request is
+	// not executed more than once if the client retries it.
+	IdempotencyKeyHeader = "Libstorage-Idempotency-Key"
+
+	// RetryAfterHeader is the HTTP header that contains the number of seconds
+	// after which a client may retry a request that was rejected because the
+	// server is too busy to process it.
+	RetryAfterHeader = "Retry-After"
+
+	// AuthorizationHeader is the HTTP header that contains the bearer token
+	// with which a client authenticates its requests.
+	AuthorizationHeader = "Authorization"
+
+	// WWWAuthenticateHeader is the HTTP header that contains the
+	// authentication scheme a client must use when the server rejects a
+	// request as unauthenticated.
+	WWWAuthenticateHeader = "Www-Authenticate"
 )
@@ -20,6 +20,13 @@ type VolumeCopyRequest struct {
 	Opts       map[string]interface{} `json:"opts,omitempty"`
 }
 
+// VolumeResizeRequest is the JSON body for resizing a volume.
+type VolumeResizeRequest struct {
+	Size  int64                  `json:"size"`
+	Force bool                   `json:"force,omitempty"`
+	Opts  map[string]interface{} `json:"opts,omitempty"`
+}
+
 // VolumeSnapshotRequest is the JSON body for snapshotting a volume.
 type VolumeSnapshotRequest struct {
 	SnapshotName string                 `json:"snapshotName"`
@@ -1,5 +1,9 @@
 package types
 
+// JSONStreamContentType is the content type of a response that is a stream of
+// JSON objects, one per line.
+const JSONStreamContentType = "application/x-ndjson"
+
 // VolumeAttachResponse is the JSON response for attaching a volume to an
 // instance.
 type VolumeAttachResponse struct {
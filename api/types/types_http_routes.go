@@ -27,6 +27,10 @@ type Route interface {
 	// Middlewares adds middleware to the route.
 	Middlewares(middlewares ...Middleware) Route
 
+	// Streaming marks the route as one whose response is streamed to the
+	// client as it is written rather than buffered until it is complete.
+	Streaming() Route
+
 	// Name returns the name of the route.
 	GetName() string
 
@@ -44,4 +48,8 @@ type Route interface {
 
 	// GetMiddlewares returns a list of route-specific middleware.
 	GetMiddlewares() []Middleware
+
+	// GetStreaming returns a flag indicating whether or not the route's
+	// response is streamed.
+	GetStreaming() bool
 }
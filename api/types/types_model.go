@@ -251,6 +251,34 @@ type ServiceInfo struct {
 
 	// Driver is the name of the driver registered for the service.
 	Driver *DriverInfo `json:"driver"`
+
+	// TaskQueue is information about the service's task queue.
+	TaskQueue *TaskQueueInfo `json:"taskQueue,omitempty" yaml:"taskQueue,omitempty"`
+}
+
+// TaskQueueInfo is information about a storage service's task queue.
+type TaskQueueInfo struct {
+	// Workers is the number of tasks the service executes concurrently. A
+	// value of zero indicates that the number is not limited.
+	Workers int `json:"workers"`
+
+	// Depth is the maximum number of queued tasks. A value of zero indicates
+	// the queue is unbounded.
+	Depth int `json:"depth"`
+
+	// Queued is the number of tasks waiting to be executed.
+	Queued int `json:"queued"`
+
+	// Running is the number of tasks being executed.
+	Running int `json:"running"`
+
+	// AvgWaitTime is the average amount of time, in milliseconds, that
+	// recent tasks waited in the queue before they were executed.
+	AvgWaitTime int64 `json:"avgWaitTime" yaml:"avgWaitTime"`
+
+	// OldestWaitTime is the amount of time, in milliseconds, that the
+	// oldest queued task has been waiting.
+	OldestWaitTime int64 `json:"oldestWaitTime" yaml:"oldestWaitTime"`
 }
 
 // DriverInfo is information about a driver.
@@ -263,6 +291,67 @@ type DriverInfo struct {
 
 	// NextDevice is the next available device information for the service.
 	NextDevice *NextDeviceInfo `json:"nextDevice,omitempty" yaml:"nextDevice,omitempty"`
+
+	// Capabilities are the operations the driver supports. This field is
+	// omitted if the driver does not describe its capabilities.
+	Capabilities *StorageCapabilities `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// StorageCapabilities describes the operations a storage driver supports.
+type StorageCapabilities struct {
+	// Snapshots indicates whether the driver can snapshot volumes as well as
+	// list, inspect, and remove snapshots.
+	Snapshots bool `json:"snapshots" yaml:"snapshots"`
+
+	// VolumeCopy indicates whether the driver can copy volumes.
+	VolumeCopy bool `json:"volumeCopy" yaml:"volumeCopy"`
+
+	// SnapshotCopy indicates whether the driver can copy snapshots.
+	SnapshotCopy bool `json:"snapshotCopy" yaml:"snapshotCopy"`
+
+	// CreateFromSnapshot indicates whether the driver can create volumes
+	// from snapshots.
+	CreateFromSnapshot bool `json:"createFromSnapshot" yaml:"createFromSnapshot"`
+
+	// Resize indicates whether the driver can resize volumes.
+	Resize bool `json:"resize" yaml:"resize"`
+
+	// MultiAttach indicates whether a volume may be attached to more than one
+	// instance at a time.
+	MultiAttach bool `json:"multiAttach" yaml:"multiAttach"`
+
+	// Type is the type of storage the driver provides: block, nas, object.
+	// The driver's Type is used if the field is empty.
+	Type StorageType `json:"type" yaml:"type"`
+
+	// MaxVolumeSize is the maximum size, in GB, of a volume. A value of zero
+	// indicates that the driver does not limit the size.
+	MaxVolumeSize int64 `json:"maxVolumeSize,omitempty" yaml:"maxVolumeSize,omitempty"`
+}
+
+// Supports returns a flag indicating whether or not the capabilities include
+// the provided capability. A nil value supports all capabilities, since the
+// operations of a driver that does not describe its capabilities are not
+// restricted.
+func (c *StorageCapabilities) Supports(capability StorageCapability) bool {
+	if c == nil {
+		return true
+	}
+	switch capability {
+	case StorageCapabilitySnapshots:
+		return c.Snapshots
+	case StorageCapabilityVolumeCopy:
+		return c.VolumeCopy
+	case StorageCapabilitySnapshotCopy:
+		return c.SnapshotCopy
+	case StorageCapabilityCreateFromSnapshot:
+		return c.CreateFromSnapshot
+	case StorageCapabilityResize:
+		return c.Resize
+	case StorageCapabilityMultiAttach:
+		return c.MultiAttach
+	}
+	return true
 }
 
 // NextDeviceInfo assists the libStorage client in determining the
@@ -307,6 +396,24 @@ const (
 
 	// TaskStateError is the state for a task that has completed with an error.
 	TaskStateError = "error"
+
+	// TaskStateCanceled is the state for a task that was canceled before it
+	// completed.
+	TaskStateCanceled = "canceled"
+)
+
+// TaskPriority is the priority with which a task is dequeued.
+type TaskPriority int
+
+const (
+	// TaskPriorityNormal is the priority of most tasks.
+	TaskPriorityNormal TaskPriority = iota
+
+	// TaskPriorityHigh is the priority of tasks, such as detach operations,
+	// that release resources and should not wait behind tasks that consume
+	// them. The priority is honored only if
+	// libstorage.server.tasks.queue.prioritize is enabled.
+	TaskPriorityHigh
 )
 
 // Task is a representation of an asynchronous, long-running task.
@@ -317,6 +424,9 @@ type Task struct {
 	// User is the name of the user that created the task.
 	User string `json:"user,omitempty" yaml:",omitempty"`
 
+	// Service is the name of the service on which the task operates.
+	Service string `json:"service,omitempty" yaml:",omitempty"`
+
 	// CompleteTime is the time stamp when the task was completed
 	// (whether success or failure).
 	CompleteTime int64 `json:"completeTime,omitempty" yaml:"completeTime,omitempty"`
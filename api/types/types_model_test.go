@@ -85,3 +85,27 @@ func TestInstanceWithOnlyInstanceIDMarshalToYAML(t *testing.T) {
 
 	fmt.Println(string(out))
 }
+
+func TestStorageCapabilitiesSupports(t *testing.T) {
+
+	var c *StorageCapabilities
+	if !c.Supports(StorageCapabilitySnapshots) {
+		t.Error("nil capabilities should support snapshots")
+	}
+
+	c = &StorageCapabilities{Resize: true}
+	if !c.Supports(StorageCapabilityResize) {
+		t.Error("capabilities should support resize")
+	}
+	for _, sc := range []StorageCapability{
+		StorageCapabilitySnapshots,
+		StorageCapabilityVolumeCopy,
+		StorageCapabilitySnapshotCopy,
+		StorageCapabilityCreateFromSnapshot,
+		StorageCapabilityMultiAttach,
+	} {
+		if c.Supports(sc) {
+			t.Errorf("capabilities should not support %s", sc)
+		}
+	}
+}
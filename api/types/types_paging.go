@@ -0,0 +1,81 @@
+package types
+
+// PageOpts are the options used to request a single page of a paged list
+// resource, such as /volumes, /snapshots, or /tasks.
+type PageOpts struct {
+
+	// Limit is the maximum number of objects returned in a single page. A
+	// value of zero indicates there is no limit.
+	Limit int
+
+	// Offset is the number of objects to skip before the first object in the
+	// page.
+	Offset int
+
+	// Token is a continuation token returned by the server in the
+	// NextPageTokenHeader. If set, the token takes precedence over the Offset
+	// and Sort options.
+	Token string
+
+	// Sort is the name of the attribute by which the list is sorted, with an
+	// optional ":asc" or ":desc" suffix. Objects with equal sort values are
+	// ordered by their service name and then their ID so that the order is
+	// stable across requests.
+	Sort string
+}
+
+// VolumeIterator iterates over a list of volumes that is retrieved from the
+// server one page at a time.
+type VolumeIterator interface {
+
+	// Next advances the iterator to the next volume, requesting the next
+	// page from the server if necessary. A false value is returned when there
+	// are no more volumes or an error occurs.
+	Next() bool
+
+	// Service returns the name of the service to which the current volume
+	// belongs.
+	Service() string
+
+	// Volume returns the current volume.
+	Volume() *Volume
+
+	// Err returns the error, if any, that stopped the iteration.
+	Err() error
+}
+
+// SnapshotIterator iterates over a list of snapshots that is retrieved from
+// the server one page at a time.
+type SnapshotIterator interface {
+
+	// Next advances the iterator to the next snapshot, requesting the next
+	// page from the server if necessary. A false value is returned when there
+	// are no more snapshots or an error occurs.
+	Next() bool
+
+	// Service returns the name of the service to which the current snapshot
+	// belongs.
+	Service() string
+
+	// Snapshot returns the current snapshot.
+	Snapshot() *Snapshot
+
+	// Err returns the error, if any, that stopped the iteration.
+	Err() error
+}
+
+// TaskIterator iterates over a list of tasks that is retrieved from the
+// server one page at a time.
+type TaskIterator interface {
+
+	// Next advances the iterator to the next task, requesting the next page
+	// from the server if necessary. A false value is returned when there are
+	// no more tasks or an error occurs.
+	Next() bool
+
+	// Task returns the current task.
+	Task() *Task
+
+	// Err returns the error, if any, that stopped the iteration.
+	Err() error
+}
@@ -0,0 +1,68 @@
+package types
+
+// QuotaScope is the scope of the resources to which a quota applies.
+type QuotaScope string
+
+const (
+	// QuotaScopeService is the scope of a quota that applies to all of a
+	// storage service's resources.
+	QuotaScopeService QuotaScope = "service"
+
+	// QuotaScopeTenant is the scope of a quota that applies to the resources
+	// created on behalf of a tenant.
+	QuotaScopeTenant = "tenant"
+
+	// QuotaScopeInstance is the scope of a quota that applies to the
+	// resources created on behalf of an instance.
+	QuotaScopeInstance = "instance"
+)
+
+// QuotaLimits are the limits of a quota. A zero value is not limited.
+type QuotaLimits struct {
+
+	// Volumes is the maximum number of volumes.
+	Volumes int64 `json:"volumes,omitempty" yaml:",omitempty"`
+
+	// Size is the maximum total size, in GB, of the volumes.
+	Size int64 `json:"size,omitempty" yaml:",omitempty"`
+
+	// VolumeSize is the maximum size, in GB, of a single volume.
+	VolumeSize int64 `json:"volumeSize,omitempty" yaml:"volumeSize,omitempty"`
+
+	// Snapshots is the maximum number of snapshots.
+	Snapshots int64 `json:"snapshots,omitempty" yaml:",omitempty"`
+}
+
+// QuotaUsage is the usage of the resources to which a quota applies.
+type QuotaUsage struct {
+
+	// Volumes is the number of volumes.
+	Volumes int64 `json:"volumes"`
+
+	// Size is the total size, in GB, of the volumes.
+	Size int64 `json:"size"`
+
+	// Snapshots is the number of snapshots.
+	Snapshots int64 `json:"snapshots"`
+}
+
+// Quota is the limits and usage of the resources in a quota's scope.
+type Quota struct {
+
+	// Scope is the quota's scope.
+	Scope QuotaScope `json:"scope"`
+
+	// Name is the name of the tenant or the ID of the instance to which the
+	// quota applies. It is empty for a service quota.
+	Name string `json:"name,omitempty" yaml:",omitempty"`
+
+	// Limits are the quota's limits. A nil value indicates the resources in
+	// the quota's scope are not limited.
+	Limits *QuotaLimits `json:"limits,omitempty" yaml:",omitempty"`
+
+	// Usage is the usage of the resources in the quota's scope.
+	Usage *QuotaUsage `json:"usage"`
+}
+
+// ServiceQuotaMap is the quotas of one or more storage services.
+type ServiceQuotaMap map[string][]*Quota
@@ -0,0 +1,228 @@
+package types
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// SecretRedacted is the value that replaces a secret when it is written
+	// to the log or included in a representation of the configuration.
+	SecretRedacted = "******"
+
+	// SecretFileRefPrefix is the prefix of a config value that refers to the
+	// file that contains the actual value.
+	SecretFileRefPrefix = "file:"
+
+	// SecretEnvRefPrefix is the prefix of a config value that refers to the
+	// environment variable that contains the actual value.
+	SecretEnvRefPrefix = "env:"
+)
+
+var (
+	secretEnvRefRX   = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	secretConfigKeys = map[string]bool{}
+	secretValues     = map[string]bool{}
+	secretsLock      = &sync.RWMutex{}
+)
+
+// SecretFileRef returns the path of the file to which a config value refers
+// and a flag indicating whether the value is a file reference. A file
+// reference is "file:" followed by an absolute path, such as
+// "file:/run/secrets/sio-pass". A "file://" URL is not a reference.
+func SecretFileRef(val string) (string, bool) {
+	if !strings.HasPrefix(val, SecretFileRefPrefix) {
+		return "", false
+	}
+	filePath := strings.TrimPrefix(val, SecretFileRefPrefix)
+	if !filepath.IsAbs(filePath) || strings.HasPrefix(filePath, "//") {
+		return "", false
+	}
+	return filePath, true
+}
+
+// SecretEnvRef returns the name of the environment variable to which a config
+// value refers and a flag indicating whether the value is an environment
+// variable reference, such as "env:SIO_PASS".
+func SecretEnvRef(val string) (string, bool) {
+	if !strings.HasPrefix(val, SecretEnvRefPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(val, SecretEnvRefPrefix)
+	if !secretEnvRefRX.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// IsSecretRef returns a flag indicating whether a config value is a file or
+// environment variable reference.
+func IsSecretRef(val string) bool {
+	if _, ok := SecretFileRef(val); ok {
+		return true
+	}
+	_, ok := SecretEnvRef(val)
+	return ok
+}
+
+// RegisterSecretConfigKeys marks configuration keys as secret. A key is
+// matched without regard to case at the end of a property's dotted path,
+// so a secret key is also secret when it appears in a configuration scope.
+// The "libstorage.server", "libstorage.client", and "libstorage" prefixes are
+// removed from a key before it is registered for this reason.
+func RegisterSecretConfigKeys(keys ...string) {
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+	for _, k := range keys {
+		secretConfigKeys[trimSecretConfigKey(k)] = true
+	}
+}
+
+// RegisterSecretValues registers the values of secrets so they are redacted
+// from the log output of a context's WithField and WithFields functions
+// regardless of the names of the fields in which they appear. Empty values
+// are ignored.
+func RegisterSecretValues(vals ...string) {
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+	for _, v := range vals {
+		if v != "" {
+			secretValues[v] = true
+		}
+	}
+}
+
+// IsSecretConfigKey returns a flag indicating whether the dotted path of a
+// configuration property ends with a key marked as secret.
+func IsSecretConfigKey(key string) bool {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+	return isSecretConfigKey(key)
+}
+
+// IsSecretValue returns a flag indicating whether a value is a registered
+// secret.
+func IsSecretValue(val string) bool {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+	return secretValues[val]
+}
+
+// RedactConfig returns a copy of configuration settings, such as those
+// returned by a gofig.Config's AllSettings function, with the values of the
+// properties whose keys are secret replaced by SecretRedacted.
+func RedactConfig(settings map[string]interface{}) map[string]interface{} {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+	return redactConfigMap("", settings)
+}
+
+// RedactLogFields returns a copy of log fields with the fields whose names
+// are secret configuration keys, and the fields whose values are registered
+// secrets, replaced by SecretRedacted. The settings in map values are
+// redacted as with RedactConfig.
+func RedactLogFields(fields map[string]interface{}) map[string]interface{} {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+	redacted := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		redacted[k] = redactLogField(k, v)
+	}
+	return redacted
+}
+
+// RedactLogField returns SecretRedacted if a log field's name is a secret
+// configuration key or its value is a registered secret; otherwise the value
+// is returned as it is redacted by RedactLogFields.
+func RedactLogField(key string, val interface{}) interface{} {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+	return redactLogField(key, val)
+}
+
+func redactLogField(key string, val interface{}) interface{} {
+	if isSecretConfigKey(key) {
+		return SecretRedacted
+	}
+	switch tv := val.(type) {
+	case string:
+		if secretValues[tv] {
+			return SecretRedacted
+		}
+	case fmt.Stringer:
+		if secretValues[tv.String()] {
+			return SecretRedacted
+		}
+	case map[string]interface{}:
+		return redactConfigMap(key, tv)
+	}
+	return val
+}
+
+func redactConfigMap(
+	path string, settings map[string]interface{}) map[string]interface{} {
+
+	redacted := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		redacted[k] = redactConfigValue(joinSecretConfigKey(path, k), v)
+	}
+	return redacted
+}
+
+func redactConfigValue(path string, val interface{}) interface{} {
+	if val != nil && isSecretConfigKey(path) {
+		return SecretRedacted
+	}
+	switch tv := val.(type) {
+	case map[string]interface{}:
+		return redactConfigMap(path, tv)
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(tv))
+		for k, v := range tv {
+			m[fmt.Sprintf("%v", k)] = v
+		}
+		return redactConfigMap(path, m)
+	case []interface{}:
+		a := make([]interface{}, len(tv))
+		for i, v := range tv {
+			a[i] = redactConfigValue(path, v)
+		}
+		return a
+	case string:
+		if secretValues[tv] {
+			return SecretRedacted
+		}
+	}
+	return val
+}
+
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for sk := range secretConfigKeys {
+		if key == sk || strings.HasSuffix(key, "."+sk) {
+			return true
+		}
+	}
+	return false
+}
+
+func trimSecretConfigKey(key string) string {
+	key = strings.ToLower(key)
+	for _, p := range []string{ConfigServer, ConfigClient, ConfigRoot} {
+		p = strings.ToLower(p) + "."
+		if strings.HasPrefix(key, p) {
+			return strings.TrimPrefix(key, p)
+		}
+	}
+	return key
+}
+
+func joinSecretConfigKey(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return fmt.Sprintf("%s.%s", path, k)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretRefs(t *testing.T) {
+	p, ok := SecretFileRef("file:/run/secrets/sio-pass")
+	assert.True(t, ok)
+	assert.Equal(t, "/run/secrets/sio-pass", p)
+
+	n, ok := SecretEnvRef("env:SIO_PASS")
+	assert.True(t, ok)
+	assert.Equal(t, "SIO_PASS", n)
+
+	assert.True(t, IsSecretRef("env:SIO_PASS"))
+	assert.False(t, IsSecretRef("file:///run/secrets/sio-pass"))
+	assert.False(t, IsSecretRef("file:secrets/sio-pass"))
+	assert.False(t, IsSecretRef("env:SIO PASS"))
+	assert.False(t, IsSecretRef("password"))
+}
+
+func TestRedactConfig(t *testing.T) {
+	RegisterSecretConfigKeys(
+		"scaleio.password", ConfigServerAuthTokens, ConfigClientAuthToken)
+	RegisterSecretValues("resolvedsecret")
+
+	assert.True(t, IsSecretConfigKey("scaleio.password"))
+	assert.True(t, IsSecretConfigKey(
+		"libstorage.server.services.sio.ScaleIO.Password"))
+	assert.True(t, IsSecretConfigKey("libstorage.client.auth.token"))
+	assert.False(t, IsSecretConfigKey("scaleio.userName"))
+	assert.False(t, IsSecretConfigKey("mypassword"))
+
+	assert.Equal(t, map[string]interface{}{
+		"scaleio": map[string]interface{}{
+			"username": "admin",
+			"password": SecretRedacted,
+		},
+		"libstorage": map[string]interface{}{
+			"server": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"tokens": SecretRedacted,
+				},
+				"services": map[string]interface{}{
+					"sio": map[string]interface{}{
+						"scaleio": map[string]interface{}{
+							"password": SecretRedacted,
+						},
+						"other": SecretRedacted,
+					},
+				},
+			},
+		},
+	}, RedactConfig(map[string]interface{}{
+		"scaleio": map[string]interface{}{
+			"username": "admin",
+			"password": "password",
+		},
+		"libstorage": map[string]interface{}{
+			"server": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"tokens": map[string]interface{}{
+						"ci": "citoken",
+					},
+				},
+				"services": map[string]interface{}{
+					"sio": map[string]interface{}{
+						"scaleio": map[string]interface{}{
+							"password": "password",
+						},
+						"other": "resolvedsecret",
+					},
+				},
+			},
+		},
+	}))
+
+	assert.Equal(t, map[string]interface{}{
+		"userName":         "admin",
+		"password":         SecretRedacted,
+		"scaleio.password": SecretRedacted,
+		"size":             1,
+	}, RedactLogFields(map[string]interface{}{
+		"userName":         "admin",
+		"password":         "resolvedsecret",
+		"scaleio.password": "password",
+		"size":             1,
+	}))
+	assert.Equal(t, SecretRedacted, RedactLogField("x", "resolvedsecret"))
+	assert.Equal(t, "admin", RedactLogField("x", "admin"))
+}
@@ -36,6 +36,18 @@ type StorageService interface {
 		ctx Context,
 		run StorageTaskRunFunc,
 		schema []byte) *Task
+
+	// TaskEnqueue enqueues a task for execution with the specified priority.
+	// A task that cannot be enqueued because the service's task queue is
+	// full is completed immediately with an ErrQueueFull error.
+	TaskEnqueue(
+		ctx Context,
+		run StorageTaskRunFunc,
+		schema []byte,
+		priority TaskPriority) *Task
+
+	// TaskQueueInfo returns information about the service's task queue.
+	TaskQueueInfo() *TaskQueueInfo
 }
 
 // TaskTrackingService a service for tracking tasks.
@@ -58,6 +70,11 @@ type TaskTrackingService interface {
 	// TaskInspect returns the task with the specified ID.
 	TaskInspect(taskID int) *Task
 
+	// TaskCancel cancels the task with the specified ID. The context passed
+	// to the task's run function is canceled, and a task that has not yet
+	// started is never run.
+	TaskCancel(taskID int) *Task
+
 	// TaskWait blocks until the specified task completes.
 	TaskWait(taskID int) <-chan int
 
@@ -83,3 +100,30 @@ type TaskExecutionService interface {
 		run TaskRunFunc,
 		schema []byte) *Task
 }
+
+// NewTaskStore is a function that constructs a new TaskStore.
+type NewTaskStore func() TaskStore
+
+// TaskStore is the interface implemented by types that persist the tasks
+// tracked by the task service.
+type TaskStore interface {
+	Driver
+
+	// NextID returns a new task ID. A task ID is never reused by a store,
+	// even after the task to which it was assigned is removed.
+	NextID() int
+
+	// Save creates or updates a task.
+	Save(task *Task) error
+
+	// Get returns the task with the specified ID. A nil value is returned if
+	// no such task exists.
+	Get(taskID int) (*Task, error)
+
+	// List returns all of the tasks in the store.
+	List() ([]*Task, error)
+
+	// Evict is invoked once a completed task is no longer tracked by the
+	// task service. A store may remove the task or retain it as history.
+	Evict(taskID int) error
+}
@@ -0,0 +1,69 @@
+package types
+
+import "encoding/json"
+
+// TaskError is the error of a task that was unmarshaled from JSON. The
+// original error's type is not known, so the error is marshaled as the same
+// JSON from which it was unmarshaled.
+type TaskError struct {
+	// Message is the error's message.
+	Message string
+
+	// Raw is the JSON from which the error was unmarshaled.
+	Raw json.RawMessage
+}
+
+// Error returns the error's message.
+func (e *TaskError) Error() string {
+	return e.Message
+}
+
+// MarshalJSON marshals the error as the JSON from which it was unmarshaled.
+func (e *TaskError) MarshalJSON() ([]byte, error) {
+	if len(e.Raw) == 0 {
+		return json.Marshal(e.Message)
+	}
+	return e.Raw, nil
+}
+
+// UnmarshalJSON unmarshals the task from JSON. A task's error cannot be
+// unmarshaled into the error interface, so it is unmarshaled as a TaskError.
+func (t *Task) UnmarshalJSON(data []byte) error {
+
+	// the alias does not have the UnmarshalJSON function, which prevents
+	// this function from being called recursively
+	type task Task
+	v := &struct {
+		*task
+		Error json.RawMessage `json:"error,omitempty"`
+	}{task: (*task)(t)}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	t.Error = nil
+	if len(v.Error) > 0 && string(v.Error) != "null" {
+		t.Error = &TaskError{Message: taskErrorMessage(v.Error), Raw: v.Error}
+	}
+
+	return nil
+}
+
+// taskErrorMessage returns the message of an error marshaled as JSON. The
+// error is either a string or an object with a message field.
+func taskErrorMessage(raw json.RawMessage) string {
+	var msg string
+	if err := json.Unmarshal(raw, &msg); err == nil {
+		return msg
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		for _, k := range []string{"message", "msg", "error"} {
+			if msg, ok := obj[k].(string); ok {
+				return msg
+			}
+		}
+	}
+	return string(raw)
+}
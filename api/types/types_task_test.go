@@ -0,0 +1,42 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskUnmarshalJSON(t *testing.T) {
+
+	task := &Task{}
+	err := json.Unmarshal([]byte(`{
+		"id": 3,
+		"queueTime": 1,
+		"state": "error",
+		"error": {"message": "task canceled", "status": 500}
+	}`), task)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, 3, task.ID)
+	assert.EqualValues(t, TaskStateError, task.State)
+	if !assert.Error(t, task.Error) {
+		t.FailNow()
+	}
+	assert.Equal(t, "task canceled", task.Error.Error())
+
+	buf, err := json.Marshal(task)
+	assert.NoError(t, err)
+	assert.Contains(t, string(buf), `"status":500`)
+
+	task = &Task{}
+	err = json.Unmarshal([]byte(`{"id": 4, "error": "failed"}`), task)
+	assert.NoError(t, err)
+	assert.Equal(t, "failed", task.Error.Error())
+
+	task = &Task{}
+	err = json.Unmarshal([]byte(`{"id": 5, "state": "success"}`), task)
+	assert.NoError(t, err)
+	assert.NoError(t, task.Error)
+}
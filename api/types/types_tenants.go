@@ -0,0 +1,50 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tenant is the namespace of the volumes created on behalf of a tenant. The
+// name of each of a tenant's volumes is prefixed with the tenant's namespace
+// and a separator.
+type Tenant struct {
+
+	// Name is the tenant's name.
+	Name string `json:"name" yaml:"name"`
+
+	// Separator separates the tenant's name from the names of its volumes.
+	Separator string `json:"separator" yaml:"separator"`
+}
+
+// Namespace returns the tenant's namespace, which is the tenant's name with
+// each percent sign and each occurrence of the separator percent-encoded so
+// that the namespaces of two tenants never overlap.
+func (t *Tenant) Namespace() string {
+	ns := strings.Replace(t.Name, "%", "%25", -1)
+	if t.Separator == "" {
+		return ns
+	}
+	esc := ""
+	for _, b := range []byte(t.Separator) {
+		esc += fmt.Sprintf("%%%02X", b)
+	}
+	return strings.Replace(ns, t.Separator, esc, -1)
+}
+
+// VolumeName returns the name in the tenant's namespace of the volume with
+// the provided name.
+func (t *Tenant) VolumeName(name string) string {
+	return t.Namespace() + t.Separator + name
+}
+
+// LocalVolumeName returns the name of the volume with the provided name
+// without the tenant's prefix as well as a flag indicating whether or not
+// the volume belongs to the tenant.
+func (t *Tenant) LocalVolumeName(name string) (string, bool) {
+	prefix := t.Namespace() + t.Separator
+	if !strings.HasPrefix(name, prefix) {
+		return "", false
+	}
+	return name[len(prefix):], true
+}
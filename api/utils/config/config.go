@@ -1,44 +1,87 @@
 package config
 
 import (
+	"io/ioutil"
 	"path"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/akutz/gofig"
 	"github.com/akutz/gotil"
+	"github.com/emccode/libstorage/api/registry"
 	"github.com/emccode/libstorage/api/types"
 	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/schema"
 )
 
 // NewConfig returns a new configuration instance.
 func NewConfig() (gofig.Config, error) {
 	config := gofig.New()
 
-	etcYML := types.Etc.Join("config.yml")
-	etcYAML := types.Etc.Join("config.yaml")
+	for _, f := range Files() {
+		if err := readConfigFile(config, f); err != nil {
+			return nil, err
+		}
+	}
 
-	userHomeDir := gotil.HomeDir()
-	usrYML := path.Join(userHomeDir, "config.yml")
-	usrYAML := path.Join(userHomeDir, "config.yaml")
+	types.BackCompat(config)
 
-	if err := readConfigFile(config, etcYML); err != nil {
+	if err := ResolveSecrets(config); err != nil {
 		return nil, err
 	}
-	if err := readConfigFile(config, etcYAML); err != nil {
-		return nil, err
-	}
-	if err := readConfigFile(config, usrYML); err != nil {
-		return nil, err
+
+	return config, nil
+}
+
+// Files returns the paths of the global and user configuration files that
+// NewConfig reads if they exist, in the order in which they are read.
+func Files() []string {
+	userHomeDir := gotil.HomeDir()
+	return []string{
+		types.Etc.Join("config.yml"),
+		types.Etc.Join("config.yaml"),
+		path.Join(userHomeDir, "config.yml"),
+		path.Join(userHomeDir, "config.yaml"),
 	}
-	if err := readConfigFile(config, usrYAML); err != nil {
-		return nil, err
+}
+
+// ValidateFile validates a YAML or JSON configuration file. The file is
+// validated with Validate.
+func ValidateFile(filePath string) error {
+	buf, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
 	}
+	return Validate(buf)
+}
 
-	types.BackCompat(config)
+// Validate validates YAML or JSON configuration data using the libStorage
+// configuration JSON schema. The driver of each service must be a registered
+// storage driver, and the properties of the configuration scopes that are not
+// described by the schema must be named after registered drivers. The other
+// root properties belong to other programs and are not validated. If the data
+// is invalid a schema.ConfigErrors error is returned that describes each
+// invalid property by its path.
+func Validate(data []byte) error {
+	var d interface{}
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		return err
+	}
 
-	return config, nil
+	v := &schema.ConfigValidator{}
+	for sd := range registry.StorageDrivers() {
+		v.StorageDrivers = append(v.StorageDrivers, sd.Name())
+		v.Drivers = append(v.Drivers, sd.Name())
+	}
+	for od := range registry.OSDrivers() {
+		v.Drivers = append(v.Drivers, od.Name())
+	}
+	for id := range registry.IntegrationDrivers() {
+		v.Drivers = append(v.Drivers, id.Name())
+	}
+	return v.Validate(d)
 }
 
 // UpdateLogLevel updates the log level based on the config.
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// ResolveSecrets replaces the config values that are secret references with
+// the values to which they refer. A reference is either "file:" followed by
+// the absolute path of a file, such as "file:/run/secrets/sio-pass", whose
+// contents less any trailing newline are the value, or "env:" followed by
+// the name of an environment variable, such as "env:SIO_PASS", whose value
+// is the value. A config value that is a reference is valid regardless of
+// the type of its key.
+//
+// The key of each resolved reference is marked as secret, and the values of
+// all of the secret keys are registered so they are redacted from log output.
+func ResolveSecrets(config gofig.Config) error {
+	if config == nil {
+		return nil
+	}
+	resolved := map[string]interface{}{}
+	if err := resolveSecrets(
+		resolved, "", config.AllSettings()); err != nil {
+		return err
+	}
+	for k, v := range resolved {
+		config.Set(k, v)
+		types.RegisterSecretConfigKeys(k)
+	}
+	registerSecretValues("", config.AllSettings(), false)
+	return nil
+}
+
+func resolveSecrets(
+	resolved map[string]interface{},
+	path string,
+	settings map[string]interface{}) error {
+
+	for k, v := range settings {
+		kp := joinKey(path, k)
+		switch tv := v.(type) {
+		case map[string]interface{}:
+			if err := resolveSecrets(resolved, kp, tv); err != nil {
+				return err
+			}
+		case map[interface{}]interface{}:
+			m := map[string]interface{}{}
+			for mk, mv := range tv {
+				m[fmt.Sprintf("%v", mk)] = mv
+			}
+			if err := resolveSecrets(resolved, kp, m); err != nil {
+				return err
+			}
+		case string:
+			s, ok, err := resolveSecret(kp, tv)
+			if err != nil {
+				return err
+			}
+			if ok {
+				resolved[kp] = s
+			}
+		case []interface{}:
+			a := make([]interface{}, len(tv))
+			isRef := false
+			for i, av := range tv {
+				a[i] = av
+				sv, ok := av.(string)
+				if !ok {
+					continue
+				}
+				s, ok, err := resolveSecret(kp, sv)
+				if err != nil {
+					return err
+				}
+				if ok {
+					a[i] = s
+					isRef = true
+				}
+			}
+			if isRef {
+				resolved[kp] = a
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSecret returns the value to which a secret reference refers and a
+// flag indicating whether the value was a reference.
+func resolveSecret(key, val string) (string, bool, error) {
+	if filePath, ok := types.SecretFileRef(val); ok {
+		buf, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return "", false, goof.WithFieldsE(goof.Fields{
+				"key":  key,
+				"path": filePath,
+			}, "error reading config secret file", err)
+		}
+		return strings.TrimRight(string(buf), "\r\n"), true, nil
+	}
+	if name, ok := types.SecretEnvRef(val); ok {
+		s, ok := os.LookupEnv(name)
+		if !ok {
+			return "", false, goof.WithFields(goof.Fields{
+				"key":    key,
+				"envVar": name,
+			}, "config secret env var not set")
+		}
+		return s, true, nil
+	}
+	return val, false, nil
+}
+
+func registerSecretValues(path string, val interface{}, secret bool) {
+	switch tv := val.(type) {
+	case map[string]interface{}:
+		for k, v := range tv {
+			kp := joinKey(path, k)
+			registerSecretValues(kp, v, secret || types.IsSecretConfigKey(kp))
+		}
+	case map[interface{}]interface{}:
+		for k, v := range tv {
+			kp := joinKey(path, fmt.Sprintf("%v", k))
+			registerSecretValues(kp, v, secret || types.IsSecretConfigKey(kp))
+		}
+	case []interface{}:
+		for _, v := range tv {
+			registerSecretValues(path, v, secret)
+		}
+	case string:
+		if secret {
+			types.RegisterSecretValues(tv)
+		}
+	}
+}
+
+func joinKey(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return fmt.Sprintf("%s.%s", path, k)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/context"
+	"github.com/emccode/libstorage/api/types"
+)
+
+func newSecretsTestConfig(
+	t *testing.T, format string, args ...interface{}) gofig.Config {
+
+	config := gofig.New()
+	if !assert.NoError(t, config.ReadConfig(
+		bytes.NewReader([]byte(fmt.Sprintf(format, args...))))) {
+		t.FailNow()
+	}
+	return config
+}
+
+func TestResolveSecrets(t *testing.T) {
+	f, err := ioutil.TempFile("", "libstorage-secret")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(f.Name())
+	_, err = f.WriteString("filesecret\n")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	os.Setenv("LIBSTORAGE_TEST_ENV_SECRET", "envsecret")
+	defer os.Unsetenv("LIBSTORAGE_TEST_ENV_SECRET")
+	os.Setenv("LIBSTORAGE_TEST_LIST_SECRET", "listsecret")
+	defer os.Unsetenv("LIBSTORAGE_TEST_LIST_SECRET")
+
+	config := newSecretsTestConfig(t, `
+scaleio:
+  userName: admin
+  password: file:%s
+libstorage:
+  server:
+    services:
+      sio:
+        scaleio:
+          password: env:LIBSTORAGE_TEST_ENV_SECRET
+    endpoints:
+      localhost:
+        hosts:
+        - localhost
+        - env:LIBSTORAGE_TEST_LIST_SECRET
+`, f.Name())
+
+	if !assert.NoError(t, ResolveSecrets(config)) {
+		t.FailNow()
+	}
+
+	// the file's trailing newline is trimmed
+	assert.Equal(t, "filesecret", config.GetString("scaleio.password"))
+	assert.Equal(t, "admin", config.GetString("scaleio.userName"))
+
+	// a reference in a service scope is resolved for the scope
+	assert.Equal(t, "envsecret", config.GetString(
+		"libstorage.server.services.sio.scaleio.password"))
+	assert.Equal(t, "envsecret", config.Scope(
+		"libstorage.server.services.sio").GetString("scaleio.password"))
+
+	// the references in a list are resolved and the other values are kept
+	assert.Equal(t, []string{"localhost", "listsecret"},
+		config.GetStringSlice("libstorage.server.endpoints.localhost.hosts"))
+
+	assert.True(t, types.IsSecretConfigKey("scaleio.password"))
+	assert.True(t, types.IsSecretConfigKey(
+		"libstorage.server.endpoints.localhost.hosts"))
+	assert.False(t, types.IsSecretConfigKey("scaleio.userName"))
+
+	for _, s := range []string{"filesecret", "envsecret", "listsecret"} {
+		assert.True(t, types.IsSecretValue(s), s)
+	}
+	assert.False(t, types.IsSecretValue("admin"))
+
+	out := log.StandardLogger().Out
+	lvl := log.GetLevel()
+	buf := &bytes.Buffer{}
+	log.SetOutput(buf)
+	log.SetLevel(log.InfoLevel)
+	defer func() {
+		log.SetOutput(out)
+		log.SetLevel(lvl)
+	}()
+
+	context.Background().WithFields(log.Fields{
+		"file":     "filesecret",
+		"env":      "envsecret",
+		"list":     "listsecret",
+		"userName": "admin",
+	}).Info("resolved secrets")
+
+	logged := buf.String()
+	for _, s := range []string{"filesecret", "envsecret", "listsecret"} {
+		assert.NotContains(t, logged, s)
+	}
+	assert.Contains(t, logged, types.SecretRedacted)
+	assert.Contains(t, logged, "admin")
+}
+
+func TestResolveSecretsErrors(t *testing.T) {
+	os.Unsetenv("LIBSTORAGE_TEST_MISSING_SECRET")
+	assert.Error(t, ResolveSecrets(newSecretsTestConfig(t, `
+scaleio:
+  password: env:LIBSTORAGE_TEST_MISSING_SECRET
+`)))
+
+	assert.Error(t, ResolveSecrets(newSecretsTestConfig(t, `
+libstorage:
+  server:
+    services:
+      sio:
+        scaleio:
+          password: file:/libstorage/test/missing-secret
+`)))
+
+	assert.Error(t, ResolveSecrets(newSecretsTestConfig(t, `
+libstorage:
+  client:
+    hosts:
+    - env:LIBSTORAGE_TEST_MISSING_SECRET
+`)))
+}
@@ -0,0 +1,226 @@
+package filters
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// fieldsPrefix is the prefix used by a filter's left operand to refer
+// explicitly to a key in an object's Fields map.
+const fieldsPrefix = "fields."
+
+// ValueFunc returns the value of the attribute with the provided name as
+// well as a flag indicating whether or not the attribute is present.
+type ValueFunc func(attr string) (string, bool)
+
+// Match returns a flag indicating whether or not the object whose attribute
+// values are returned by the provided ValueFunc satisfies the filter.
+func Match(f *types.Filter, values ValueFunc) bool {
+
+	if f == nil {
+		return true
+	}
+
+	switch f.Op {
+	case filterAnd:
+		for _, c := range f.Children {
+			if !Match(c, values) {
+				return false
+			}
+		}
+		return true
+
+	case filterOr:
+		for _, c := range f.Children {
+			if Match(c, values) {
+				return true
+			}
+		}
+		return false
+
+	case filterNot:
+		for _, c := range f.Children {
+			if Match(c, values) {
+				return false
+			}
+		}
+		return true
+	}
+
+	v, ok := values(f.Left)
+
+	switch f.Op {
+	case filterPresent:
+		return ok
+
+	case filterEqualityMatch:
+		return ok && Compare(v, f.Right) == 0
+
+	case filterSubstrings:
+		return ok && strings.Contains(
+			strings.ToLower(v), strings.ToLower(f.Right))
+
+	case filterSubstringsPrefix:
+		return ok && strings.HasSuffix(
+			strings.ToLower(v), strings.ToLower(f.Right))
+
+	case filterSubstringsPostfix:
+		return ok && strings.HasPrefix(
+			strings.ToLower(v), strings.ToLower(f.Right))
+
+	case filterGreaterOrEqual:
+		return ok && Compare(v, f.Right) >= 0
+
+	case filterLessOrEqual:
+		return ok && Compare(v, f.Right) <= 0
+
+	case filterApproxMatch:
+		return ok && approx(v) == approx(f.Right)
+	}
+
+	return false
+}
+
+// MatchVolume returns a flag indicating whether or not the volume satisfies
+// the filter.
+func MatchVolume(f *types.Filter, v *types.Volume) bool {
+	return Match(f, VolumeValues(v))
+}
+
+// MatchSnapshot returns a flag indicating whether or not the snapshot
+// satisfies the filter.
+func MatchSnapshot(f *types.Filter, s *types.Snapshot) bool {
+	return Match(f, SnapshotValues(s))
+}
+
+// VolumeValues returns a ValueFunc for a volume's attributes.
+func VolumeValues(v *types.Volume) ValueFunc {
+	return func(attr string) (string, bool) {
+		switch strings.ToLower(attr) {
+		case "id":
+			return present(v.ID)
+		case "name":
+			return present(v.Name)
+		case "size":
+			return presentInt(v.Size)
+		case "iops":
+			return presentInt(v.IOPS)
+		case "type":
+			return present(v.Type)
+		case "availabilityzone":
+			return present(v.AvailabilityZone)
+		case "status":
+			return present(v.Status)
+		case "networkname":
+			return present(v.NetworkName)
+		}
+		return field(v.Fields, attr)
+	}
+}
+
+// SnapshotValues returns a ValueFunc for a snapshot's attributes.
+func SnapshotValues(s *types.Snapshot) ValueFunc {
+	return func(attr string) (string, bool) {
+		switch strings.ToLower(attr) {
+		case "id":
+			return present(s.ID)
+		case "name":
+			return present(s.Name)
+		case "description":
+			return present(s.Description)
+		case "status":
+			return present(s.Status)
+		case "volumeid":
+			return present(s.VolumeID)
+		case "volumesize":
+			return presentInt(s.VolumeSize)
+		case "starttime":
+			return presentInt(s.StartTime)
+		}
+		return field(s.Fields, attr)
+	}
+}
+
+// TaskValues returns a ValueFunc for a task's attributes.
+func TaskValues(t *types.Task) ValueFunc {
+	return func(attr string) (string, bool) {
+		switch strings.ToLower(attr) {
+		case "id":
+			return strconv.Itoa(t.ID), true
+		case "user":
+			return present(t.User)
+		case "state":
+			return present(string(t.State))
+		case "queuetime":
+			return presentInt(t.QueueTime)
+		case "starttime":
+			return presentInt(t.StartTime)
+		case "completetime":
+			return presentInt(t.CompleteTime)
+		}
+		return "", false
+	}
+}
+
+// field returns the value of a key from a Fields map. The attribute may be
+// prefixed with "fields." in order to avoid a collision with the name of a
+// built-in attribute. Keys are matched without regard to case if there is
+// no exact match.
+func field(fields map[string]string, attr string) (string, bool) {
+	if len(fields) == 0 {
+		return "", false
+	}
+	if len(attr) > len(fieldsPrefix) &&
+		strings.EqualFold(attr[:len(fieldsPrefix)], fieldsPrefix) {
+		attr = attr[len(fieldsPrefix):]
+	}
+	if v, ok := fields[attr]; ok {
+		return v, true
+	}
+	for k, v := range fields {
+		if strings.EqualFold(k, attr) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func present(v string) (string, bool) {
+	return v, v != ""
+}
+
+func presentInt(v int64) (string, bool) {
+	return strconv.FormatInt(v, 10), v != 0
+}
+
+// Compare compares two operands numerically if they are both numbers and
+// lexically, without regard to case, otherwise. The result is -1 if l < r, 0
+// if l == r, and 1 if l > r.
+func Compare(l, r string) int {
+	if lf, err := strconv.ParseFloat(l, 64); err == nil {
+		if rf, err := strconv.ParseFloat(r, 64); err == nil {
+			switch {
+			case lf < rf:
+				return -1
+			case lf > rf:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(l), strings.ToLower(r))
+}
+
+// approx normalizes a value for an approximate match by ignoring case and
+// all whitespace, hyphen, and underscore characters.
+func approx(v string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\r', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(v))
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/types"
 )
 
 func TestCompilePresent(t *testing.T) {
@@ -145,3 +147,114 @@ func TestCompileInequalityMatch(t *testing.T) {
 	assert.EqualValues(t, "department", f.Children[1].Left)
 	assert.EqualValues(t, "finance", f.Children[1].Right)
 }
+
+func newMatchTestVolume() *types.Volume {
+	return &types.Volume{
+		ID:               "vfs-000",
+		Name:             "Volume 000",
+		Size:             128,
+		IOPS:             50,
+		Type:             "gold",
+		AvailabilityZone: "us-east-1a",
+		Status:           "available",
+		Fields: map[string]string{
+			"env":   "prod",
+			"owner": "Finance",
+		},
+	}
+}
+
+func assertMatchVolume(t *testing.T, expected bool, fsz string) {
+	f, err := CompileFilter(fsz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, MatchVolume(f, newMatchTestVolume()), fsz)
+}
+
+func TestMatchVolumeEquality(t *testing.T) {
+	assertMatchVolume(t, true, `(name=volume 000)`)
+	assertMatchVolume(t, true, `(id=vfs-000)`)
+	assertMatchVolume(t, true, `(type=gold)`)
+	assertMatchVolume(t, true, `(availabilityZone=us-east-1a)`)
+	assertMatchVolume(t, true, `(status=available)`)
+	assertMatchVolume(t, true, `(size=128)`)
+	assertMatchVolume(t, false, `(name=volume 001)`)
+	assertMatchVolume(t, false, `(iops=51)`)
+}
+
+func TestMatchVolumeFields(t *testing.T) {
+	assertMatchVolume(t, true, `(fields.env=prod)`)
+	assertMatchVolume(t, true, `(env=prod)`)
+	assertMatchVolume(t, true, `(fields.owner=finance)`)
+	assertMatchVolume(t, false, `(fields.env=dev)`)
+	assertMatchVolume(t, false, `(fields.missing=prod)`)
+}
+
+func TestMatchVolumePresent(t *testing.T) {
+	assertMatchVolume(t, true, `(fields.env=*)`)
+	assertMatchVolume(t, true, `(size=*)`)
+	assertMatchVolume(t, false, `(networkName=*)`)
+	assertMatchVolume(t, false, `(fields.missing=*)`)
+}
+
+func TestMatchVolumeSubstrings(t *testing.T) {
+	assertMatchVolume(t, true, `(name=*lume*)`)
+	assertMatchVolume(t, true, `(name=*000)`)
+	assertMatchVolume(t, true, `(name=Volume*)`)
+	assertMatchVolume(t, false, `(name=*001)`)
+	assertMatchVolume(t, false, `(name=000*)`)
+}
+
+func TestMatchVolumeOrdering(t *testing.T) {
+	assertMatchVolume(t, true, `(size>=100)`)
+	assertMatchVolume(t, true, `(size>=128)`)
+	assertMatchVolume(t, false, `(size>=1000)`)
+	assertMatchVolume(t, true, `(size<=1000)`)
+	assertMatchVolume(t, false, `(size<=64)`)
+	assertMatchVolume(t, true, `(type>=bronze)`)
+}
+
+func TestMatchVolumeApprox(t *testing.T) {
+	assertMatchVolume(t, true, `(name~=volume-000)`)
+	assertMatchVolume(t, true, `(availabilityZone~=US East 1A)`)
+	assertMatchVolume(t, false, `(name~=volume-001)`)
+}
+
+func TestMatchVolumeCompound(t *testing.T) {
+	assertMatchVolume(t, true, `(&(size>=100)(fields.env=prod))`)
+	assertMatchVolume(t, false, `(&(size>=100)(fields.env=dev))`)
+	assertMatchVolume(t, true, `(|(size>=1000)(fields.env=prod))`)
+	assertMatchVolume(t, false, `(|(size>=1000)(fields.env=dev))`)
+	assertMatchVolume(t, true, `(!(fields.env=dev))`)
+	assertMatchVolume(t, false, `(!(fields.env=prod))`)
+	assertMatchVolume(t, true,
+		`(&(|(type=gold)(type=silver))(!(status=error))(iops<=100))`)
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	s := &types.Snapshot{
+		ID:         "snap-000",
+		Name:       "Snapshot 000",
+		VolumeID:   "abc",
+		VolumeSize: 10240,
+		StartTime:  1600000000,
+		Status:     "online",
+		Fields:     map[string]string{"retain": "true"},
+	}
+
+	assertMatch := func(expected bool, fsz string) {
+		f, err := CompileFilter(fsz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, MatchSnapshot(f, s), fsz)
+	}
+
+	assertMatch(true, `(&(volumeID=abc)(startTime<=1700000000))`)
+	assertMatch(false, `(&(volumeID=abc)(startTime>=1700000000))`)
+	assertMatch(true, `(volumeSize>=1024)`)
+	assertMatch(true, `(|(status=offline)(fields.retain=true))`)
+	assertMatch(false, `(description=*)`)
+	assertMatch(true, `(name=snapshot*)`)
+}
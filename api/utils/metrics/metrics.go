@@ -0,0 +1,293 @@
+/*
+Package metrics provides the counters, gauges, and histograms that describe
+the server's HTTP requests, tasks, and storage driver calls, as well as the
+logic that writes them in the Prometheus text exposition format.
+
+Metrics are registered in a single, process-wide registry when they are
+created. Creating a metric with the name of an existing metric returns the
+existing metric so that packages may declare their metrics as package-level
+variables without regard to the order in which they are initialized.
+*/
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the buckets used by
+// the histograms that measure latencies.
+var DefaultBuckets = []float64{
+	.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300,
+}
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+
+	labelSep = "\xff"
+)
+
+var (
+	families    = map[string]*family{}
+	familiesRWL = &sync.RWMutex{}
+)
+
+type family struct {
+	sync.Mutex
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	sum         float64
+	count       uint64
+	buckets     []uint64
+}
+
+func newFamily(
+	name, help, typ string,
+	buckets []float64,
+	labels []string) *family {
+
+	familiesRWL.Lock()
+	defer familiesRWL.Unlock()
+
+	if f, ok := families[name]; ok {
+		return f
+	}
+
+	f := &family{
+		name:    name,
+		help:    help,
+		typ:     typ,
+		labels:  labels,
+		buckets: buckets,
+		series:  map[string]*series{},
+	}
+	families[name] = f
+	return f
+}
+
+// get returns the series for the label values. The family must be locked.
+func (f *family) get(labelValues []string) *series {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf(
+			"metric %s: expected %d label values, got %d",
+			f.name, len(f.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, labelSep)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: labelValues}
+		if f.typ == typeHistogram {
+			s.buckets = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Counter is a metric whose values only increase.
+type Counter struct {
+	f *family
+}
+
+// NewCounter returns a new counter with the provided label names.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{newFamily(name, help, typeCounter, nil, labels)}
+}
+
+// Inc increments by one the value for the provided label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the provided, non-negative amount to the value for the provided
+// label values.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.f.Lock()
+	defer c.f.Unlock()
+	c.f.get(labelValues).value += v
+}
+
+// Gauge is a metric whose values may increase or decrease.
+type Gauge struct {
+	f *family
+}
+
+// NewGauge returns a new gauge with the provided label names.
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{newFamily(name, help, typeGauge, nil, labels)}
+}
+
+// Set sets the value for the provided label values.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.f.Lock()
+	defer g.f.Unlock()
+	g.f.get(labelValues).value = v
+}
+
+// Reset removes the values for all label values.
+func (g *Gauge) Reset() {
+	g.f.Lock()
+	defer g.f.Unlock()
+	g.f.series = map[string]*series{}
+}
+
+// Histogram is a metric that counts observations in buckets.
+type Histogram struct {
+	f *family
+}
+
+// NewHistogram returns a new histogram with the provided bucket upper bounds
+// and label names. The DefaultBuckets are used if no buckets are provided.
+func NewHistogram(
+	name, help string, buckets []float64, labels ...string) *Histogram {
+
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	return &Histogram{newFamily(name, help, typeHistogram, buckets, labels)}
+}
+
+// Observe records an observation for the provided label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	h.f.Lock()
+	defer h.f.Unlock()
+	s := h.f.get(labelValues)
+	s.sum += v
+	s.count++
+	for i, b := range h.f.buckets {
+		if v <= b {
+			s.buckets[i]++
+		}
+	}
+}
+
+// ObserveSince records the number of seconds elapsed since the provided time
+// for the provided label values.
+func (h *Histogram) ObserveSince(t time.Time, labelValues ...string) {
+	h.Observe(time.Since(t).Seconds(), labelValues...)
+}
+
+// Write writes all of the registered metrics to the writer in the
+// Prometheus text exposition format.
+func Write(w io.Writer) error {
+
+	familiesRWL.RLock()
+	names := []string{}
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fams := []*family{}
+	for _, name := range names {
+		fams = append(fams, families[name])
+	}
+	familiesRWL.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range fams {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+func (f *family) write(w *bufio.Writer) {
+	f.Lock()
+	defer f.Unlock()
+
+	if len(f.series) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+
+	keys := []string{}
+	for k := range f.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := f.series[k]
+		if f.typ != typeHistogram {
+			fmt.Fprintf(w, "%s%s %s\n",
+				f.name, f.labelPairs(s.labelValues, ""), formatFloat(s.value))
+			continue
+		}
+		for i, b := range f.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n",
+				f.name,
+				f.labelPairs(s.labelValues, formatFloat(b)),
+				s.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n",
+			f.name, f.labelPairs(s.labelValues, "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n",
+			f.name, f.labelPairs(s.labelValues, ""), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n",
+			f.name, f.labelPairs(s.labelValues, ""), s.count)
+	}
+}
+
+// labelPairs returns the series' labels in braces. The le label is appended
+// if the provided bucket bound is not empty.
+func (f *family) labelPairs(labelValues []string, le string) string {
+	pairs := []string{}
+	for i, l := range f.labels {
+		pairs = append(
+			pairs, fmt.Sprintf(`%s="%s"`, l, escapeLabel(labelValues[i])))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf(`le="%s"`, le))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("{%s}", strings.Join(pairs, ","))
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelReplacer.Replace(s)
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	c := NewCounter("test_counter_total", "A test counter.", "route", "code")
+	c.Inc("volumes", "200")
+	c.Inc("volumes", "200")
+	c.Add(3, "volumes", "500")
+	c.Add(-1, "volumes", "500")
+
+	// creating a metric with an existing name returns the existing metric
+	assert.True(t, c.f == NewCounter("test_counter_total", "").f)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Write(buf))
+	s := buf.String()
+
+	assert.Contains(t, s, "# HELP test_counter_total A test counter.\n")
+	assert.Contains(t, s, "# TYPE test_counter_total counter\n")
+	assert.Contains(t, s, `test_counter_total{route="volumes",code="200"} 2`)
+	assert.Contains(t, s, `test_counter_total{route="volumes",code="500"} 3`)
+}
+
+func TestGauge(t *testing.T) {
+	g := NewGauge("test_gauge", "A test gauge.", "service")
+	g.Set(4, `v"fs`)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Write(buf))
+	assert.Contains(t, buf.String(), `test_gauge{service="v\"fs"} 4`)
+
+	g.Reset()
+	buf.Reset()
+	assert.NoError(t, Write(buf))
+	assert.NotContains(t, buf.String(), "test_gauge")
+}
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram(
+		"test_duration_seconds", "A test histogram.", []float64{1, 5}, "m")
+	h.Observe(0.5, "a")
+	h.Observe(2, "a")
+	h.Observe(10, "a")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Write(buf))
+	s := buf.String()
+
+	assert.Contains(t, s, "# TYPE test_duration_seconds histogram\n")
+	assert.Contains(t, s, `test_duration_seconds_bucket{m="a",le="1"} 1`)
+	assert.Contains(t, s, `test_duration_seconds_bucket{m="a",le="5"} 2`)
+	assert.Contains(t, s, `test_duration_seconds_bucket{m="a",le="+Inf"} 3`)
+	assert.Contains(t, s, `test_duration_seconds_sum{m="a"} 12.5`)
+	assert.Contains(t, s, `test_duration_seconds_count{m="a"} 3`)
+
+	// the families are written in order of their names
+	assert.True(t,
+		strings.Index(s, "test_counter_total") <
+			strings.Index(s, "test_duration_seconds"))
+}
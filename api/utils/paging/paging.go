@@ -0,0 +1,367 @@
+/*
+Package paging provides the sorting and slicing logic shared by the server and
+the client for the paged list resources -- /volumes, /snapshots, and /tasks.
+
+A list is always sorted before it is sliced so that a page is the same
+regardless of the order in which the services returned their objects. Objects
+with equal sort values are ordered by their service name and ID.
+*/
+package paging
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/filters"
+)
+
+const (
+	// LimitParam is the query string parameter for the page size.
+	LimitParam = "limit"
+
+	// OffsetParam is the query string parameter for the page offset.
+	OffsetParam = "offset"
+
+	// TokenParam is the query string parameter for a continuation token.
+	TokenParam = "token"
+
+	// SortParam is the query string parameter for the sort attribute.
+	SortParam = "sort"
+
+	descSuffix = ":desc"
+	ascSuffix  = ":asc"
+)
+
+// Page is a request for a single page of a list.
+type Page struct {
+
+	// Offset is the number of objects to skip.
+	Offset int
+
+	// Limit is the maximum number of objects in the page; zero is no limit.
+	Limit int
+
+	// SortKey is the attribute by which the list is sorted. An empty value
+	// sorts the list by service name and ID.
+	SortKey string
+
+	// SortDesc is a flag that indicates the sort is descending.
+	SortDesc bool
+}
+
+// Item is a single object in a list.
+type Item struct {
+
+	// Service is the name of the service to which the object belongs.
+	Service string
+
+	// ID is the object's ID.
+	ID string
+
+	// Values returns the object's attribute values.
+	Values filters.ValueFunc
+
+	// Object is the object.
+	Object interface{}
+}
+
+// ParsePage parses a page request from a query string. A nil page is
+// returned if the query string does not contain any of the paging
+// parameters.
+//
+// The raw query values are used instead of the values in the request's
+// store because the latter converts values such as "1" and "0" to booleans.
+func ParsePage(query url.Values) (*Page, error) {
+
+	var (
+		limit  = query.Get(LimitParam)
+		offset = query.Get(OffsetParam)
+		token  = query.Get(TokenParam)
+		sortBy = query.Get(SortParam)
+	)
+
+	if limit == "" && offset == "" && token == "" && sortBy == "" {
+		return nil, nil
+	}
+
+	p := &Page{}
+
+	if limit != "" {
+		i, err := strconv.Atoi(limit)
+		if err != nil || i < 0 {
+			return nil, utils.NewBadPageOptsErr(LimitParam, limit, err)
+		}
+		p.Limit = i
+	}
+
+	if token != "" {
+		if err := p.decodeToken(token); err != nil {
+			return nil, utils.NewBadPageOptsErr(TokenParam, token, err)
+		}
+		return p, nil
+	}
+
+	if offset != "" {
+		i, err := strconv.Atoi(offset)
+		if err != nil || i < 0 {
+			return nil, utils.NewBadPageOptsErr(OffsetParam, offset, err)
+		}
+		p.Offset = i
+	}
+
+	p.SortKey, p.SortDesc = ParseSort(sortBy)
+	return p, nil
+}
+
+// ParseSort parses a sort parameter of the form "attribute[:asc|:desc]".
+func ParseSort(sortBy string) (string, bool) {
+	lsortBy := strings.ToLower(sortBy)
+	switch {
+	case strings.HasSuffix(lsortBy, descSuffix):
+		return sortBy[:len(sortBy)-len(descSuffix)], true
+	case strings.HasSuffix(lsortBy, ascSuffix):
+		return sortBy[:len(sortBy)-len(ascSuffix)], false
+	}
+	return sortBy, false
+}
+
+// Apply sorts the items and returns the ones that belong to the page as well
+// as the continuation token for the next page. The token is empty if the
+// page is the last one.
+func (p *Page) Apply(items []*Item) ([]*Item, string) {
+
+	Sort(items, p.SortKey, p.SortDesc)
+
+	if p.Offset >= len(items) {
+		return []*Item{}, ""
+	}
+
+	end := len(items)
+	if p.Limit > 0 && p.Offset+p.Limit < end {
+		end = p.Offset + p.Limit
+	}
+
+	var token string
+	if end < len(items) {
+		token = (&Page{
+			Offset:   end,
+			Limit:    p.Limit,
+			SortKey:  p.SortKey,
+			SortDesc: p.SortDesc,
+		}).encodeToken()
+	}
+
+	return items[p.Offset:end], token
+}
+
+// encodeToken encodes the page's offset and sort options as an opaque
+// token. The limit is not included so that a client may change the page
+// size between requests.
+func (p *Page) encodeToken() string {
+	sortBy := p.SortKey
+	if p.SortDesc {
+		sortBy = sortBy + descSuffix
+	}
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%d|%s", p.Offset, sortBy)))
+}
+
+func (p *Page) decodeToken(token string) error {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return err
+	}
+	parts := strings.SplitN(string(buf), "|", 2)
+	if len(parts) != 2 {
+		return goof.New("invalid token")
+	}
+	offset, err := strconv.Atoi(parts[0])
+	if err != nil || offset < 0 {
+		return goof.New("invalid token offset")
+	}
+	p.Offset = offset
+	p.SortKey, p.SortDesc = ParseSort(parts[1])
+	return nil
+}
+
+// Sort sorts the items by the value of the provided attribute. Items with
+// equal values, or that do not have the attribute, are ordered by their
+// service name and then their ID.
+func Sort(items []*Item, key string, desc bool) {
+	sort.Stable(&byKey{items: items, key: key, desc: desc})
+}
+
+type byKey struct {
+	items []*Item
+	key   string
+	desc  bool
+}
+
+func (a *byKey) Len() int      { return len(a.items) }
+func (a *byKey) Swap(i, j int) { a.items[i], a.items[j] = a.items[j], a.items[i] }
+func (a *byKey) Less(i, j int) bool {
+	l, r := a.items[i], a.items[j]
+	if a.key != "" {
+		lv, lok := l.Values(a.key)
+		rv, rok := r.Values(a.key)
+		if lok && rok {
+			if c := filters.Compare(lv, rv); c != 0 {
+				if a.desc {
+					return c > 0
+				}
+				return c < 0
+			}
+		} else if lok != rok {
+			// objects that do not have the attribute come last
+			return lok
+		}
+	}
+	if l.Service != r.Service {
+		return l.Service < r.Service
+	}
+	return l.ID < r.ID
+}
+
+// ServiceVolumeItems returns the volumes in the map as a list of items.
+func ServiceVolumeItems(m types.ServiceVolumeMap) []*Item {
+	items := []*Item{}
+	for service, vm := range m {
+		items = append(items, VolumeItems(service, vm)...)
+	}
+	return items
+}
+
+// VolumeItems returns the volumes in the map as a list of items.
+func VolumeItems(service string, m types.VolumeMap) []*Item {
+	items := []*Item{}
+	for id, v := range m {
+		items = append(items, &Item{
+			Service: service,
+			ID:      id,
+			Values:  filters.VolumeValues(v),
+			Object:  v,
+		})
+	}
+	return items
+}
+
+// ServiceSnapshotItems returns the snapshots in the map as a list of items.
+func ServiceSnapshotItems(m types.ServiceSnapshotMap) []*Item {
+	items := []*Item{}
+	for service, sm := range m {
+		items = append(items, SnapshotItems(service, sm)...)
+	}
+	return items
+}
+
+// SnapshotItems returns the snapshots in the map as a list of items.
+func SnapshotItems(service string, m types.SnapshotMap) []*Item {
+	items := []*Item{}
+	for id, s := range m {
+		items = append(items, &Item{
+			Service: service,
+			ID:      id,
+			Values:  filters.SnapshotValues(s),
+			Object:  s,
+		})
+	}
+	return items
+}
+
+// TaskItems returns the tasks as a list of items.
+func TaskItems(tasks []*types.Task) []*Item {
+	items := []*Item{}
+	for _, t := range tasks {
+		items = append(items, &Item{
+			// pad the ID so tasks are ordered numerically by default
+			ID:     fmt.Sprintf("%020d", t.ID),
+			Values: filters.TaskValues(t),
+			Object: t,
+		})
+	}
+	return items
+}
+
+// ServiceVolumeMap returns the page of the provided volumes and the
+// continuation token for the next page.
+func (p *Page) ServiceVolumeMap(
+	m types.ServiceVolumeMap) (types.ServiceVolumeMap, string) {
+
+	items, token := p.Apply(ServiceVolumeItems(m))
+	page := types.ServiceVolumeMap{}
+	for _, i := range items {
+		vm, ok := page[i.Service]
+		if !ok {
+			vm = types.VolumeMap{}
+			page[i.Service] = vm
+		}
+		vm[i.ID] = i.Object.(*types.Volume)
+	}
+	return page, token
+}
+
+// VolumeMap returns the page of the provided volumes and the continuation
+// token for the next page.
+func (p *Page) VolumeMap(
+	service string, m types.VolumeMap) (types.VolumeMap, string) {
+
+	items, token := p.Apply(VolumeItems(service, m))
+	page := types.VolumeMap{}
+	for _, i := range items {
+		page[i.ID] = i.Object.(*types.Volume)
+	}
+	return page, token
+}
+
+// ServiceSnapshotMap returns the page of the provided snapshots and the
+// continuation token for the next page.
+func (p *Page) ServiceSnapshotMap(
+	m types.ServiceSnapshotMap) (types.ServiceSnapshotMap, string) {
+
+	items, token := p.Apply(ServiceSnapshotItems(m))
+	page := types.ServiceSnapshotMap{}
+	for _, i := range items {
+		sm, ok := page[i.Service]
+		if !ok {
+			sm = types.SnapshotMap{}
+			page[i.Service] = sm
+		}
+		sm[i.ID] = i.Object.(*types.Snapshot)
+	}
+	return page, token
+}
+
+// SnapshotMap returns the page of the provided snapshots and the
+// continuation token for the next page.
+func (p *Page) SnapshotMap(
+	service string, m types.SnapshotMap) (types.SnapshotMap, string) {
+
+	items, token := p.Apply(SnapshotItems(service, m))
+	page := types.SnapshotMap{}
+	for _, i := range items {
+		page[i.ID] = i.Object.(*types.Snapshot)
+	}
+	return page, token
+}
+
+// TaskMap returns the page of the provided tasks, keyed by their IDs, and
+// the continuation token for the next page.
+func (p *Page) TaskMap(
+	tasks []*types.Task) (map[string]*types.Task, string) {
+
+	items, token := p.Apply(TaskItems(tasks))
+	page := map[string]*types.Task{}
+	for _, i := range items {
+		t := i.Object.(*types.Task)
+		page[strconv.Itoa(t.ID)] = t
+	}
+	return page, token
+}
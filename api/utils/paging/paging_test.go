@@ -0,0 +1,131 @@
+package paging
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+func newTestServiceVolumeMap() types.ServiceVolumeMap {
+	return types.ServiceVolumeMap{
+		"ebs": types.VolumeMap{
+			"vol-000": &types.Volume{ID: "vol-000", Size: 30},
+			"vol-001": &types.Volume{ID: "vol-001", Size: 10},
+		},
+		"vfs": types.VolumeMap{
+			"vfs-000": &types.Volume{ID: "vfs-000", Size: 20},
+			"vfs-001": &types.Volume{ID: "vfs-001", Size: 10},
+			"vfs-002": &types.Volume{ID: "vfs-002"},
+		},
+	}
+}
+
+func pageIDs(m types.ServiceVolumeMap) []string {
+	items := ServiceVolumeItems(m)
+	Sort(items, "", false)
+	ids := []string{}
+	for _, i := range items {
+		ids = append(ids, i.Service+"/"+i.ID)
+	}
+	return ids
+}
+
+func TestParsePageNone(t *testing.T) {
+	p, err := ParsePage(url.Values{"attachments": []string{"true"}})
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestParsePage(t *testing.T) {
+	p, err := ParsePage(url.Values{
+		LimitParam:  []string{"1"},
+		OffsetParam: []string{"0"},
+		SortParam:   []string{"size:DESC"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Page{Limit: 1, SortKey: "size", SortDesc: true}, p)
+}
+
+func TestParsePageInvalid(t *testing.T) {
+	_, err := ParsePage(url.Values{LimitParam: []string{"-1"}})
+	assert.IsType(t, &types.ErrBadPageOpts{}, err)
+	_, err = ParsePage(url.Values{OffsetParam: []string{"one"}})
+	assert.IsType(t, &types.ErrBadPageOpts{}, err)
+	_, err = ParsePage(url.Values{TokenParam: []string{"!!!"}})
+	assert.IsType(t, &types.ErrBadPageOpts{}, err)
+}
+
+func TestPageDefaultOrder(t *testing.T) {
+	p := &Page{Limit: 2}
+	page, token := p.ServiceVolumeMap(newTestServiceVolumeMap())
+	assert.Equal(t, []string{"ebs/vol-000", "ebs/vol-001"}, pageIDs(page))
+	assert.NotEmpty(t, token)
+
+	p, err := ParsePage(url.Values{
+		LimitParam: []string{"2"},
+		TokenParam: []string{token},
+	})
+	assert.NoError(t, err)
+	page, token = p.ServiceVolumeMap(newTestServiceVolumeMap())
+	assert.Equal(t, []string{"vfs/vfs-000", "vfs/vfs-001"}, pageIDs(page))
+	assert.NotEmpty(t, token)
+
+	p, err = ParsePage(url.Values{
+		LimitParam: []string{"2"},
+		TokenParam: []string{token},
+	})
+	assert.NoError(t, err)
+	page, token = p.ServiceVolumeMap(newTestServiceVolumeMap())
+	assert.Equal(t, []string{"vfs/vfs-002"}, pageIDs(page))
+	assert.Empty(t, token)
+}
+
+func TestPageSortedBySize(t *testing.T) {
+	items, token := (&Page{SortKey: "size"}).Apply(
+		ServiceVolumeItems(newTestServiceVolumeMap()))
+	assert.Empty(t, token)
+
+	ids := []string{}
+	for _, i := range items {
+		ids = append(ids, i.Service+"/"+i.ID)
+	}
+	assert.Equal(t, []string{
+		"ebs/vol-001",
+		"vfs/vfs-001",
+		"vfs/vfs-000",
+		"ebs/vol-000",
+		"vfs/vfs-002",
+	}, ids)
+}
+
+func TestPageSortedBySizeDescWithOffset(t *testing.T) {
+	p := &Page{Offset: 1, Limit: 2, SortKey: "size", SortDesc: true}
+	items, token := p.Apply(ServiceVolumeItems(newTestServiceVolumeMap()))
+	assert.NotEmpty(t, token)
+
+	ids := []string{}
+	for _, i := range items {
+		ids = append(ids, i.Service+"/"+i.ID)
+	}
+	assert.Equal(t, []string{"vfs/vfs-000", "ebs/vol-001"}, ids)
+}
+
+func TestPageOffsetOutOfRange(t *testing.T) {
+	page, token := (&Page{Offset: 10}).VolumeMap("vfs", types.VolumeMap{
+		"vfs-000": &types.Volume{ID: "vfs-000"},
+	})
+	assert.Empty(t, page)
+	assert.Empty(t, token)
+}
+
+func TestTaskMap(t *testing.T) {
+	tasks := []*types.Task{{ID: 10}, {ID: 2}, {ID: 1}}
+	page, token := (&Page{Limit: 2}).TaskMap(tasks)
+	assert.NotEmpty(t, token)
+	assert.Len(t, page, 2)
+	assert.NotNil(t, page["1"])
+	assert.NotNil(t, page["2"])
+}
@@ -63,6 +63,10 @@ var (
 	// request.
 	VolumeCopyRequestSchema = buildSchemaVar("volumeCopyRequest")
 
+	// VolumeResizeRequestSchema is the JSON schema for a Volume resize
+	// request.
+	VolumeResizeRequestSchema = buildSchemaVar("volumeResizeRequest")
+
 	// VolumeSnapshotRequestSchema is the JSON schema for a Volume snapshot
 	// request.
 	VolumeSnapshotRequestSchema = buildSchemaVar("volumeSnapshotRequest")
@@ -0,0 +1,379 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+const (
+	// configStorageDriverFormat is the format of the strings that must be
+	// the names of registered storage drivers.
+	configStorageDriverFormat = "storageDriver"
+
+	// configDriverSectionsKeyword is the keyword that marks the objects
+	// whose properties that are not described by the schema must be named
+	// after registered drivers.
+	configDriverSectionsKeyword = "x-driverSections"
+
+	// configForeignSectionsKeyword is the keyword that marks the objects
+	// whose properties that are neither described by the schema nor named
+	// after registered drivers belong to other programs and are ignored.
+	configForeignSectionsKeyword = "x-foreignSections"
+)
+
+var (
+	configSchema     map[string]interface{}
+	configSchemaErr  error
+	configSchemaOnce sync.Once
+)
+
+// ConfigError is an invalid property of a configuration.
+type ConfigError struct {
+
+	// Path is the dotted path of the property.
+	Path string
+
+	// Message describes why the property is invalid.
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ConfigErrors are the invalid properties of a configuration.
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ConfigValidator validates configuration data using the libStorage
+// configuration JSON schema.
+//
+// Property names are matched without regard to case, and a scalar value is
+// valid if it can be converted to the type of its property, as both are by
+// gofig. A secret reference, such as "env:SIO_PASS", is valid for a property
+// of any type. The names of the properties of the configuration scopes that
+// are not described by the schema must be the names of registered drivers,
+// and the strings with the format "storageDriver" must be the names of
+// registered storage drivers. The other properties of the root of the
+// configuration, such as the sections of the programs that embed libStorage,
+// are ignored.
+type ConfigValidator struct {
+
+	// StorageDrivers are the names of the registered storage drivers.
+	StorageDrivers []string
+
+	// Drivers are the names of all of the registered drivers.
+	Drivers []string
+}
+
+// Validate validates configuration data that was unmarshaled from YAML or
+// JSON. If the data is invalid a ConfigErrors error is returned.
+func (v *ConfigValidator) Validate(d interface{}) error {
+	configSchemaOnce.Do(func() {
+		configSchemaErr = json.Unmarshal(
+			[]byte(ConfigJSONSchema), &configSchema)
+	})
+	if configSchemaErr != nil {
+		return configSchemaErr
+	}
+
+	errs := ConfigErrors{}
+	v.validate(&errs, "", configSchema, normalizeConfig(d))
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (v *ConfigValidator) validate(
+	errs *ConfigErrors,
+	path string,
+	s map[string]interface{},
+	d interface{}) {
+
+	s = resolveConfigRef(s)
+
+	// a property without a value is not set
+	if d == nil {
+		return
+	}
+
+	// a secret reference is resolved when the configuration is loaded
+	if sd, ok := d.(string); ok && types.IsSecretRef(sd) {
+		return
+	}
+
+	if t, ok := s["type"]; ok && !isConfigType(t, d) {
+		addConfigError(errs, path, "invalid type, expected %s", typeNames(t))
+		return
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok && !inConfigEnum(enum, d) {
+		addConfigError(errs, path, "invalid value %q, expected one of %s",
+			fmt.Sprintf("%v", d), typeNames(enum))
+		return
+	}
+
+	if min, ok := s["minimum"].(float64); ok {
+		if f, ok := toFloat(d); ok && f < min {
+			addConfigError(errs, path, "invalid value %v, minimum is %v", d, min)
+			return
+		}
+	}
+
+	if s["format"] == configStorageDriverFormat {
+		if name := fmt.Sprintf("%v", d); name != "" &&
+			!containsFold(v.StorageDrivers, name) {
+			addConfigError(errs, path, "unknown storage driver %q", name)
+			return
+		}
+	}
+
+	switch td := d.(type) {
+	case map[string]interface{}:
+		v.validateObject(errs, path, s, td)
+	case []interface{}:
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			for i, item := range td {
+				v.validate(errs, fmt.Sprintf("%s[%d]", path, i), items, item)
+			}
+		}
+	}
+}
+
+func (v *ConfigValidator) validateObject(
+	errs *ConfigErrors,
+	path string,
+	s map[string]interface{},
+	d map[string]interface{}) {
+
+	props := map[string]map[string]interface{}{}
+	if sp, ok := s["properties"].(map[string]interface{}); ok {
+		for k, ps := range sp {
+			if m, ok := ps.(map[string]interface{}); ok {
+				props[strings.ToLower(k)] = m
+			}
+		}
+	}
+
+	keys := map[string]string{}
+	for k := range d {
+		keys[strings.ToLower(k)] = k
+	}
+
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			name := fmt.Sprintf("%v", r)
+			if _, ok := keys[strings.ToLower(name)]; !ok {
+				addConfigError(errs, joinConfigPath(path, name),
+					"missing required property")
+			}
+		}
+	}
+
+	driverSections, _ := s[configDriverSectionsKeyword].(bool)
+	foreignSections, _ := s[configForeignSectionsKeyword].(bool)
+
+	names := make([]string, 0, len(d))
+	for k := range d {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		kp := joinConfigPath(path, k)
+
+		if ps, ok := props[strings.ToLower(k)]; ok {
+			v.validate(errs, kp, ps, d[k])
+			continue
+		}
+
+		switch ap := s["additionalProperties"].(type) {
+		case bool:
+			if !ap {
+				addConfigError(errs, kp, "unknown property")
+			}
+		case map[string]interface{}:
+			if driverSections && !containsFold(v.Drivers, k) {
+				if !foreignSections {
+					addConfigError(errs, kp, "unknown property")
+				}
+				continue
+			}
+			v.validate(errs, kp, ap, d[k])
+		}
+	}
+}
+
+// resolveConfigRef returns the definition to which a schema refers if it is
+// a reference; otherwise the schema is returned.
+func resolveConfigRef(s map[string]interface{}) map[string]interface{} {
+	for {
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			return s
+		}
+		defs, _ := configSchema["definitions"].(map[string]interface{})
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		def, ok := defs[name].(map[string]interface{})
+		if !ok {
+			return s
+		}
+		s = def
+	}
+}
+
+// normalizeConfig converts the maps unmarshaled from YAML, whose keys may
+// be of any type, to maps with string keys.
+func normalizeConfig(d interface{}) interface{} {
+	switch td := d.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range td {
+			m[fmt.Sprintf("%v", k)] = normalizeConfig(v)
+		}
+		return m
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range td {
+			m[k] = normalizeConfig(v)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(td))
+		for i, v := range td {
+			a[i] = normalizeConfig(v)
+		}
+		return a
+	}
+	return d
+}
+
+func isConfigType(t interface{}, d interface{}) bool {
+	switch tt := t.(type) {
+	case string:
+		return isConfigTypeName(tt, d)
+	case []interface{}:
+		for _, n := range tt {
+			if s, ok := n.(string); ok && isConfigTypeName(s, d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isConfigTypeName(t string, d interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := d.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := d.([]interface{})
+		return ok
+	case "string":
+		switch d.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+		return true
+	case "boolean":
+		switch td := d.(type) {
+		case bool:
+			return true
+		case string:
+			_, err := strconv.ParseBool(td)
+			return err == nil
+		}
+	case "integer":
+		if s, ok := d.(string); ok {
+			_, err := strconv.ParseInt(s, 0, 64)
+			return err == nil
+		}
+		f, ok := toFloat(d)
+		return ok && f == float64(int64(f))
+	case "number":
+		if s, ok := d.(string); ok {
+			_, err := strconv.ParseFloat(s, 64)
+			return err == nil
+		}
+		_, ok := toFloat(d)
+		return ok
+	case "null":
+		return d == nil
+	}
+	return false
+}
+
+func toFloat(d interface{}) (float64, bool) {
+	switch td := d.(type) {
+	case int:
+		return float64(td), true
+	case int64:
+		return float64(td), true
+	case uint64:
+		return float64(td), true
+	case float64:
+		return td, true
+	}
+	return 0, false
+}
+
+func inConfigEnum(enum []interface{}, d interface{}) bool {
+	s := fmt.Sprintf("%v", d)
+	for _, e := range enum {
+		if strings.EqualFold(fmt.Sprintf("%v", e), s) {
+			return true
+		}
+	}
+	return false
+}
+
+func typeNames(t interface{}) string {
+	a, ok := t.([]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", t)
+	}
+	names := make([]string, len(a))
+	for i, n := range a {
+		names[i] = fmt.Sprintf("%v", n)
+	}
+	return strings.Join(names, ", ")
+}
+
+func containsFold(a []string, s string) bool {
+	for _, v := range a {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinConfigPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return fmt.Sprintf("%s.%s", path, k)
+}
+
+func addConfigError(
+	errs *ConfigErrors, path, format string, args ...interface{}) {
+	*errs = append(*errs, &ConfigError{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,1825 @@
+package schema
+
+const (
+	// ConfigJSONSchema is the libStorage configuration JSON schema
+	ConfigJSONSchema = `{
+    "id": "https://github.com/emccode/libstorage/config",
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "title": "libStorage Configuration JSON Schema",
+    "$ref": "#/definitions/config",
+    "definitions": {
+        "config": {
+            "title": "Config",
+            "description": "The root of the libStorage configuration. The properties that are neither described by this schema nor named after registered drivers belong to other programs that share the configuration and are not validated.",
+            "type": "object",
+            "properties": {
+                "libstorage": {
+                    "$ref": "#/definitions/libstorageConfig"
+                },
+                "docker": {
+                    "$ref": "#/definitions/dockerConfig"
+                },
+                "isilon": {
+                    "$ref": "#/definitions/isilonConfig"
+                },
+                "linux": {
+                    "$ref": "#/definitions/linuxConfig"
+                },
+                "scaleio": {
+                    "$ref": "#/definitions/scaleioConfig"
+                },
+                "vfs": {
+                    "$ref": "#/definitions/vfsConfig"
+                },
+                "virtualbox": {
+                    "$ref": "#/definitions/virtualboxConfig"
+                },
+                "volume": {
+                    "$ref": "#/definitions/volumeConfig"
+                }
+            },
+            "additionalProperties": {
+                "description": "The configuration of a registered driver that is not described by this schema.",
+                "type": "object"
+            },
+            "x-driverSections": true,
+            "x-foreignSections": true
+        },
+        "libstorageConfig": {
+            "title": "libStorage",
+            "description": "The libStorage configuration.",
+            "type": "object",
+            "properties": {
+                "host": {
+                    "type": "string",
+                    "description": "The address of the libStorage server."
+                },
+                "service": {
+                    "type": "string",
+                    "description": "The name of the service the client uses."
+                },
+                "embedded": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the client starts an embedded server."
+                },
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the storage driver used by a service that does not specify one."
+                },
+                "os": {
+                    "$ref": "#/definitions/osConfig"
+                },
+                "storage": {
+                    "$ref": "#/definitions/storageConfig"
+                },
+                "integration": {
+                    "$ref": "#/definitions/integrationConfig"
+                },
+                "logging": {
+                    "$ref": "#/definitions/loggingConfig"
+                },
+                "http": {
+                    "$ref": "#/definitions/httpConfig"
+                },
+                "executor": {
+                    "$ref": "#/definitions/executorConfig"
+                },
+                "tls": {
+                    "$ref": "#/definitions/tlsConfig"
+                },
+                "device": {
+                    "$ref": "#/definitions/deviceConfig"
+                },
+                "schema": {
+                    "$ref": "#/definitions/schemaConfig"
+                },
+                "client": {
+                    "$ref": "#/definitions/clientConfig"
+                },
+                "server": {
+                    "$ref": "#/definitions/serverConfig"
+                }
+            },
+            "additionalProperties": false
+        },
+        "serverConfig": {
+            "title": "Server",
+            "description": "The libStorage server's configuration. The server is a configuration scope, so it may override any libStorage or driver property.",
+            "type": "object",
+            "properties": {
+                "host": {
+                    "type": "string",
+                    "description": "The address of the libStorage server."
+                },
+                "service": {
+                    "type": "string",
+                    "description": "The name of the service the client uses."
+                },
+                "embedded": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the client starts an embedded server."
+                },
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the storage driver used by a service that does not specify one."
+                },
+                "os": {
+                    "$ref": "#/definitions/osConfig"
+                },
+                "storage": {
+                    "$ref": "#/definitions/storageConfig"
+                },
+                "integration": {
+                    "$ref": "#/definitions/integrationConfig"
+                },
+                "logging": {
+                    "$ref": "#/definitions/loggingConfig"
+                },
+                "http": {
+                    "$ref": "#/definitions/httpConfig"
+                },
+                "executor": {
+                    "$ref": "#/definitions/executorConfig"
+                },
+                "tls": {
+                    "$ref": "#/definitions/tlsConfig"
+                },
+                "device": {
+                    "$ref": "#/definitions/deviceConfig"
+                },
+                "schema": {
+                    "$ref": "#/definitions/schemaConfig"
+                },
+                "autoEndpointMode": {
+                    "type": "string",
+                    "description": "The type of endpoint created when none is configured.",
+                    "enum": [
+                        "unix",
+                        "tcp"
+                    ]
+                },
+                "tasks": {
+                    "$ref": "#/definitions/tasksConfig"
+                },
+                "webhooks": {
+                    "$ref": "#/definitions/webhooksConfig"
+                },
+                "metrics": {
+                    "$ref": "#/definitions/metricsConfig"
+                },
+                "auth": {
+                    "$ref": "#/definitions/authConfig"
+                },
+                "signing": {
+                    "$ref": "#/definitions/signingConfig"
+                },
+                "rateLimit": {
+                    "$ref": "#/definitions/rateLimitConfig"
+                },
+                "driverCalls": {
+                    "$ref": "#/definitions/driverCallsConfig"
+                },
+                "quotas": {
+                    "$ref": "#/definitions/quotasConfig"
+                },
+                "tenants": {
+                    "$ref": "#/definitions/tenantsConfig"
+                },
+                "audit": {
+                    "$ref": "#/definitions/auditConfig"
+                },
+                "idempotency": {
+                    "$ref": "#/definitions/idempotencyConfig"
+                },
+                "services": {
+                    "description": "The storage services hosted by the server, by name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/serviceConfig"
+                    }
+                },
+                "endpoints": {
+                    "description": "The endpoints on which the server listens, by name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/endpointConfig"
+                    }
+                },
+                "libstorage": {
+                    "$ref": "#/definitions/libstorageConfig"
+                },
+                "docker": {
+                    "$ref": "#/definitions/dockerConfig"
+                },
+                "isilon": {
+                    "$ref": "#/definitions/isilonConfig"
+                },
+                "linux": {
+                    "$ref": "#/definitions/linuxConfig"
+                },
+                "scaleio": {
+                    "$ref": "#/definitions/scaleioConfig"
+                },
+                "vfs": {
+                    "$ref": "#/definitions/vfsConfig"
+                },
+                "virtualbox": {
+                    "$ref": "#/definitions/virtualboxConfig"
+                },
+                "volume": {
+                    "$ref": "#/definitions/volumeConfig"
+                }
+            },
+            "additionalProperties": {
+                "description": "The configuration of a registered driver that is not described by this schema.",
+                "type": "object"
+            },
+            "x-driverSections": true
+        },
+        "serviceConfig": {
+            "title": "Service",
+            "description": "A storage service. A service is a configuration scope, so it may override any server, libStorage, or driver property.",
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the service's storage driver.",
+                    "format": "storageDriver"
+                },
+                "host": {
+                    "type": "string",
+                    "description": "The address of the libStorage server."
+                },
+                "service": {
+                    "type": "string",
+                    "description": "The name of the service the client uses."
+                },
+                "embedded": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the client starts an embedded server."
+                },
+                "os": {
+                    "$ref": "#/definitions/osConfig"
+                },
+                "storage": {
+                    "$ref": "#/definitions/storageConfig"
+                },
+                "integration": {
+                    "$ref": "#/definitions/integrationConfig"
+                },
+                "logging": {
+                    "$ref": "#/definitions/loggingConfig"
+                },
+                "http": {
+                    "$ref": "#/definitions/httpConfig"
+                },
+                "executor": {
+                    "$ref": "#/definitions/executorConfig"
+                },
+                "tls": {
+                    "$ref": "#/definitions/tlsConfig"
+                },
+                "device": {
+                    "$ref": "#/definitions/deviceConfig"
+                },
+                "schema": {
+                    "$ref": "#/definitions/schemaConfig"
+                },
+                "autoEndpointMode": {
+                    "type": "string",
+                    "description": "The type of endpoint created when none is configured.",
+                    "enum": [
+                        "unix",
+                        "tcp"
+                    ]
+                },
+                "tasks": {
+                    "$ref": "#/definitions/tasksConfig"
+                },
+                "webhooks": {
+                    "$ref": "#/definitions/webhooksConfig"
+                },
+                "metrics": {
+                    "$ref": "#/definitions/metricsConfig"
+                },
+                "auth": {
+                    "$ref": "#/definitions/authConfig"
+                },
+                "signing": {
+                    "$ref": "#/definitions/signingConfig"
+                },
+                "rateLimit": {
+                    "$ref": "#/definitions/rateLimitConfig"
+                },
+                "driverCalls": {
+                    "$ref": "#/definitions/driverCallsConfig"
+                },
+                "quotas": {
+                    "$ref": "#/definitions/quotasConfig"
+                },
+                "tenants": {
+                    "$ref": "#/definitions/tenantsConfig"
+                },
+                "audit": {
+                    "$ref": "#/definitions/auditConfig"
+                },
+                "idempotency": {
+                    "$ref": "#/definitions/idempotencyConfig"
+                },
+                "libstorage": {
+                    "$ref": "#/definitions/libstorageConfig"
+                },
+                "docker": {
+                    "$ref": "#/definitions/dockerConfig"
+                },
+                "isilon": {
+                    "$ref": "#/definitions/isilonConfig"
+                },
+                "linux": {
+                    "$ref": "#/definitions/linuxConfig"
+                },
+                "scaleio": {
+                    "$ref": "#/definitions/scaleioConfig"
+                },
+                "vfs": {
+                    "$ref": "#/definitions/vfsConfig"
+                },
+                "virtualbox": {
+                    "$ref": "#/definitions/virtualboxConfig"
+                },
+                "volume": {
+                    "$ref": "#/definitions/volumeConfig"
+                }
+            },
+            "additionalProperties": {
+                "description": "The configuration of a registered driver that is not described by this schema.",
+                "type": "object"
+            },
+            "x-driverSections": true
+        },
+        "endpointConfig": {
+            "title": "Endpoint",
+            "description": "A server endpoint. An endpoint is a configuration scope, so it may override any server, libStorage, or driver property.",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "description": "The address on which the endpoint listens."
+                },
+                "socket": {
+                    "description": "The owner, group, and mode of a UNIX socket endpoint's socket file.",
+                    "type": "object",
+                    "properties": {
+                        "owner": {
+                            "type": "string",
+                            "description": "The name or ID of the socket file's owner."
+                        },
+                        "group": {
+                            "type": "string",
+                            "description": "The name or ID of the socket file's group."
+                        },
+                        "mode": {
+                            "type": "string",
+                            "description": "The octal mode of the socket file."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "middleware": {
+                    "description": "The endpoint's middleware.",
+                    "type": "object",
+                    "properties": {
+                        "disabled": {
+                            "type": [
+                                "string",
+                                "array"
+                            ],
+                            "description": "The names of the middleware the endpoint does not use.",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "routes": {
+                    "description": "The routes the endpoint exposes.",
+                    "type": "object",
+                    "properties": {
+                        "readOnly": {
+                            "type": "boolean",
+                            "description": "A flag indicating whether or not the endpoint exposes only GET and HEAD routes."
+                        },
+                        "allow": {
+                            "type": [
+                                "string",
+                                "array"
+                            ],
+                            "description": "The names of the routes the endpoint exposes.",
+                            "items": {
+                                "type": "string"
+                            }
+                        },
+                        "deny": {
+                            "type": [
+                                "string",
+                                "array"
+                            ],
+                            "description": "The names of the routes the endpoint does not expose.",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "host": {
+                    "type": "string",
+                    "description": "The address of the libStorage server."
+                },
+                "service": {
+                    "type": "string",
+                    "description": "The name of the service the client uses."
+                },
+                "embedded": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the client starts an embedded server."
+                },
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the storage driver used by a service that does not specify one."
+                },
+                "os": {
+                    "$ref": "#/definitions/osConfig"
+                },
+                "storage": {
+                    "$ref": "#/definitions/storageConfig"
+                },
+                "integration": {
+                    "$ref": "#/definitions/integrationConfig"
+                },
+                "logging": {
+                    "$ref": "#/definitions/loggingConfig"
+                },
+                "http": {
+                    "$ref": "#/definitions/httpConfig"
+                },
+                "executor": {
+                    "$ref": "#/definitions/executorConfig"
+                },
+                "tls": {
+                    "$ref": "#/definitions/tlsConfig"
+                },
+                "device": {
+                    "$ref": "#/definitions/deviceConfig"
+                },
+                "schema": {
+                    "$ref": "#/definitions/schemaConfig"
+                },
+                "autoEndpointMode": {
+                    "type": "string",
+                    "description": "The type of endpoint created when none is configured.",
+                    "enum": [
+                        "unix",
+                        "tcp"
+                    ]
+                },
+                "tasks": {
+                    "$ref": "#/definitions/tasksConfig"
+                },
+                "webhooks": {
+                    "$ref": "#/definitions/webhooksConfig"
+                },
+                "metrics": {
+                    "$ref": "#/definitions/metricsConfig"
+                },
+                "auth": {
+                    "$ref": "#/definitions/authConfig"
+                },
+                "signing": {
+                    "$ref": "#/definitions/signingConfig"
+                },
+                "rateLimit": {
+                    "$ref": "#/definitions/rateLimitConfig"
+                },
+                "driverCalls": {
+                    "$ref": "#/definitions/driverCallsConfig"
+                },
+                "quotas": {
+                    "$ref": "#/definitions/quotasConfig"
+                },
+                "tenants": {
+                    "$ref": "#/definitions/tenantsConfig"
+                },
+                "audit": {
+                    "$ref": "#/definitions/auditConfig"
+                },
+                "idempotency": {
+                    "$ref": "#/definitions/idempotencyConfig"
+                },
+                "libstorage": {
+                    "$ref": "#/definitions/libstorageConfig"
+                },
+                "docker": {
+                    "$ref": "#/definitions/dockerConfig"
+                },
+                "isilon": {
+                    "$ref": "#/definitions/isilonConfig"
+                },
+                "linux": {
+                    "$ref": "#/definitions/linuxConfig"
+                },
+                "scaleio": {
+                    "$ref": "#/definitions/scaleioConfig"
+                },
+                "vfs": {
+                    "$ref": "#/definitions/vfsConfig"
+                },
+                "virtualbox": {
+                    "$ref": "#/definitions/virtualboxConfig"
+                },
+                "volume": {
+                    "$ref": "#/definitions/volumeConfig"
+                }
+            },
+            "additionalProperties": {
+                "description": "The configuration of a registered driver that is not described by this schema.",
+                "type": "object"
+            },
+            "x-driverSections": true,
+            "required": [
+                "address"
+            ]
+        },
+        "clientConfig": {
+            "title": "Client",
+            "description": "The libStorage client's configuration. The client is a configuration scope, so it may override any libStorage or driver property.",
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "description": "The client type.",
+                    "enum": [
+                        "integration",
+                        "controller"
+                    ]
+                },
+                "cache": {
+                    "description": "The client's caches.",
+                    "type": "object",
+                    "properties": {
+                        "instanceID": {
+                            "type": "string",
+                            "description": "How long an instance ID is cached."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "tenant": {
+                    "type": "string",
+                    "description": "The tenant on whose behalf requests are made."
+                },
+                "retries": {
+                    "type": "integer",
+                    "description": "The number of times a request is retried.",
+                    "minimum": 0
+                },
+                "retryMaxWait": {
+                    "type": "string",
+                    "description": "The maximum amount of time before a request is retried."
+                },
+                "host": {
+                    "type": "string",
+                    "description": "The address of the libStorage server."
+                },
+                "service": {
+                    "type": "string",
+                    "description": "The name of the service the client uses."
+                },
+                "embedded": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the client starts an embedded server."
+                },
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the storage driver used by a service that does not specify one."
+                },
+                "os": {
+                    "$ref": "#/definitions/osConfig"
+                },
+                "storage": {
+                    "$ref": "#/definitions/storageConfig"
+                },
+                "integration": {
+                    "$ref": "#/definitions/integrationConfig"
+                },
+                "logging": {
+                    "$ref": "#/definitions/loggingConfig"
+                },
+                "http": {
+                    "$ref": "#/definitions/httpConfig"
+                },
+                "executor": {
+                    "$ref": "#/definitions/executorConfig"
+                },
+                "tls": {
+                    "$ref": "#/definitions/tlsConfig"
+                },
+                "device": {
+                    "$ref": "#/definitions/deviceConfig"
+                },
+                "schema": {
+                    "$ref": "#/definitions/schemaConfig"
+                },
+                "auth": {
+                    "$ref": "#/definitions/authConfig"
+                },
+                "signing": {
+                    "$ref": "#/definitions/signingConfig"
+                },
+                "libstorage": {
+                    "$ref": "#/definitions/libstorageConfig"
+                },
+                "docker": {
+                    "$ref": "#/definitions/dockerConfig"
+                },
+                "isilon": {
+                    "$ref": "#/definitions/isilonConfig"
+                },
+                "linux": {
+                    "$ref": "#/definitions/linuxConfig"
+                },
+                "scaleio": {
+                    "$ref": "#/definitions/scaleioConfig"
+                },
+                "vfs": {
+                    "$ref": "#/definitions/vfsConfig"
+                },
+                "virtualbox": {
+                    "$ref": "#/definitions/virtualboxConfig"
+                },
+                "volume": {
+                    "$ref": "#/definitions/volumeConfig"
+                }
+            },
+            "additionalProperties": {
+                "description": "The configuration of a registered driver that is not described by this schema.",
+                "type": "object"
+            },
+            "x-driverSections": true
+        },
+        "osConfig": {
+            "description": "The OS driver configuration.",
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the OS driver."
+                }
+            },
+            "additionalProperties": false
+        },
+        "storageConfig": {
+            "description": "The storage driver configuration.",
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the storage driver."
+                }
+            },
+            "additionalProperties": false
+        },
+        "integrationConfig": {
+            "description": "The integration driver configuration.",
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string",
+                    "description": "The name of the integration driver."
+                },
+                "volume": {
+                    "description": "The integration driver's volume configuration.",
+                    "type": "object",
+                    "properties": {
+                        "operations": {
+                            "description": "The volume operations.",
+                            "type": "object",
+                            "properties": {
+                                "mount": {
+                                    "description": "The mount operation.",
+                                    "type": "object",
+                                    "properties": {
+                                        "preempt": {
+                                            "type": "boolean",
+                                            "description": "A flag indicating whether or not a volume attached to another instance is detached before it is mounted."
+                                        },
+                                        "path": {
+                                            "type": "string",
+                                            "description": "The path beneath which volumes are mounted."
+                                        },
+                                        "rootPath": {
+                                            "type": "string",
+                                            "description": "The path within a volume that is mounted."
+                                        }
+                                    },
+                                    "additionalProperties": false
+                                },
+                                "unmount": {
+                                    "description": "The unmount operation.",
+                                    "type": "object",
+                                    "properties": {
+                                        "ignoreusedcount": {
+                                            "type": "boolean",
+                                            "description": "A flag indicating whether or not a volume is unmounted regardless of its use count."
+                                        }
+                                    },
+                                    "additionalProperties": false
+                                },
+                                "path": {
+                                    "description": "The path operation.",
+                                    "type": "object",
+                                    "properties": {
+                                        "cache": {
+                                            "description": "The volume path cache.",
+                                            "type": "object",
+                                            "properties": {
+                                                "enabled": {
+                                                    "type": "boolean",
+                                                    "description": "A flag indicating whether or not the volume paths are cached."
+                                                },
+                                                "async": {
+                                                    "type": "boolean",
+                                                    "description": "A flag indicating whether or not the cache is initialized asynchronously."
+                                                }
+                                            },
+                                            "additionalProperties": false
+                                        }
+                                    },
+                                    "additionalProperties": false
+                                },
+                                "create": {
+                                    "description": "The create operation.",
+                                    "type": "object",
+                                    "properties": {
+                                        "disable": {
+                                            "type": "boolean",
+                                            "description": "A flag indicating whether or not volumes may not be created."
+                                        },
+                                        "implicit": {
+                                            "type": "boolean",
+                                            "description": "A flag indicating whether or not volumes are created when they are mounted."
+                                        },
+                                        "default": {
+                                            "description": "The defaults of created volumes.",
+                                            "type": "object",
+                                            "properties": {
+                                                "size": {
+                                                    "type": "string",
+                                                    "description": "The default size, in GB, of a volume."
+                                                },
+                                                "fsType": {
+                                                    "type": "string",
+                                                    "description": "The default file system type of a volume."
+                                                },
+                                                "availabilityZone": {
+                                                    "type": "string",
+                                                    "description": "The default availability zone of a volume."
+                                                },
+                                                "type": {
+                                                    "type": "string",
+                                                    "description": "The default type of a volume."
+                                                },
+                                                "IOPS": {
+                                                    "type": "string",
+                                                    "description": "The default IOPS of a volume."
+                                                }
+                                            },
+                                            "additionalProperties": false
+                                        }
+                                    },
+                                    "additionalProperties": false
+                                },
+                                "remove": {
+                                    "description": "The remove operation.",
+                                    "type": "object",
+                                    "properties": {
+                                        "disable": {
+                                            "type": "boolean",
+                                            "description": "A flag indicating whether or not volumes may not be removed."
+                                        }
+                                    },
+                                    "additionalProperties": false
+                                }
+                            },
+                            "additionalProperties": false
+                        }
+                    },
+                    "additionalProperties": false
+                }
+            },
+            "additionalProperties": false
+        },
+        "loggingConfig": {
+            "description": "The logging configuration.",
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "description": "The log level.",
+                    "enum": [
+                        "panic",
+                        "fatal",
+                        "error",
+                        "warn",
+                        "warning",
+                        "info",
+                        "debug"
+                    ]
+                },
+                "stdout": {
+                    "type": "string",
+                    "description": "The file to which to log os.Stdout."
+                },
+                "stderr": {
+                    "type": "string",
+                    "description": "The file to which to log os.Stderr."
+                },
+                "httpRequests": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not HTTP requests are logged."
+                },
+                "httpResponses": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not HTTP responses are logged."
+                }
+            },
+            "additionalProperties": false
+        },
+        "httpConfig": {
+            "description": "The HTTP configuration.",
+            "type": "object",
+            "properties": {
+                "disableKeepAlive": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not HTTP keep-alive is disabled."
+                },
+                "writeTimeout": {
+                    "type": "integer",
+                    "description": "The HTTP write timeout in seconds.",
+                    "minimum": 0
+                },
+                "readTimeout": {
+                    "type": "integer",
+                    "description": "The HTTP read timeout in seconds.",
+                    "minimum": 0
+                }
+            },
+            "additionalProperties": false
+        },
+        "executorConfig": {
+            "description": "The executor configuration.",
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string",
+                    "description": "The path of the executor."
+                },
+                "disableDownload": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the executor is not downloaded from the server."
+                }
+            },
+            "additionalProperties": false
+        },
+        "tlsConfig": {
+            "description": "The TLS configuration.",
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not TLS is disabled."
+                },
+                "serverName": {
+                    "type": "string",
+                    "description": "The expected name of the server."
+                },
+                "clientCertRequired": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not clients must present certificates."
+                },
+                "trustedCertsFile": {
+                    "type": "string",
+                    "description": "The path of the trusted certificates."
+                },
+                "certFile": {
+                    "type": "string",
+                    "description": "The path of the certificate."
+                },
+                "keyFile": {
+                    "type": "string",
+                    "description": "The path of the private key."
+                }
+            },
+            "additionalProperties": false
+        },
+        "deviceConfig": {
+            "description": "The device configuration.",
+            "type": "object",
+            "properties": {
+                "attachTimeout": {
+                    "type": "string",
+                    "description": "How long to wait for an attached device to appear."
+                },
+                "scanType": {
+                    "type": "integer",
+                    "description": "The device scan type.",
+                    "minimum": 0
+                }
+            },
+            "additionalProperties": false
+        },
+        "schemaConfig": {
+            "description": "The JSON schema configuration.",
+            "type": "object",
+            "properties": {
+                "responseValidationEnabled": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the server's responses are validated."
+                }
+            },
+            "additionalProperties": false
+        },
+        "tasksConfig": {
+            "description": "The tasks configuration.",
+            "type": "object",
+            "properties": {
+                "exeTimeout": {
+                    "type": "string",
+                    "description": "How long a request waits for its task to complete."
+                },
+                "logTimeout": {
+                    "type": "string",
+                    "description": "How long a completed task is retained."
+                },
+                "queue": {
+                    "description": "The task queues.",
+                    "type": "object",
+                    "properties": {
+                        "workers": {
+                            "type": "integer",
+                            "description": "The number of tasks a service executes concurrently. Zero is unbounded.",
+                            "minimum": 0
+                        },
+                        "depth": {
+                            "type": "integer",
+                            "description": "The maximum number of queued tasks.",
+                            "minimum": 0
+                        },
+                        "prioritize": {
+                            "type": "boolean",
+                            "description": "A flag indicating whether or not detach operations are executed first."
+                        },
+                        "retryAfter": {
+                            "type": "string",
+                            "description": "The value of the Retry-After header returned when a queue is full."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "store": {
+                    "description": "The task store.",
+                    "type": "object",
+                    "properties": {
+                        "type": {
+                            "type": "string",
+                            "description": "The task store type.",
+                            "enum": [
+                                "mem",
+                                "file"
+                            ]
+                        },
+                        "path": {
+                            "type": "string",
+                            "description": "The file store's directory."
+                        },
+                        "retention": {
+                            "type": "string",
+                            "description": "How long the file store keeps completed tasks."
+                        }
+                    },
+                    "additionalProperties": false
+                }
+            },
+            "additionalProperties": false
+        },
+        "webhooksConfig": {
+            "description": "The webhooks configuration.",
+            "type": "object",
+            "properties": {
+                "hooks": {
+                    "description": "The webhooks to which events are delivered.",
+                    "type": "array",
+                    "items": {
+                        "description": "The URL of a webhook or a webhook.",
+                        "type": [
+                            "string",
+                            "object"
+                        ],
+                        "properties": {
+                            "url": {
+                                "type": "string",
+                                "description": "The URL to which events are POSTed."
+                            },
+                            "events": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The types of events delivered to the hook.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "services": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The services whose events are delivered to the hook.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "secret": {
+                                "type": "string",
+                                "description": "The key with which the hook's payloads are signed."
+                            }
+                        },
+                        "additionalProperties": false
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "description": "The key with which the payloads are signed."
+                },
+                "retries": {
+                    "type": "integer",
+                    "description": "The number of times a failed delivery is retried.",
+                    "minimum": 0
+                },
+                "backoff": {
+                    "type": "string",
+                    "description": "The amount of time before the first retry."
+                },
+                "timeout": {
+                    "type": "string",
+                    "description": "The amount of time after which a delivery attempt fails."
+                },
+                "deadLetterLog": {
+                    "type": "string",
+                    "description": "The path of the dead-letter log."
+                }
+            },
+            "additionalProperties": false
+        },
+        "metricsConfig": {
+            "description": "The metrics configuration.",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "description": "The token required to scrape the metrics."
+                },
+                "anonymous": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the metrics may be scraped anonymously."
+                }
+            },
+            "additionalProperties": false
+        },
+        "authConfig": {
+            "description": "The authentication configuration.",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "description": "The client's token."
+                },
+                "tokens": {
+                    "description": "The principals' static tokens, by principal name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "principals": {
+                    "description": "The rules that authorize each principal, by principal name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": [
+                            "object",
+                            "array"
+                        ],
+                        "properties": {
+                            "services": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The services to which the rule's verbs apply.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "verbs": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The verbs the principal may perform on the services.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "additionalProperties": false,
+                        "items": {
+                            "description": "A rule.",
+                            "type": "object",
+                            "properties": {
+                                "services": {
+                                    "type": [
+                                        "string",
+                                        "array"
+                                    ],
+                                    "description": "The services to which the rule's verbs apply.",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                "verbs": {
+                                    "type": [
+                                        "string",
+                                        "array"
+                                    ],
+                                    "description": "The verbs the principal may perform on the services.",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                }
+                            },
+                            "additionalProperties": false
+                        }
+                    }
+                },
+                "anonymousRoutes": {
+                    "type": [
+                        "string",
+                        "array"
+                    ],
+                    "description": "The names of the routes that do not require authentication.",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "certificates": {
+                    "description": "The rules that map client certificates' identities to principals.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "description": "A certificate rule.",
+                        "type": "object",
+                        "properties": {
+                            "principal": {
+                                "type": "string",
+                                "description": "The name of the principal."
+                            },
+                            "instanceID": {
+                                "type": "string",
+                                "description": "The ID of the only instance on whose behalf the certificate may be used."
+                            },
+                            "services": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The services to which the rule's verbs apply.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "verbs": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The verbs the principal may perform on the services.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "additionalProperties": false
+                    }
+                },
+                "peers": {
+                    "description": "The rules that map UNIX socket peers to principals.",
+                    "type": "array",
+                    "items": {
+                        "description": "A peer rule.",
+                        "type": "object",
+                        "properties": {
+                            "principal": {
+                                "type": "string",
+                                "description": "The name of the principal."
+                            },
+                            "users": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The names or IDs of the users whose processes the rule matches.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "groups": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The names or IDs of the groups whose processes the rule matches.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "services": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The services to which the rule's verbs apply.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "verbs": {
+                                "type": [
+                                    "string",
+                                    "array"
+                                ],
+                                "description": "The verbs the principal may perform on the services.",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "additionalProperties": false
+                    }
+                },
+                "jwt": {
+                    "description": "The JWT configuration.",
+                    "type": "object",
+                    "properties": {
+                        "key": {
+                            "type": "string",
+                            "description": "The secret with which HS256 JWTs are verified."
+                        },
+                        "publicKey": {
+                            "type": "string",
+                            "description": "The RSA public key, or the path to one, with which RS256 JWTs are verified."
+                        },
+                        "issuer": {
+                            "type": "string",
+                            "description": "The required value of a JWT's iss claim."
+                        },
+                        "audience": {
+                            "type": "string",
+                            "description": "The value a JWT's aud claim must contain."
+                        },
+                        "principalClaim": {
+                            "type": "string",
+                            "description": "The JWT claim whose value is the principal's name."
+                        }
+                    },
+                    "additionalProperties": false
+                }
+            },
+            "additionalProperties": false
+        },
+        "signingConfig": {
+            "description": "The header signing configuration.",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the headers must be signed."
+                },
+                "key": {
+                    "type": "string",
+                    "description": "The secret with which hmac-sha256 signatures are signed or verified."
+                },
+                "publicKey": {
+                    "type": "string",
+                    "description": "The RSA public key, or the path to one, with which rsa-sha256 signatures are verified."
+                },
+                "privateKey": {
+                    "type": "string",
+                    "description": "The RSA private key, or the path to one, with which the client signs the headers."
+                },
+                "instances": {
+                    "description": "The instances' secrets or keys, by instance ID.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": [
+                            "string",
+                            "object"
+                        ],
+                        "properties": {
+                            "key": {
+                                "type": "string",
+                                "description": "The instance's secret."
+                            },
+                            "publicKey": {
+                                "type": "string",
+                                "description": "The instance's RSA public key, or the path to one."
+                            }
+                        },
+                        "additionalProperties": false
+                    }
+                },
+                "maxAge": {
+                    "type": "string",
+                    "description": "The maximum age of a signature."
+                }
+            },
+            "additionalProperties": false
+        },
+        "rateLimitConfig": {
+            "description": "The rate limit configuration.",
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "description": "The property of a request by which it is limited."
+                },
+                "read": {
+                    "description": "The limit of the requests that read resources.",
+                    "type": "object",
+                    "properties": {
+                        "rate": {
+                            "type": "string",
+                            "description": "The number of requests per second."
+                        },
+                        "burst": {
+                            "type": "integer",
+                            "description": "The maximum number of requests in a burst.",
+                            "minimum": 0
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "mutate": {
+                    "description": "The limit of the requests that mutate resources.",
+                    "type": "object",
+                    "properties": {
+                        "rate": {
+                            "type": "string",
+                            "description": "The number of requests per second."
+                        },
+                        "burst": {
+                            "type": "integer",
+                            "description": "The maximum number of requests in a burst.",
+                            "minimum": 0
+                        }
+                    },
+                    "additionalProperties": false
+                }
+            },
+            "additionalProperties": false
+        },
+        "driverCallsConfig": {
+            "description": "The storage driver calls configuration.",
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "integer",
+                    "description": "The maximum number of concurrent calls to a service's driver.",
+                    "minimum": 0
+                },
+                "maxWait": {
+                    "type": "string",
+                    "description": "How long a call waits for its turn."
+                }
+            },
+            "additionalProperties": false
+        },
+        "quotaLimits": {
+            "title": "Quota Limits",
+            "description": "A quota's limits.",
+            "type": "object",
+            "properties": {
+                "volumes": {
+                    "type": "integer",
+                    "description": "The maximum number of volumes.",
+                    "minimum": 0
+                },
+                "size": {
+                    "type": "integer",
+                    "description": "The maximum total size, in GB, of the volumes.",
+                    "minimum": 0
+                },
+                "volumeSize": {
+                    "type": "integer",
+                    "description": "The maximum size, in GB, of a single volume.",
+                    "minimum": 0
+                },
+                "snapshots": {
+                    "type": "integer",
+                    "description": "The maximum number of snapshots.",
+                    "minimum": 0
+                }
+            },
+            "additionalProperties": false
+        },
+        "quotasConfig": {
+            "description": "The quotas configuration.",
+            "type": "object",
+            "properties": {
+                "cacheTTL": {
+                    "type": "string",
+                    "description": "How long the usage of a quota is cached."
+                },
+                "service": {
+                    "description": "The quota of a service's resources.",
+                    "$ref": "#/definitions/quotaLimits"
+                },
+                "tenants": {
+                    "description": "The quotas of the tenants' resources, by tenant name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/quotaLimits"
+                    }
+                },
+                "instances": {
+                    "description": "The quotas of the instances' resources, by instance ID.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/quotaLimits"
+                    }
+                }
+            },
+            "additionalProperties": false
+        },
+        "tenantsConfig": {
+            "description": "The tenant namespaces configuration.",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the volume names are separated by tenant."
+                },
+                "separator": {
+                    "type": "string",
+                    "description": "The separator between a tenant's name and a volume's name."
+                },
+                "admins": {
+                    "type": [
+                        "string",
+                        "array"
+                    ],
+                    "description": "The principals that may act on behalf of any tenant.",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            },
+            "additionalProperties": false
+        },
+        "auditConfig": {
+            "description": "The audit log configuration.",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the audit log is enabled."
+                },
+                "file": {
+                    "type": "string",
+                    "description": "The path of the audit log."
+                },
+                "maxSize": {
+                    "type": "integer",
+                    "description": "The maximum size, in MB, of the audit log before it is rotated.",
+                    "minimum": 0
+                },
+                "maxFiles": {
+                    "type": "integer",
+                    "description": "The number of rotated audit logs that are kept.",
+                    "minimum": 0
+                }
+            },
+            "additionalProperties": false
+        },
+        "idempotencyConfig": {
+            "description": "The idempotency keys configuration.",
+            "type": "object",
+            "properties": {
+                "window": {
+                    "type": "string",
+                    "description": "How long an idempotency key is remembered."
+                }
+            },
+            "additionalProperties": false
+        },
+        "dockerConfig": {
+            "title": "Docker",
+            "description": "The deprecated Docker integration driver properties.",
+            "type": "object",
+            "properties": {
+                "fsType": {
+                    "type": "string",
+                    "description": "Deprecated. Use libstorage.integration.volume.operations.create.default.fsType."
+                },
+                "volumeType": {
+                    "type": "string",
+                    "description": "Deprecated. Use libstorage.integration.volume.operations.create.default.type."
+                },
+                "iops": {
+                    "type": "string",
+                    "description": "Deprecated. Use libstorage.integration.volume.operations.create.default.IOPS."
+                },
+                "size": {
+                    "type": "string",
+                    "description": "Deprecated. Use libstorage.integration.volume.operations.create.default.size."
+                },
+                "availabilityZone": {
+                    "type": "string",
+                    "description": "Deprecated. Use libstorage.integration.volume.operations.create.default.availabilityZone."
+                },
+                "mountDirPath": {
+                    "type": "string",
+                    "description": "Deprecated. Use libstorage.integration.volume.operations.mount.path."
+                }
+            },
+            "additionalProperties": false
+        },
+        "volumeConfig": {
+            "title": "Volume",
+            "description": "The deprecated volume operations properties.",
+            "type": "object",
+            "properties": {
+                "mount": {
+                    "description": "The mount operation.",
+                    "type": "object",
+                    "properties": {
+                        "preempt": {
+                            "type": "boolean",
+                            "description": "Deprecated. Use libstorage.integration.volume.operations.mount.preempt."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "create": {
+                    "description": "The create operation.",
+                    "type": "object",
+                    "properties": {
+                        "disable": {
+                            "type": "boolean",
+                            "description": "Deprecated. Use libstorage.integration.volume.operations.create.disable."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "remove": {
+                    "description": "The remove operation.",
+                    "type": "object",
+                    "properties": {
+                        "disable": {
+                            "type": "boolean",
+                            "description": "Deprecated. Use libstorage.integration.volume.operations.remove.disable."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "unmount": {
+                    "description": "The unmount operation.",
+                    "type": "object",
+                    "properties": {
+                        "ignoreusedcount": {
+                            "type": "boolean",
+                            "description": "Deprecated. Use libstorage.integration.volume.operations.unmount.ignoreusedcount."
+                        }
+                    },
+                    "additionalProperties": false
+                },
+                "path": {
+                    "description": "The path operation.",
+                    "type": "object",
+                    "properties": {
+                        "cache": {
+                            "type": "boolean",
+                            "description": "Deprecated. Use libstorage.integration.volume.operations.path.cache.enabled."
+                        }
+                    },
+                    "additionalProperties": false
+                }
+            },
+            "additionalProperties": false
+        },
+        "linuxConfig": {
+            "title": "Linux",
+            "description": "The Linux OS driver configuration.",
+            "type": "object",
+            "properties": {
+                "volume": {
+                    "description": "The volume properties.",
+                    "type": "object",
+                    "properties": {
+                        "filemode": {
+                            "type": "integer",
+                            "description": "The mode of the directories in which volumes are mounted.",
+                            "minimum": 0
+                        },
+                        "rootpath": {
+                            "type": "string",
+                            "description": "The path within a volume that is mounted."
+                        }
+                    },
+                    "additionalProperties": false
+                }
+            },
+            "additionalProperties": false
+        },
+        "isilonConfig": {
+            "title": "Isilon",
+            "description": "The Isilon storage driver configuration.",
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "type": "string",
+                    "description": "The Isilon API endpoint."
+                },
+                "insecure": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the endpoint's certificate is not verified."
+                },
+                "userName": {
+                    "type": "string",
+                    "description": "The name of the Isilon user."
+                },
+                "group": {
+                    "type": "string",
+                    "description": "The Isilon user's group."
+                },
+                "password": {
+                    "type": "string",
+                    "description": "The Isilon user's password."
+                },
+                "volumePath": {
+                    "type": "string",
+                    "description": "The path beneath which volumes are created."
+                },
+                "nfsHost": {
+                    "type": "string",
+                    "description": "The host name or address of the NFS server."
+                },
+                "dataSubnet": {
+                    "type": "string",
+                    "description": "The subnet of the clients to which the volumes are exported."
+                },
+                "quotas": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the volumes' sizes are enforced with quotas."
+                },
+                "sharedMounts": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not a volume may be attached to many clients."
+                }
+            },
+            "additionalProperties": false
+        },
+        "scaleioConfig": {
+            "title": "ScaleIO",
+            "description": "The ScaleIO storage driver configuration.",
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "type": "string",
+                    "description": "The ScaleIO gateway endpoint."
+                },
+                "insecure": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the gateway's certificate is not verified."
+                },
+                "useCerts": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the system's certificates are used."
+                },
+                "userID": {
+                    "type": "string",
+                    "description": "The ID of the ScaleIO user."
+                },
+                "userName": {
+                    "type": "string",
+                    "description": "The name of the ScaleIO user."
+                },
+                "password": {
+                    "type": "string",
+                    "description": "The ScaleIO user's password."
+                },
+                "systemID": {
+                    "type": "string",
+                    "description": "The ID of the ScaleIO system."
+                },
+                "systemName": {
+                    "type": "string",
+                    "description": "The name of the ScaleIO system."
+                },
+                "protectionDomainID": {
+                    "type": "string",
+                    "description": "The ID of the protection domain."
+                },
+                "protectionDomainName": {
+                    "type": "string",
+                    "description": "The name of the protection domain."
+                },
+                "storagePoolID": {
+                    "type": "string",
+                    "description": "The ID of the storage pool."
+                },
+                "storagePoolName": {
+                    "type": "string",
+                    "description": "The name of the storage pool."
+                },
+                "thinOrThick": {
+                    "type": "string",
+                    "description": "The provisioning type of the volumes, ThinProvisioned or ThickProvisioned."
+                },
+                "version": {
+                    "type": "string",
+                    "description": "The version of the ScaleIO API."
+                }
+            },
+            "additionalProperties": false
+        },
+        "vfsConfig": {
+            "title": "VFS",
+            "description": "The VFS storage driver configuration.",
+            "type": "object",
+            "properties": {
+                "root": {
+                    "type": "string",
+                    "description": "The directory in which the volumes and snapshots are stored."
+                }
+            },
+            "additionalProperties": false
+        },
+        "virtualboxConfig": {
+            "title": "VirtualBox",
+            "description": "The VirtualBox storage driver configuration.",
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "type": "string",
+                    "description": "The VirtualBox web service endpoint."
+                },
+                "username": {
+                    "type": "string",
+                    "description": "The name of the VirtualBox user."
+                },
+                "password": {
+                    "type": "string",
+                    "description": "The VirtualBox user's password."
+                },
+                "tls": {
+                    "type": "boolean",
+                    "description": "A flag indicating whether or not the endpoint uses TLS."
+                },
+                "volumePath": {
+                    "type": "string",
+                    "description": "The path in which the volumes are created."
+                },
+                "localMachineNameOrId": {
+                    "type": "string",
+                    "description": "The name or ID of the local virtual machine."
+                },
+                "controllerName": {
+                    "type": "string",
+                    "description": "The name of the storage controller to which volumes are attached."
+                },
+                "diskIDPath": {
+                    "type": "string",
+                    "description": "The path of the disks' IDs."
+                },
+                "scsiHostPath": {
+                    "type": "string",
+                    "description": "The path of the SCSI hosts."
+                }
+            },
+            "additionalProperties": false
+        }
+    }
+}
+`
+)
@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var testConfigValidator = &ConfigValidator{
+	StorageDrivers: []string{"vfs", "virtualbox", "scaleio"},
+	Drivers:        []string{"vfs", "virtualbox", "scaleio", "linux", "ebs"},
+}
+
+func validateTestConfig(t *testing.T, config string) error {
+	var d interface{}
+	if !assert.NoError(t, yaml.Unmarshal([]byte(config), &d)) {
+		t.FailNow()
+	}
+	return testConfigValidator.Validate(d)
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, validateTestConfig(t, `
+libstorage:
+  logging:
+    level: warn
+  integration:
+    volume:
+      operations:
+        create:
+          default:
+            size: 1 # GB
+  server:
+    logging:
+      level: info
+    virtualbox:
+      endpoint: http://10.0.2.2:18083
+      tls: false
+    endpoints:
+      localhost:
+        address: tcp://:7979
+        tls:
+          certFile: /etc/libstorage/libstorage-server.crt
+          keyFile: /etc/libstorage/libstorage-server.key
+        routes:
+          readOnly: true
+          allow: volume*, service*
+        libstorage:
+          server:
+            auth:
+              tokens:
+                ci: citoken
+    auth:
+      principals:
+        alice:
+          services: ebs
+          verbs: read, attach
+        ci:
+        - services: "*"
+          verbs: read
+      certificates:
+        host1.example.com:
+          principal: host1
+        "*":
+    webhooks:
+      hooks:
+      - https://automation.example.com/libstorage
+      - url: https://audit.example.com/hooks/storage
+        events:
+        - volumeCreate
+    services:
+      virtualbox-00:
+        driver: virtualbox
+        virtualbox:
+          volumePath: $HOME/VirtualBox/Volumes-00
+        quotas:
+          service:
+            volumes: 100
+        integration:
+          volume:
+            operations:
+              create:
+                default:
+                  size: 1
+      ebs:
+        driver: VFS
+        signing:
+          key: myebssecret
+        ebs:
+          region: us-east-1
+      s3:
+        libstorage:
+          server:
+            tasks:
+              queue:
+                workers: "2"
+                prioritize: true
+virtualbox:
+  controllerName: SATA
+  password: file:/run/secrets/vbox-pass
+scaleio:
+  insecure: env:SIO_INSECURE
+  password: env:SIO_PASS
+ebs:
+  accessKey: key
+rexray:
+  logLevel: warn
+`))
+}
+
+func TestValidateConfigErrors(t *testing.T) {
+	err := validateTestConfig(t, `
+libstorage:
+  logging:
+    level: loud
+  server:
+    tasks:
+      queue:
+        workers: many
+        depth: -1
+    endpoints:
+      localhost:
+        tls:
+          keyfile: /etc/libstorage/libstorage-server.key
+    services:
+      vfs:
+        driver: vsf
+        vfs:
+          rot: /tmp/vfs
+      virtualbox:
+        driver: virtualbox
+        virtualbox: http://10.0.2.2:18083
+      scaleio:
+        scalio:
+          password: password
+    sevrices:
+      vfs:
+        driver: vfs
+scaleio:
+  insecure: maybe
+`)
+	errs, ok := err.(ConfigErrors)
+	if !assert.True(t, ok, "%v", err) {
+		t.FailNow()
+	}
+
+	paths := map[string]string{}
+	for _, e := range errs {
+		paths[e.Path] = e.Message
+	}
+	assert.Equal(t, map[string]string{
+		"libstorage.logging.level": `invalid value "loud", expected one ` +
+			`of panic, fatal, error, warn, warning, info, debug`,
+		"libstorage.server.tasks.queue.workers": "invalid type, " +
+			"expected integer",
+		"libstorage.server.tasks.queue.depth": "invalid value -1, " +
+			"minimum is 0",
+		"libstorage.server.endpoints.localhost.address": "missing " +
+			"required property",
+		"libstorage.server.services.vfs.driver": `unknown storage ` +
+			`driver "vsf"`,
+		"libstorage.server.services.vfs.vfs.rot": "unknown property",
+		"libstorage.server.services.virtualbox.virtualbox": "invalid " +
+			"type, expected object",
+		"libstorage.server.services.scaleio.scalio": "unknown property",
+		"libstorage.server.sevrices":                "unknown property",
+		"scaleio.insecure":                          "invalid type, expected boolean",
+	}, paths)
+}
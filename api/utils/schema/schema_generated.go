@@ -194,6 +194,10 @@ const (
                     "type": "string",
                     "description": "The name of the user that created the task."
                 },
+                "service": {
+                    "type": "string",
+                    "description": "The name of the service on which the task operates."
+                },
                 "completeTime": {
                     "type": "number",
                     "description": "The time stamp (epoch) when the task was completed."
@@ -229,13 +233,47 @@ const (
                     "description": "Name is the service's name."
                 },
                 "instance": { "$ref": "#/definitions/instance" },
-                "driver": { "$ref": "#/definitions/driverInfo" }
+                "driver": { "$ref": "#/definitions/driverInfo" },
+                "taskQueue": { "$ref": "#/definitions/taskQueueInfo" }
             },
             "required": [ "name", "driver" ],
             "additionalProperties": false
         },
 
 
+        "taskQueueInfo": {
+            "type": "object",
+            "properties": {
+                "workers": {
+                    "type": "number",
+                    "description": "Workers is the number of tasks the service executes concurrently. A value of zero indicates that the number is not limited."
+                },
+                "depth": {
+                    "type": "number",
+                    "description": "Depth is the maximum number of queued tasks. A value of zero indicates the queue is unbounded."
+                },
+                "queued": {
+                    "type": "number",
+                    "description": "Queued is the number of tasks waiting to be executed."
+                },
+                "running": {
+                    "type": "number",
+                    "description": "Running is the number of tasks being executed."
+                },
+                "avgWaitTime": {
+                    "type": "number",
+                    "description": "AvgWaitTime is the average amount of time, in milliseconds, that recent tasks waited in the queue before they were executed."
+                },
+                "oldestWaitTime": {
+                    "type": "number",
+                    "description": "OldestWaitTime is the amount of time, in milliseconds, that the oldest queued task has been waiting."
+                }
+            },
+            "required": [ "workers", "depth", "queued", "running", "avgWaitTime", "oldestWaitTime" ],
+            "additionalProperties": false
+        },
+
+
         "driverInfo": {
             "type": "object",
             "properties": {
@@ -247,13 +285,55 @@ const (
                     "type": "string",
                     "description": "Type is the type of storage the driver provides: block, nas, object."
                 },
-                "nextDevice": { "$ref": "#/definitions/nextDeviceInfo" }
+                "nextDevice": { "$ref": "#/definitions/nextDeviceInfo" },
+                "capabilities": { "$ref": "#/definitions/storageCapabilities" }
             },
             "required": [ "name", "type" ],
             "additionalProperties": false
         },
 
 
+        "storageCapabilities": {
+            "type": "object",
+            "properties": {
+                "snapshots": {
+                    "type": "boolean",
+                    "description": "Snapshots indicates whether the driver can snapshot volumes as well as list, inspect, and remove snapshots."
+                },
+                "volumeCopy": {
+                    "type": "boolean",
+                    "description": "VolumeCopy indicates whether the driver can copy volumes."
+                },
+                "snapshotCopy": {
+                    "type": "boolean",
+                    "description": "SnapshotCopy indicates whether the driver can copy snapshots."
+                },
+                "createFromSnapshot": {
+                    "type": "boolean",
+                    "description": "CreateFromSnapshot indicates whether the driver can create volumes from snapshots."
+                },
+                "resize": {
+                    "type": "boolean",
+                    "description": "Resize indicates whether the driver can resize volumes."
+                },
+                "multiAttach": {
+                    "type": "boolean",
+                    "description": "MultiAttach indicates whether a volume may be attached to more than one instance at a time."
+                },
+                "type": {
+                    "type": "string",
+                    "description": "Type is the type of storage the driver provides: block, nas, object."
+                },
+                "maxVolumeSize": {
+                    "type": "number",
+                    "description": "MaxVolumeSize is the maximum size, in GB, of a volume. The size is not limited by the driver if the value is omitted."
+                }
+            },
+            "required": [ "snapshots", "volumeCopy", "snapshotCopy", "createFromSnapshot", "resize", "multiAttach", "type" ],
+            "additionalProperties": false
+        },
+
+
         "executorInfo": {
             "type": "object",
             "properties": {
@@ -448,6 +528,25 @@ const (
         },
 
 
+        "volumeResizeRequest": {
+            "type": "object",
+            "properties": {
+                "size": {
+                    "type": "number",
+                    "minimum": 1,
+                    "description": "The new volume size (GB)."
+                },
+                "force": {
+                    "type": "boolean",
+                    "description": "Resize the volume even if it is attached."
+                },
+                "opts": { "$ref" : "#/definitions/opts" }
+            },
+            "required": [ "size" ],
+            "additionalProperties": false
+        },
+
+
         "volumeSnapshotRequest": {
             "type": "object",
             "properties": {
@@ -536,6 +635,10 @@ const (
                     "minimum": 400,
                     "maximum": 599
                 },
+                "code": {
+                    "type": "string",
+                    "description": "The error's machine-readable code."
+                },
                 "error": {
                     "type": "object",
                     "additionalProperties": true
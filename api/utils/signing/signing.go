@@ -0,0 +1,250 @@
+/*
+Package signing signs and verifies the values of the headers with which a
+client identifies its instance -- the InstanceID and LocalDevices headers --
+so that one client cannot act on behalf of another client's instance.
+
+The signature of a header value is sent in a companion header whose name is
+the header's name suffixed with "-Signature". A signature header's value has
+the format:
+
+	DRIVER=ALGORITHM:TIMESTAMP:SIGNATURE
+
+where DRIVER is the name of the driver of the signed header value, ALGORITHM
+is either hmac-sha256 or rsa-sha256, TIMESTAMP is the Unix time at which the
+value was signed, and SIGNATURE is the base64-encoded signature of the header
+name, header value, and timestamp, separated by newlines.
+*/
+package signing
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/utils"
+)
+
+const (
+	// AlgHMACSHA256 is the algorithm of the signatures made with a secret.
+	AlgHMACSHA256 = "hmac-sha256"
+
+	// AlgRSASHA256 is the algorithm of the signatures made with an RSA key.
+	AlgRSASHA256 = "rsa-sha256"
+)
+
+// Key is a key with which header values are signed or verified. A key is
+// either a secret shared by the client and server or an RSA key pair of
+// which the client has the private key and the server the public key.
+type Key struct {
+	// Secret is the shared secret.
+	Secret []byte
+
+	// PrivateKey is the private key with which a client signs values.
+	PrivateKey *rsa.PrivateKey
+
+	// PublicKey is the public key with which a server verifies values.
+	PublicKey *rsa.PublicKey
+}
+
+// ParseKey returns a key from a secret and/or a private or public key that
+// is either PEM-encoded or the path to a PEM-encoded file. A nil value is
+// returned if all of the arguments are empty.
+func ParseKey(secret, privateKey, publicKey string) (*Key, error) {
+
+	if secret == "" && privateKey == "" && publicKey == "" {
+		return nil, nil
+	}
+
+	k := &Key{}
+	if secret != "" {
+		k.Secret = []byte(secret)
+	}
+
+	if privateKey != "" {
+		pk, err := utils.ParseRSAPrivateKey(privateKey)
+		if err != nil {
+			return nil, goof.WithError("invalid private key", err)
+		}
+		k.PrivateKey = pk
+		k.PublicKey = &pk.PublicKey
+	}
+
+	if publicKey != "" {
+		pk, err := utils.ParseRSAPublicKey(publicKey)
+		if err != nil {
+			return nil, goof.WithError("invalid public key", err)
+		}
+		k.PublicKey = pk
+	}
+
+	return k, nil
+}
+
+// SignHeader returns the value of the signature header for a value of the
+// header with the provided name. A private key is preferred to a secret.
+func (k *Key) SignHeader(name, value string) (string, error) {
+	return k.sign(name, value, time.Now().Unix())
+}
+
+func (k *Key) sign(name, value string, ts int64) (string, error) {
+
+	payload := signedPayload(name, value, ts)
+
+	var (
+		alg string
+		sig []byte
+	)
+
+	switch {
+	case k.PrivateKey != nil:
+		alg = AlgRSASHA256
+		sum := sha256.Sum256(payload)
+		var err error
+		if sig, err = rsa.SignPKCS1v15(
+			rand.Reader, k.PrivateKey, crypto.SHA256, sum[:]); err != nil {
+			return "", err
+		}
+	case len(k.Secret) > 0:
+		alg = AlgHMACSHA256
+		sig = hmacSum(k.Secret, payload)
+	default:
+		return "", goof.New("signing key has neither secret nor private key")
+	}
+
+	return fmt.Sprintf("%s=%s:%d:%s",
+		headerDriver(value),
+		alg,
+		ts,
+		base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// Signature is a parsed signature header value.
+type Signature struct {
+	Driver    string
+	Algorithm string
+	Time      int64
+	Sig       []byte
+}
+
+// ParseSignature parses a signature header value.
+func ParseSignature(v string) (*Signature, error) {
+
+	i := strings.Index(v, "=")
+	if i < 1 {
+		return nil, goof.WithField("value", v, "invalid signature")
+	}
+
+	parts := strings.SplitN(v[i+1:], ":", 3)
+	if len(parts) != 3 {
+		return nil, goof.WithField("value", v, "invalid signature")
+	}
+
+	ts, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, goof.WithFieldE("value", v, "invalid signature", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, goof.WithFieldE("value", v, "invalid signature", err)
+	}
+
+	return &Signature{
+		Driver:    v[:i],
+		Algorithm: parts[0],
+		Time:      ts,
+		Sig:       sig,
+	}, nil
+}
+
+// FindSignature returns the signature for the driver from the values of a
+// signature header. A nil value is returned if there is no such signature.
+func FindSignature(values []string, driver string) (*Signature, error) {
+	for _, v := range values {
+		sig, err := ParseSignature(v)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(sig.Driver, driver) {
+			return sig, nil
+		}
+	}
+	return nil, nil
+}
+
+// Verify returns an error unless the signature is a valid signature of the
+// header value made with one of the keys no more than maxAge ago. The age of
+// the signature is not checked if maxAge is zero.
+func Verify(
+	keys []*Key,
+	name, value string,
+	sig *Signature,
+	maxAge time.Duration) error {
+
+	driver := headerDriver(value)
+
+	if sig == nil {
+		return utils.NewBadSignatureErr(name, driver, "missing signature")
+	}
+
+	if maxAge > 0 {
+		age := time.Since(time.Unix(sig.Time, 0))
+		if age > maxAge || age < -maxAge {
+			return utils.NewBadSignatureErr(name, driver, "signature expired")
+		}
+	}
+
+	payload := signedPayload(name, value, sig.Time)
+
+	for _, k := range keys {
+		switch sig.Algorithm {
+		case AlgHMACSHA256:
+			if len(k.Secret) > 0 &&
+				hmac.Equal(hmacSum(k.Secret, payload), sig.Sig) {
+				return nil
+			}
+		case AlgRSASHA256:
+			if k.PublicKey == nil {
+				continue
+			}
+			sum := sha256.Sum256(payload)
+			if rsa.VerifyPKCS1v15(
+				k.PublicKey, crypto.SHA256, sum[:], sig.Sig) == nil {
+				return nil
+			}
+		default:
+			return utils.NewBadSignatureErr(
+				name, driver, "unsupported algorithm")
+		}
+	}
+
+	return utils.NewBadSignatureErr(name, driver, "invalid signature")
+}
+
+func signedPayload(name, value string, ts int64) []byte {
+	return []byte(fmt.Sprintf("%s\n%s\n%d", name, value, ts))
+}
+
+func hmacSum(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// headerDriver returns the name of the driver of an InstanceID or
+// LocalDevices header value, both of which begin with "DRIVER=".
+func headerDriver(value string) string {
+	if i := strings.Index(value, "="); i > 0 {
+		return value[:i]
+	}
+	return ""
+}
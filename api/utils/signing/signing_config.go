@@ -0,0 +1,158 @@
+package signing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+// Config is the configuration with which a server verifies the signatures
+// of the InstanceID and LocalDevices headers.
+type Config struct {
+	// MaxAge is the maximum age of a signature.
+	MaxAge time.Duration
+
+	// Default is the key used when neither the instance nor the service
+	// has a key.
+	Default *Key
+
+	// Instances are the keys of individual instances by their IDs.
+	Instances map[string]*Key
+}
+
+// Keys returns the keys with which the signatures of the headers sent on
+// behalf of the instance with the provided ID are verified. An instance that
+// has its own key may only be identified by headers signed with that key;
+// otherwise the keys of the instance's storage services and the default key
+// are returned.
+func (c *Config) Keys(instanceID string, serviceKeys ...*Key) []*Key {
+	if k, ok := c.Instances[instanceID]; ok && instanceID != "" {
+		return []*Key{k}
+	}
+	keys := append([]*Key{}, serviceKeys...)
+	if c.Default != nil {
+		keys = append(keys, c.Default)
+	}
+	return keys
+}
+
+// ParseServerConfig parses the configuration of header signing from the
+// libstorage.server.signing section of the server's configuration. A nil
+// value is returned if signing is not enabled.
+func ParseServerConfig(config gofig.Config) (*Config, error) {
+
+	if !config.GetBool(types.ConfigServerSigningEnabled) {
+		return nil, nil
+	}
+
+	c := &Config{Instances: map[string]*Key{}}
+
+	if v := config.GetString(types.ConfigServerSigningMaxAge); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, goof.WithFieldE(
+				"configKey", types.ConfigServerSigningMaxAge,
+				"invalid duration", err)
+		}
+		c.MaxAge = d
+	}
+
+	var err error
+	if c.Default, err = ParseServerKey(config); err != nil {
+		return nil, err
+	}
+
+	instances, err := toStringMap(
+		config.Get(types.ConfigServerSigningInstances))
+	if err != nil {
+		return nil, err
+	}
+	for id, v := range instances {
+		var k *Key
+		switch tv := v.(type) {
+		case string:
+			k, err = ParseKey(tv, "", "")
+		default:
+			var m map[string]interface{}
+			if m, err = toStringMap(tv); err != nil {
+				return nil, err
+			}
+			k, err = ParseKey(
+				toString(m["key"]), "", toString(m["publicKey"]))
+		}
+		if err != nil {
+			return nil, goof.WithFieldsE(goof.Fields{
+				"configKey":  types.ConfigServerSigningInstances,
+				"instanceID": id,
+			}, "invalid key", err)
+		}
+		if k == nil {
+			return nil, goof.WithFields(goof.Fields{
+				"configKey":  types.ConfigServerSigningInstances,
+				"instanceID": id,
+			}, "missing key")
+		}
+		c.Instances[id] = k
+	}
+
+	return c, nil
+}
+
+// ParseServerKey parses the key from the libstorage.server.signing.key and
+// libstorage.server.signing.publicKey properties of the provided config,
+// which may be scoped to a service. A nil value is returned if neither
+// property is set.
+func ParseServerKey(config gofig.Config) (*Key, error) {
+	k, err := ParseKey(
+		config.GetString(types.ConfigServerSigningKey),
+		"",
+		config.GetString(types.ConfigServerSigningPublicKey))
+	if err != nil {
+		return nil, goof.WithFieldE(
+			"configKey", types.ConfigServerSigning, "invalid key", err)
+	}
+	return k, nil
+}
+
+// ParseClientKey parses the key with which a client signs its headers from
+// the libstorage.client.signing section of the client's configuration. A nil
+// value is returned if no key is configured.
+func ParseClientKey(config gofig.Config) (*Key, error) {
+	k, err := ParseKey(
+		config.GetString(types.ConfigClientSigningKey),
+		config.GetString(types.ConfigClientSigningPrivateKey),
+		"")
+	if err != nil {
+		return nil, goof.WithFieldE(
+			"configKey", types.ConfigClient+".signing", "invalid key", err)
+	}
+	return k, nil
+}
+
+func toStringMap(v interface{}) (map[string]interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return tv, nil
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range tv {
+			m[fmt.Sprintf("%v", k)] = v
+		}
+		return m, nil
+	}
+	return nil, goof.WithField(
+		"configKey", types.ConfigServerSigningInstances, "invalid format")
+}
+
+func toString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
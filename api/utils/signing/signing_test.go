@@ -0,0 +1,89 @@
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/emccode/libstorage/api/types"
+)
+
+const testIID = "vfs=1234"
+
+func TestSignHMAC(t *testing.T) {
+	k := &Key{Secret: []byte("secret")}
+
+	v, err := k.SignHeader(types.InstanceIDHeader, testIID)
+	assert.NoError(t, err)
+
+	sig, err := FindSignature([]string{"mock=x:1:AA==", v}, "VFS")
+	assert.NoError(t, err)
+	if !assert.NotNil(t, sig) {
+		t.FailNow()
+	}
+	assert.Equal(t, "vfs", sig.Driver)
+	assert.Equal(t, AlgHMACSHA256, sig.Algorithm)
+
+	keys := []*Key{{Secret: []byte("other")}, k}
+	assert.NoError(t, Verify(
+		keys, types.InstanceIDHeader, testIID, sig, time.Minute))
+
+	// the signature of one header is not valid for another
+	assert.IsType(t, &types.ErrBadSignature{}, Verify(
+		keys, types.LocalDevicesHeader, testIID, sig, time.Minute))
+	assert.IsType(t, &types.ErrBadSignature{}, Verify(
+		keys, types.InstanceIDHeader, "vfs=5678", sig, time.Minute))
+	assert.IsType(t, &types.ErrBadSignature{}, Verify(
+		keys[:1], types.InstanceIDHeader, testIID, sig, time.Minute))
+	assert.IsType(t, &types.ErrBadSignature{}, Verify(
+		keys, types.InstanceIDHeader, testIID, nil, time.Minute))
+}
+
+func TestSignRSA(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &Key{PrivateKey: pk}
+
+	v, err := k.SignHeader(types.InstanceIDHeader, testIID)
+	assert.NoError(t, err)
+	sig, err := ParseSignature(v)
+	assert.NoError(t, err)
+	assert.Equal(t, AlgRSASHA256, sig.Algorithm)
+
+	assert.NoError(t, Verify(
+		[]*Key{{PublicKey: &pk.PublicKey}},
+		types.InstanceIDHeader, testIID, sig, time.Minute))
+	assert.IsType(t, &types.ErrBadSignature{}, Verify(
+		[]*Key{{Secret: []byte("secret")}},
+		types.InstanceIDHeader, testIID, sig, time.Minute))
+}
+
+func TestSignExpired(t *testing.T) {
+	k := &Key{Secret: []byte("secret")}
+	v, err := k.sign(
+		types.InstanceIDHeader, testIID, time.Now().Add(-time.Hour).Unix())
+	assert.NoError(t, err)
+	sig, err := ParseSignature(v)
+	assert.NoError(t, err)
+
+	assert.IsType(t, &types.ErrBadSignature{}, Verify(
+		[]*Key{k}, types.InstanceIDHeader, testIID, sig, time.Minute))
+	assert.NoError(t, Verify(
+		[]*Key{k}, types.InstanceIDHeader, testIID, sig, 0))
+}
+
+func TestConfigKeys(t *testing.T) {
+	def := &Key{Secret: []byte("default")}
+	svc := &Key{Secret: []byte("service")}
+	iid := &Key{Secret: []byte("instance")}
+
+	c := &Config{Default: def, Instances: map[string]*Key{"1234": iid}}
+	assert.Equal(t, []*Key{iid}, c.Keys("1234", svc))
+	assert.Equal(t, []*Key{svc, def}, c.Keys("5678", svc))
+	assert.Equal(t, []*Key{def}, c.Keys(""))
+}
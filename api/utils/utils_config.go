@@ -66,3 +66,27 @@ func getBool(
 
 	return false
 }
+
+// ConfigStrings returns a list of strings from a config value that is either
+// a list or a string of comma-separated values.
+func ConfigStrings(config gofig.Config, key string) []string {
+	switch tv := config.Get(key).(type) {
+	case string:
+		s := []string{}
+		for _, v := range strings.Split(tv, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				s = append(s, v)
+			}
+		}
+		return s
+	case []string:
+		return tv
+	case []interface{}:
+		s := []string{}
+		for _, v := range tv {
+			s = append(s, fmt.Sprintf("%v", v))
+		}
+		return s
+	}
+	return nil
+}
@@ -38,6 +38,16 @@ func NewMissingInstanceIDError(service string) error {
 	}
 }
 
+// NewNotImplementedErr returns a new ErrUnimplemented error for an operation
+// that is not supported by a service's driver.
+func NewNotImplementedErr(
+	service string, capability types.StorageCapability) error {
+	return &types.ErrUnimplemented{Goof: goof.WithFields(goof.Fields{
+		"service":    service,
+		"capability": capability,
+	}, "not implemented")}
+}
+
 // NewStoreKeyErr returns a new ErrStoreKey error.
 func NewStoreKeyErr(key string) error {
 	return &types.ErrStoreKey{
@@ -64,3 +74,153 @@ func NewBadFilterErr(filter string, err error) error {
 	return &types.ErrBadFilter{Goof: goof.WithFieldE(
 		"filter", filter, "bad filter", err)}
 }
+
+// NewBadPageOptsErr returns a new ErrBadPageOpts error.
+func NewBadPageOptsErr(param, value string, err error) error {
+	return &types.ErrBadPageOpts{Goof: goof.WithFieldsE(goof.Fields{
+		"param": param,
+		"value": value,
+	}, "bad page opts", err)}
+}
+
+// NewVolumeShrinkErr returns a new ErrVolumeShrink error.
+func NewVolumeShrinkErr(volumeID string, size, newSize int64) error {
+	return &types.ErrVolumeShrink{Goof: goof.WithFields(goof.Fields{
+		"volumeID": volumeID,
+		"size":     size,
+		"newSize":  newSize,
+	}, "volume cannot be shrunk")}
+}
+
+// NewVolumeTooLargeErr returns a new ErrVolumeTooLarge error for a volume
+// size that exceeds the service's maximum volume size.
+func NewVolumeTooLargeErr(service string, size, maxSize int64) error {
+	return &types.ErrVolumeTooLarge{Goof: goof.WithFields(goof.Fields{
+		"service": service,
+		"size":    size,
+		"maxSize": maxSize,
+	}, "volume size exceeds maximum")}
+}
+
+// NewVolumeInUseErr returns a new ErrVolumeInUse error for a volume that
+// cannot be attached because it is attached to the provided host. The host
+// may be empty if it is not known.
+func NewVolumeInUseErr(volumeID, host string) error {
+	fields := goof.Fields{"volumeID": volumeID}
+	if host != "" {
+		fields["host"] = host
+	}
+	return &types.ErrVolumeInUse{Goof: goof.WithFields(
+		fields, "volume already attached to a host")}
+}
+
+// NewVolumeAttachedErr returns a new ErrVolumeInUse error for a volume that
+// cannot be resized without force because it is attached.
+func NewVolumeAttachedErr(volumeID string) error {
+	return &types.ErrVolumeInUse{Goof: goof.WithField(
+		"volumeID", volumeID, "volume is attached, resize with force")}
+}
+
+// NewInvalidRequestErr returns a new ErrInvalidRequest error.
+func NewInvalidRequestErr(reason string, err error) error {
+	return &types.ErrInvalidRequest{Goof: goof.WithFieldE(
+		"reason", reason, "invalid request", err)}
+}
+
+// NewTaskCanceledErr returns a new ErrTaskCanceled error.
+func NewTaskCanceledErr(taskID int, err error) error {
+	return &types.ErrTaskCanceled{Goof: goof.WithFieldE(
+		"taskID", taskID, "task canceled", err)}
+}
+
+// NewQueueFullErr returns a new ErrQueueFull error.
+func NewQueueFullErr(service string, depth int, retryAfter int) error {
+	return &types.ErrQueueFull{Goof: goof.WithFields(goof.Fields{
+		"service":    service,
+		"depth":      depth,
+		"retryAfter": retryAfter,
+	}, "task queue full")}
+}
+
+// NewInvalidTenantErr returns a new ErrForbidden error for a request made on
+// behalf of a tenant whose name cannot be used as a namespace.
+func NewInvalidTenantErr(tenant string) error {
+	return &types.ErrForbidden{Goof: goof.WithField(
+		"tenant", tenant, "invalid tenant")}
+}
+
+// NewQuotaExceededErr returns a new ErrQuotaExceeded error for a request
+// whose resources would exceed the limit of a quota. The name is the name of
+// the quota's tenant or instance, and the limit is the name of the limit that
+// would be exceeded.
+func NewQuotaExceededErr(
+	scope types.QuotaScope, name, limit string, max, requested int64) error {
+	return &types.ErrQuotaExceeded{Goof: goof.WithFields(goof.Fields{
+		"scope":     scope,
+		"name":      name,
+		"limit":     limit,
+		"max":       max,
+		"requested": requested,
+	}, "quota exceeded")}
+}
+
+// NewRateLimitedErr returns a new ErrTooManyRequests error for a client that
+// has exceeded its rate limit.
+func NewRateLimitedErr(key string, retryAfter int) error {
+	return &types.ErrTooManyRequests{Goof: goof.WithFields(goof.Fields{
+		"key":        key,
+		"retryAfter": retryAfter,
+	}, "rate limit exceeded")}
+}
+
+// NewTooManyDriverCallsErr returns a new ErrTooManyRequests error for a call
+// to a storage service's driver that could not be made because the maximum
+// number of calls are in flight.
+func NewTooManyDriverCallsErr(service string, max int) error {
+	return &types.ErrTooManyRequests{Goof: goof.WithFields(goof.Fields{
+		"service":    service,
+		"max":        max,
+		"retryAfter": 1,
+	}, "too many driver calls")}
+}
+
+// NewUnauthenticatedErr returns a new ErrUnauthenticated error.
+func NewUnauthenticatedErr(reason string) error {
+	return &types.ErrUnauthenticated{
+		Goof: goof.WithField("reason", reason, "unauthenticated"),
+	}
+}
+
+// NewForbiddenErr returns a new ErrForbidden error.
+func NewForbiddenErr(
+	principal, service string, verb types.AuthVerb) error {
+	return &types.ErrForbidden{Goof: goof.WithFields(goof.Fields{
+		"principal": principal,
+		"service":   service,
+		"verb":      verb,
+	}, "forbidden")}
+}
+
+// NewInstanceForbiddenErr returns a new ErrForbidden error for a principal
+// that makes a request on behalf of an instance other than its own.
+func NewInstanceForbiddenErr(principal, instanceID string) error {
+	return &types.ErrForbidden{Goof: goof.WithFields(goof.Fields{
+		"principal":  principal,
+		"instanceID": instanceID,
+	}, "forbidden")}
+}
+
+// NewIdempotencyKeyReusedErr returns a new ErrIdempotencyKeyReused error.
+func NewIdempotencyKeyReusedErr(key string) error {
+	return &types.ErrIdempotencyKeyReused{Goof: goof.WithField(
+		"key", key, "idempotency key reused with a different request")}
+}
+
+// NewBadSignatureErr returns a new ErrBadSignature error.
+func NewBadSignatureErr(header, driver, reason string) error {
+	return &types.ErrBadSignature{Goof: goof.WithFields(goof.Fields{
+		"header": header,
+		"driver": driver,
+		"reason": reason,
+	}, "invalid header signature")}
+}
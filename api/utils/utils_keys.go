@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"strings"
+
+	"github.com/akutz/goof"
+)
+
+// ParseRSAPublicKey parses an RSA public key from either a PEM-encoded public
+// key or certificate or the path to a file that contains one.
+func ParseRSAPublicKey(v string) (*rsa.PublicKey, error) {
+
+	block, err := readPEM(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = cert.PublicKey
+	case "RSA PUBLIC KEY":
+		pk := &rsa.PublicKey{}
+		if _, err := asn1.Unmarshal(block.Bytes, pk); err != nil {
+			return nil, err
+		}
+		key = pk
+	default:
+		if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, err
+		}
+	}
+
+	pk, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, goof.New("public key is not an rsa key")
+	}
+	return pk, nil
+}
+
+// ParseRSAPrivateKey parses an RSA private key from either a PEM-encoded
+// PKCS #1 or PKCS #8 private key or the path to a file that contains one.
+func ParseRSAPrivateKey(v string) (*rsa.PrivateKey, error) {
+
+	block, err := readPEM(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pk, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, goof.New("private key is not an rsa key")
+	}
+	return pk, nil
+}
+
+// readPEM decodes the first PEM block from either a PEM-encoded value or the
+// path to a file that contains one.
+func readPEM(v string) (*pem.Block, error) {
+
+	buf := []byte(v)
+	if !strings.HasPrefix(strings.TrimSpace(v), "-----BEGIN") {
+		var err error
+		if buf, err = ioutil.ReadFile(v); err != nil {
+			return nil, err
+		}
+	}
+
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, goof.New("invalid pem")
+	}
+	return block, nil
+}
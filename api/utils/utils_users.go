@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/akutz/goof"
+)
+
+var (
+	passwdFile = "/etc/passwd"
+	groupFile  = "/etc/group"
+)
+
+// LookupUID returns the ID of the user with the provided name. A numeric
+// value is returned as the ID without a lookup.
+func LookupUID(name string) (int, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+	entry, err := lookupEntry(passwdFile, name)
+	if err != nil {
+		return 0, err
+	}
+	if entry == nil {
+		return 0, goof.WithField("user", name, "unknown user")
+	}
+	return strconv.Atoi(entry[2])
+}
+
+// LookupGroup returns the ID of the group with the provided name along with
+// the names of the users who are the group's members. A numeric value is
+// returned as the ID without a lookup, in which case the members are not
+// returned.
+func LookupGroup(name string) (int, []string, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil, nil
+	}
+	entry, err := lookupEntry(groupFile, name)
+	if err != nil {
+		return 0, nil, err
+	}
+	if entry == nil {
+		return 0, nil, goof.WithField("group", name, "unknown group")
+	}
+	id, err := strconv.Atoi(entry[2])
+	if err != nil {
+		return 0, nil, err
+	}
+	var members []string
+	if len(entry) > 3 && entry[3] != "" {
+		members = strings.Split(entry[3], ",")
+	}
+	return id, members, nil
+}
+
+// lookupEntry returns the fields of the entry with the provided name from a
+// colon-delimited file such as /etc/passwd or /etc/group. A nil value is
+// returned if there is no such entry.
+func lookupEntry(path, name string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, ":")
+		if len(fields) >= 3 && fields[0] == name {
+			return fields, nil
+		}
+	}
+	return nil, scanner.Err()
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupUsers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libstorage-users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldPasswd, oldGroup := passwdFile, groupFile
+	defer func() { passwdFile, groupFile = oldPasswd, oldGroup }()
+	passwdFile = path.Join(dir, "passwd")
+	groupFile = path.Join(dir, "group")
+
+	assert.NoError(t, ioutil.WriteFile(passwdFile, []byte(
+		"root:x:0:0:root:/root:/bin/bash\n"+
+			"# comment\n"+
+			"akutz:x:1000:1000::/home/akutz:/bin/bash\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(groupFile, []byte(
+		"root:x:0:\n"+
+			"docker:x:999:akutz,root\n"), 0644))
+
+	uid, err := LookupUID("akutz")
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, uid)
+
+	uid, err = LookupUID("42")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, uid)
+
+	_, err = LookupUID("nobody")
+	assert.Error(t, err)
+
+	gid, members, err := LookupGroup("docker")
+	assert.NoError(t, err)
+	assert.Equal(t, 999, gid)
+	assert.Equal(t, []string{"akutz", "root"}, members)
+
+	gid, members, err = LookupGroup("root")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, gid)
+	assert.Empty(t, members)
+}
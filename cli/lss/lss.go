@@ -2,6 +2,7 @@ package lss
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/emccode/libstorage/api/server"
 	apitypes "github.com/emccode/libstorage/api/types"
 	apiconfig "github.com/emccode/libstorage/api/utils/config"
+	"github.com/emccode/libstorage/api/utils/schema"
 
 	// load the drivers
 	_ "github.com/emccode/libstorage/imports/config"
@@ -23,16 +25,17 @@ import (
 )
 
 var (
-	cliFlags        *flag.FlagSet
-	flagHost        *string
-	flagConfig      *string
-	flagLogLvl      *string
-	flagHelp        *bool
-	flagVerbose     *bool
-	flagVersion     *bool
-	flagEnv         *bool
-	flagPrintConfig *bool
-	config          gofig.Config
+	cliFlags           *flag.FlagSet
+	flagHost           *string
+	flagConfig         *string
+	flagLogLvl         *string
+	flagHelp           *bool
+	flagVerbose        *bool
+	flagVersion        *bool
+	flagEnv            *bool
+	flagPrintConfig    *bool
+	flagValidateConfig *string
+	config             gofig.Config
 )
 
 func init() {
@@ -44,6 +47,8 @@ func init() {
 	flagVersion = cliFlags.Bool("version", false, "print version info")
 	flagEnv = cliFlags.Bool("env", false, "print env info")
 	flagPrintConfig = cliFlags.Bool("printConfig", false, "print config info")
+	flagValidateConfig = cliFlags.String(
+		"validate-config", "", "validate the config file at path and exit")
 	flagVerbose = cliFlags.BoolP("verbose", "v", false, "print verbose usage")
 	flag.CommandLine.AddFlagSet(cliFlags)
 }
@@ -69,6 +74,10 @@ func Run() {
 		os.Exit(0)
 	}
 
+	if flagValidateConfig != nil && *flagValidateConfig != "" {
+		validateConfig(*flagValidateConfig)
+	}
+
 	// if a config is specified then do not care about any other options
 	if flagConfig != nil && gotil.FileExists(*flagConfig) {
 
@@ -79,14 +88,17 @@ func Run() {
 			os.Exit(1)
 		}
 
+		if err := apiconfig.ResolveSecrets(config); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
+			os.Exit(1)
+		}
+
 		if flagPrintConfig != nil && *flagPrintConfig {
-			jstr, err := config.ToJSON()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
-				os.Exit(1)
-			}
-			fmt.Fprintln(os.Stdout, jstr)
-			os.Exit(0)
+			printConfig()
+		}
+
+		if !validateConfigFile(*flagConfig) {
+			os.Exit(1)
 		}
 
 		s, errs, err := server.Serve(nil, config)
@@ -138,13 +150,7 @@ func Run() {
 	}
 
 	if flagPrintConfig != nil && *flagPrintConfig {
-		jstr, err := config.ToJSON()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
-			os.Exit(1)
-		}
-		fmt.Fprintln(os.Stdout, jstr)
-		os.Exit(0)
+		printConfig()
 	}
 
 	buf := &bytes.Buffer{}
@@ -163,6 +169,12 @@ func Run() {
 		os.Exit(1)
 	}
 
+	for _, f := range apiconfig.Files() {
+		if gotil.FileExists(f) && !validateConfigFile(f) {
+			os.Exit(1)
+		}
+	}
+
 	server.CloseOnAbort()
 
 	_, errs, err := server.Serve(nil, config)
@@ -174,11 +186,69 @@ func Run() {
 	<-errs
 }
 
+// validateConfig validates the config file at the provided path and
+// initializes the services and drivers it configures without starting the
+// server's endpoints. The program exits once the config is validated.
+func validateConfig(path string) {
+	if !validateConfigFile(path) {
+		os.Exit(1)
+	}
+
+	config = gofig.New()
+	if err := config.ReadConfigFile(path); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+
+	if err := apiconfig.ResolveSecrets(config); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+
+	if err := server.Validate(nil, config); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: valid config\n", path)
+	os.Exit(0)
+}
+
+// printConfig prints the config as JSON with its secrets redacted and exits.
+func printConfig() {
+	buf, err := json.MarshalIndent(
+		apitypes.RedactConfig(config.AllSettings()), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: error: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stdout, string(buf))
+	os.Exit(0)
+}
+
+// validateConfigFile validates the config file at the provided path with the
+// libStorage config schema. The invalid properties are printed to stderr.
+func validateConfigFile(path string) bool {
+	err := apiconfig.ValidateFile(path)
+	if err == nil {
+		return true
+	}
+	if errs, ok := err.(schema.ConfigErrors); ok {
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "%s: error: %s: %v\n", os.Args[0], path, e)
+		}
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "%s: error: %s: %v\n", os.Args[0], path, err)
+	return false
+}
+
 func printUsage() {
 	firstLine := fmt.Sprintf("usage: %s", os.Args[0])
 	fmt.Fprintf(os.Stderr, "%s\n", firstLine)
 	padFmt := fmt.Sprintf("%%%ds\n", len(firstLine))
 	fmt.Fprintf(os.Stderr, padFmt, "-c,--config <configFilePath> [--printConfig]")
+	fmt.Fprintf(os.Stderr, padFmt, "--validate-config <configFilePath>")
 	fmt.Fprintf(os.Stderr, padFmt, "--version")
 	fmt.Fprintf(os.Stderr, padFmt, "--env")
 	fmt.Fprintf(os.Stderr, padFmt, "[-options] <driver>[:<service>] [<driver>[:<service>]...]")
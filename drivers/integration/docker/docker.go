@@ -520,6 +520,88 @@ func (d *driver) Remove(
 	return client.Storage().VolumeRemove(ctx, vol.ID, opts)
 }
 
+// Resize will expand a volume based on volumeName and, if the volume is
+// mounted on this instance, grow its file system to the new size.
+func (d *driver) Resize(
+	ctx types.Context,
+	volumeName string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	if volumeName == "" {
+		return nil, goof.New("missing volume name or ID")
+	}
+
+	ctx.WithFields(log.Fields{
+		"volumeName": volumeName,
+		"size":       opts.Size,
+		"opts":       opts}).Info("resizing volume")
+
+	vol, err := d.volumeInspectByIDOrName(
+		ctx, "", volumeName, true, opts.Opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client := context.MustClient(ctx)
+
+	var ma *types.VolumeAttachment
+	if len(vol.Attachments) > 0 {
+		inst, err := client.Storage().InstanceInspect(ctx, utils.NewStore())
+		if err != nil {
+			return nil, goof.New("problem getting instance ID")
+		}
+		for _, att := range vol.Attachments {
+			if att.InstanceID.ID == inst.InstanceID.ID {
+				ma = att
+				break
+			}
+		}
+	}
+
+	// a volume attached to this instance is resized in place so that its
+	// file system can be grown afterwards
+	if ma != nil && !opts.Force {
+		forceOpts := *opts
+		forceOpts.Force = true
+		opts = &forceOpts
+	}
+
+	newVol, err := client.Storage().VolumeResize(ctx, vol.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the file system is only grown if the volume is attached locally
+	if ma == nil || ma.DeviceName == "" {
+		return newVol, nil
+	}
+
+	mounts, err := client.OS().Mounts(
+		ctx, ma.DeviceName, "", opts.Opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resizeOpts := &types.DeviceResizeOpts{Opts: opts.Opts}
+	if len(mounts) > 0 {
+		resizeOpts.MountPoint = mounts[0].MountPoint
+	}
+
+	if err := client.OS().Resize(
+		ctx, ma.DeviceName, resizeOpts); err != nil {
+		return nil, goof.WithFieldE(
+			"volumeName", volumeName,
+			"volume expanded but filesystem resize failed",
+			err)
+	}
+
+	ctx.WithFields(log.Fields{
+		"volumeName": volumeName,
+		"vol":        newVol}).Info("volume resized")
+
+	return newVol, nil
+}
+
 // Attach will attach a volume based on volumeName to the instance of
 // instanceID.
 func (d *driver) Attach(
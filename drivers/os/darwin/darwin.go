@@ -85,6 +85,14 @@ func (d *driver) Format(
 	return nil
 }
 
+func (d *driver) Resize(
+	ctx types.Context,
+	deviceName string,
+	opts *types.DeviceResizeOpts) error {
+
+	return nil
+}
+
 func configRegistration() *gofig.Registration {
 	r := gofig.NewRegistration("Darwin")
 	return r
@@ -177,6 +177,51 @@ func (d *driver) Format(
 	return nil
 }
 
+func (d *driver) Resize(
+	ctx types.Context,
+	deviceName string,
+	opts *types.DeviceResizeOpts) error {
+
+	fsType, err := probeFsType(deviceName)
+	if err != nil {
+		return err
+	}
+
+	ctx.WithFields(log.Fields{
+		"fsType":     fsType,
+		"deviceName": deviceName,
+		"mountPoint": opts.MountPoint,
+		"driverName": driverName}).Info("resizing filesystem")
+
+	switch fsType {
+	case "ext4":
+		if err := exec.Command("resize2fs", deviceName).Run(); err != nil {
+			return goof.WithFieldE(
+				"deviceName", deviceName,
+				"error resizing filesystem",
+				err)
+		}
+	case "xfs":
+		// xfs may only be grown while it is mounted
+		if opts.MountPoint == "" {
+			return goof.WithField(
+				"deviceName", deviceName,
+				"xfs filesystem must be mounted to be resized")
+		}
+		if err := exec.Command(
+			"xfs_growfs", opts.MountPoint).Run(); err != nil {
+			return goof.WithFieldsE(goof.Fields{
+				"deviceName": deviceName,
+				"mountPoint": opts.MountPoint,
+			}, "error resizing filesystem", err)
+		}
+	default:
+		return errUnsupportedFileSystem
+	}
+
+	return nil
+}
+
 func (d *driver) isNfsDevice(device string) bool {
 	return strings.Contains(device, ":")
 }
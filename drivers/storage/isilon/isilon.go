@@ -2,6 +2,8 @@ package isilon
 
 import (
 	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/types"
 )
 
 const (
@@ -26,4 +28,6 @@ func registerConfig() {
 	r.Key(gofig.Bool, "", false, "", "isilon.quotas")
 	r.Key(gofig.Bool, "", false, "", "isilon.sharedMounts")
 	gofig.Register(r)
+
+	types.RegisterSecretConfigKeys("isilon.password")
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/registry"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
 	"github.com/emccode/libstorage/drivers/storage/isilon"
 )
 
@@ -160,6 +161,16 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.NAS, nil
 }
 
+// Capabilities returns the operations the driver supports. A volume may be
+// attached to more than one instance if the shared mounts are enabled.
+func (d *driver) Capabilities(
+	ctx types.Context) (*types.StorageCapabilities, error) {
+	return &types.StorageCapabilities{
+		MultiAttach: d.sharedMounts(),
+		Type:        types.NAS,
+	}, nil
+}
+
 // NextDeviceInfo returns the information about the driver's next available
 // device workflow.
 func (d *driver) NextDeviceInfo(
@@ -338,11 +349,11 @@ func (d *driver) VolumeAttach(
 	if len(clients) > 0 && !d.sharedMounts() && opts.Force == false {
 		for _, c := range clients {
 			if c == instanceID.InstanceID.ID {
-				return nil, "", goof.New("volume already attached to instance")
+				return nil, "", utils.NewVolumeInUseErr(volumeID, c)
 			}
 		}
 
-		return nil, "", goof.New("volume already attached to another host")
+		return nil, "", utils.NewVolumeInUseErr(volumeID, clients[0])
 	}
 
 	if d.sharedMounts() {
@@ -437,6 +448,14 @@ func (d *driver) VolumeCopy(
 	return nil, types.ErrNotImplemented
 }
 
+// VolumeResize expands a volume (not implemented)
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+	return nil, types.ErrNotImplemented
+}
+
 // VolumeSnapshot snapshots a volume (not implemented)
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
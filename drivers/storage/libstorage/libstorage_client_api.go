@@ -2,6 +2,7 @@ package libstorage
 
 import (
 	"io"
+	"time"
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/registry"
@@ -95,6 +96,39 @@ func (c *client) VolumesByService(
 	return c.APIClient.VolumesByService(ctx, service, attachments)
 }
 
+func (c *client) VolumesIterator(
+	ctx types.Context,
+	attachments bool,
+	opts *types.PageOpts) (types.VolumeIterator, error) {
+
+	ctx = c.requireCtx(ctx)
+
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = c.withAllInstanceIDs(ctxA)
+
+	return c.APIClient.VolumesIterator(ctx, attachments, opts)
+}
+
+func (c *client) VolumesByServiceIterator(
+	ctx types.Context,
+	service string,
+	attachments bool,
+	opts *types.PageOpts) (types.VolumeIterator, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumesByServiceIterator(
+		ctx, service, attachments, opts)
+}
+
 func (c *client) VolumeInspect(
 	ctx types.Context,
 	service, volumeID string,
@@ -209,6 +243,37 @@ func (c *client) VolumeCopy(
 	return vol, nil
 }
 
+func (c *client) VolumeResize(
+	ctx types.Context,
+	service, volumeID string,
+	request *types.VolumeResizeRequest) (*types.Volume, error) {
+
+	ctx = c.requireCtx(ctx).WithValue(context.ServiceKey, service)
+
+	lsd, _ := registry.NewClientDriver(service)
+	if lsd != nil {
+		if err := lsd.Init(ctx, c.config); err != nil {
+			return nil, err
+		}
+
+		if err := lsd.VolumeResizeBefore(
+			&ctx, service, volumeID, request); err != nil {
+			return nil, err
+		}
+	}
+
+	vol, err := c.APIClient.VolumeResize(ctx, service, volumeID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if lsd != nil {
+		lsd.VolumeResizeAfter(ctx, vol)
+	}
+
+	return vol, nil
+}
+
 func (c *client) VolumeRemove(
 	ctx types.Context,
 	service, volumeID string) error {
@@ -344,6 +409,23 @@ func (c *client) SnapshotsByService(
 	return c.APIClient.SnapshotsByService(ctx, service)
 }
 
+func (c *client) SnapshotsIterator(
+	ctx types.Context,
+	opts *types.PageOpts) (types.SnapshotIterator, error) {
+
+	ctx = c.requireCtx(ctx)
+	return c.APIClient.SnapshotsIterator(ctx, opts)
+}
+
+func (c *client) SnapshotsByServiceIterator(
+	ctx types.Context,
+	service string,
+	opts *types.PageOpts) (types.SnapshotIterator, error) {
+
+	ctx = c.requireCtx(ctx).WithValue(context.ServiceKey, service)
+	return c.APIClient.SnapshotsByServiceIterator(ctx, service, opts)
+}
+
 func (c *client) SnapshotInspect(
 	ctx types.Context,
 	service, snapshotID string) (*types.Snapshot, error) {
@@ -369,6 +451,54 @@ func (c *client) SnapshotCopy(
 	return c.APIClient.SnapshotCopy(ctx, service, snapshotID, request)
 }
 
+func (c *client) Tasks(
+	ctx types.Context) (map[string]*types.Task, error) {
+
+	return c.APIClient.Tasks(c.requireCtx(ctx))
+}
+
+func (c *client) TasksIterator(
+	ctx types.Context,
+	opts *types.PageOpts) (types.TaskIterator, error) {
+
+	return c.APIClient.TasksIterator(c.requireCtx(ctx), opts)
+}
+
+func (c *client) TaskInspect(
+	ctx types.Context, taskID int) (*types.Task, error) {
+
+	return c.APIClient.TaskInspect(c.requireCtx(ctx), taskID)
+}
+
+func (c *client) TaskWait(
+	ctx types.Context,
+	taskID int,
+	timeout time.Duration) (*types.Task, error) {
+
+	return c.APIClient.TaskWait(c.requireCtx(ctx), taskID, timeout)
+}
+
+func (c *client) Events(
+	ctx types.Context,
+	opts *types.EventsOpts) (<-chan *types.Event, error) {
+
+	return c.APIClient.Events(c.requireCtx(ctx), opts)
+}
+
+func (c *client) Quotas(
+	ctx types.Context) (types.ServiceQuotaMap, error) {
+
+	ctx = c.withAllInstanceIDs(c.requireCtx(ctx))
+	return c.APIClient.Quotas(ctx)
+}
+
+func (c *client) QuotasByService(
+	ctx types.Context, service string) ([]*types.Quota, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.QuotasByService(ctx, service)
+}
+
 func (c *client) Executors(
 	ctx types.Context) (map[string]*types.ExecutorInfo, error) {
 
@@ -14,6 +14,7 @@ import (
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/types"
 	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/signing"
 )
 
 var (
@@ -79,11 +80,32 @@ func (d *driver) Init(ctx types.Context, config gofig.Config) error {
 	logRes := config.GetBool(types.ConfigLogHTTPResponses)
 	apiClient.LogRequests(logReq)
 	apiClient.LogResponses(logRes)
+	apiClient.AuthToken(config.GetString(types.ConfigClientAuthToken))
+	apiClient.Tenant(config.GetString(types.ConfigClientTenant))
+
+	signingKey, err := signing.ParseClientKey(config)
+	if err != nil {
+		return err
+	}
+	if signingKey != nil {
+		apiClient.SignHeaders(signingKey)
+	}
+
+	retries := config.GetInt(types.ConfigClientRetries)
+	retryMaxWait, err := time.ParseDuration(
+		config.GetString(types.ConfigClientRetryMaxWait))
+	if err != nil {
+		return err
+	}
+	apiClient.Retries(retries, retryMaxWait)
+	logFields["retries"] = retries
+	logFields["retryMaxWait"] = retryMaxWait.String()
 
 	logFields["enableInstanceIDHeaders"] = EnableInstanceIDHeaders
 	logFields["enableLocalDevicesHeaders"] = EnableLocalDevicesHeaders
 	logFields["logRequests"] = logReq
 	logFields["logResponses"] = logRes
+	logFields["signHeaders"] = signingKey != nil
 
 	d.client = client{
 		APIClient:    apiClient,
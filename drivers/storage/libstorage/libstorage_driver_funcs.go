@@ -48,6 +48,22 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return si.Driver.Type, nil
 }
 
+// Capabilities returns the capabilities of the remote service's driver.
+func (d *driver) Capabilities(
+	ctx types.Context) (*types.StorageCapabilities, error) {
+
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return nil, goof.New("missing service name")
+	}
+
+	si, err := d.getServiceInfo(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return si.Driver.Capabilities, nil
+}
+
 func (d *driver) InstanceInspect(
 	ctx types.Context,
 	opts types.Store) (*types.Instance, error) {
@@ -167,6 +183,26 @@ func (d *driver) VolumeCopy(
 	return d.client.VolumeCopy(ctx, serviceName, volumeID, req)
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	ctx = d.requireCtx(ctx)
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return nil, goof.New("missing service name")
+	}
+
+	req := &types.VolumeResizeRequest{
+		Size:  opts.Size,
+		Force: opts.Force,
+		Opts:  opts.Opts.Map(),
+	}
+
+	return d.client.VolumeResize(ctx, serviceName, volumeID, req)
+}
+
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
 	volumeID, snapshotName string,
@@ -19,6 +19,11 @@ import (
 const (
 	// Name is the name of the driver.
 	Name = executor.Name
+
+	// configCapabilities is the key of the optional capabilities that the
+	// driver describes. The driver does not describe its capabilities if the
+	// key is not set.
+	configCapabilities = Name + ".capabilities"
 )
 
 type driver struct {
@@ -95,6 +100,31 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+func (d *driver) Capabilities(
+	ctx types.Context) (*types.StorageCapabilities, error) {
+
+	if d.Config == nil || !d.Config.IsSet(configCapabilities) {
+		return nil, nil
+	}
+
+	// a capability is supported unless it is disabled
+	supports := func(name types.StorageCapability) bool {
+		key := fmt.Sprintf("%s.%s", configCapabilities, name)
+		return !d.Config.IsSet(key) || d.Config.GetBool(key)
+	}
+
+	return &types.StorageCapabilities{
+		Snapshots:          supports(types.StorageCapabilitySnapshots),
+		VolumeCopy:         supports(types.StorageCapabilityVolumeCopy),
+		SnapshotCopy:       supports(types.StorageCapabilitySnapshotCopy),
+		CreateFromSnapshot: supports(types.StorageCapabilityCreateFromSnapshot),
+		Resize:             supports(types.StorageCapabilityResize),
+		MultiAttach:        supports(types.StorageCapabilityMultiAttach),
+		MaxVolumeSize: int64(d.Config.GetInt(
+			configCapabilities + ".maxVolumeSize")),
+	}, nil
+}
+
 func (d *driver) NextDeviceInfo(
 	ctx types.Context) (*types.NextDeviceInfo, error) {
 	return d.nextDeviceInfo, nil
@@ -302,6 +332,32 @@ func (d *driver) VolumeCopy(
 
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	ctx.WithFields(log.Fields{
+		"volumeID": volumeID,
+		"size":     opts.Size,
+	}).Debug("mockDriver.VolumeResize")
+
+	for _, v := range d.volumes {
+		if strings.ToLower(v.ID) == strings.ToLower(volumeID) {
+			if opts.Size < v.Size {
+				return nil, utils.NewVolumeShrinkErr(
+					volumeID, v.Size, opts.Size)
+			}
+			if len(v.Attachments) > 0 && !opts.Force {
+				return nil, utils.NewVolumeAttachedErr(volumeID)
+			}
+			v.Size = opts.Size
+			return v, nil
+		}
+	}
+	return nil, utils.NewNotFoundError(volumeID)
+}
+
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
 	volumeID, snapshotName string,
@@ -364,6 +364,54 @@ func TestVolumeSnapshot(t *testing.T) {
 	apitests.Run(t, mock.Name, configYAML, tf)
 }
 
+var capabilitiesConfigYAML = []byte(`
+libstorage:
+  driver: mock
+mock:
+  capabilities:
+    snapshots: false
+    maxVolumeSize: 1024
+`)
+
+func TestUnsupportedCapabilities(t *testing.T) {
+
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+
+		// the mock driver creates any snapshot it is asked to, so the 501 is
+		// returned before the request reaches the driver
+		_, err := client.API().VolumeSnapshot(
+			nil, mock.Name, "vol-000",
+			&types.VolumeSnapshotRequest{SnapshotName: "snapshot1"})
+		assert.Error(t, err)
+		assert.Equal(t, types.ErrorCodeNotImplemented, types.ErrorCodeOf(err))
+		if httpErr, ok := err.(goof.HTTPError); assert.True(t, ok) {
+			assert.Equal(t, 501, httpErr.Status())
+		}
+
+		size := int64(2048)
+		_, err = client.API().VolumeCreate(
+			nil, mock.Name,
+			&types.VolumeCreateRequest{Name: "Volume 3", Size: &size})
+		assert.Error(t, err)
+		assert.Equal(t, types.ErrorCodeVolumeTooLarge, types.ErrorCodeOf(err))
+		assert.Equal(t, 422, types.ErrorCodeOf(err).Status())
+
+		_, err = client.API().VolumeResize(
+			nil, mock.Name, "vol-000",
+			&types.VolumeResizeRequest{Size: size})
+		assert.Error(t, err)
+		assert.Equal(t, types.ErrorCodeVolumeTooLarge, types.ErrorCodeOf(err))
+
+		vols, err := client.API().VolumesByService(nil, mock.Name, false)
+		assert.NoError(t, err)
+		assert.Len(t, vols, 3)
+		if v, ok := vols["vol-000"]; assert.True(t, ok) {
+			assert.EqualValues(t, 10240, v.Size)
+		}
+	}
+	apitests.Run(t, mock.Name, capabilitiesConfigYAML, tf)
+}
+
 func TestSnapshots(t *testing.T) {
 	tf := func(config gofig.Config, client types.Client, t *testing.T) {
 		reply, err := client.API().Snapshots(nil)
@@ -3,6 +3,8 @@ package scaleio
 import (
 	"github.com/akutz/gofig"
 	"github.com/akutz/goof"
+
+	"github.com/emccode/libstorage/api/types"
 )
 
 const (
@@ -44,4 +46,6 @@ func registerConfig() {
 	r.Key(gofig.String, "", "", "", "scaleio.thinOrThick")
 	r.Key(gofig.String, "", "", "", "scaleio.version")
 	gofig.Register(r)
+
+	types.RegisterSecretConfigKeys("scaleio.password")
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/registry"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
 	"github.com/emccode/libstorage/drivers/storage/scaleio"
 )
 
@@ -117,6 +118,11 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+func (d *driver) Capabilities(
+	ctx types.Context) (*types.StorageCapabilities, error) {
+	return &types.StorageCapabilities{Type: types.Block}, nil
+}
+
 func (d *driver) NextDeviceInfo(
 	ctx types.Context) (*types.NextDeviceInfo, error) {
 	return nil, nil
@@ -429,6 +435,13 @@ func (d *driver) VolumeCopy(
 	return nil, nil
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+	return nil, types.ErrNotImplemented
+}
+
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
 	volumeID, snapshotName string,
@@ -485,7 +498,7 @@ func (d *driver) VolumeAttach(
 	}
 
 	if len(vol.Attachments) > 0 && !opts.Force {
-		return nil, "", goof.New("volume already attached to a host")
+		return nil, "", utils.NewVolumeInUseErr(volumeID, "")
 	}
 
 	if len(vol.Attachments) > 0 && opts.Force {
@@ -14,6 +14,7 @@ import (
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/registry"
 	"github.com/emccode/libstorage/api/types"
+	"github.com/emccode/libstorage/api/utils"
 	"github.com/emccode/libstorage/drivers/storage/vbox"
 )
 
@@ -87,6 +88,12 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+// Capabilities returns the operations the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (*types.StorageCapabilities, error) {
+	return &types.StorageCapabilities{Type: types.Block}, nil
+}
+
 // NextDeviceInfo returns the information about the driver's next available
 // device workflow.
 func (d *driver) NextDeviceInfo(
@@ -279,6 +286,14 @@ func (d *driver) VolumeCopy(
 	return nil, types.ErrNotImplemented
 }
 
+// VolumeResize expands a volume (not implemented)
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+	return nil, types.ErrNotImplemented
+}
+
 // VolumeSnapshot snapshots a volume (not implemented)
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
@@ -337,7 +352,7 @@ func (d *driver) VolumeAttach(
 	}
 
 	if len(volumes[0].Attachments) > 0 && !opts.Force {
-		return nil, "", goof.New("volume already attached to a host")
+		return nil, "", utils.NewVolumeInUseErr(volumeID, "")
 	}
 	if opts.Force {
 		if _, err := d.VolumeDetach(ctx, volumeID, nil); err != nil {
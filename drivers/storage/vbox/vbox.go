@@ -1,6 +1,10 @@
 package vbox
 
-import "github.com/akutz/gofig"
+import (
+	"github.com/akutz/gofig"
+
+	"github.com/emccode/libstorage/api/types"
+)
 
 const (
 	// Name is the provider's name.
@@ -25,4 +29,6 @@ func registerConfig() {
 	r.Key(gofig.String,
 		"", "/sys/class/scsi_host/", "", "virtualbox.scsiHostPath")
 	gofig.Register(r)
+
+	types.RegisterSecretConfigKeys("virtualbox.password")
 }
@@ -108,6 +108,17 @@ func (d *driver) VolumeCopyAfter(
 	os.MkdirAll(volDir, 0755)
 }
 
+func (d *driver) VolumeResizeBefore(
+	ctx *types.Context,
+	service, volumeID string, request *types.VolumeResizeRequest) error {
+	return nil
+}
+
+func (d *driver) VolumeResizeAfter(
+	ctx types.Context,
+	result *types.Volume) {
+}
+
 func (d *driver) VolumeRemoveBefore(
 	ctx *types.Context, service, volumeID string) error {
 	return nil
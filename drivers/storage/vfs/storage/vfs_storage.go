@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/akutz/gofig"
-	"github.com/akutz/goof"
 	"github.com/akutz/gotil"
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/registry"
@@ -74,6 +73,18 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Object, nil
 }
 
+func (d *driver) Capabilities(
+	ctx types.Context) (*types.StorageCapabilities, error) {
+	return &types.StorageCapabilities{
+		Snapshots:          true,
+		VolumeCopy:         true,
+		SnapshotCopy:       true,
+		CreateFromSnapshot: true,
+		Resize:             true,
+		Type:               types.Object,
+	}, nil
+}
+
 func (d *driver) NextDeviceInfo(
 	ctx types.Context) (*types.NextDeviceInfo, error) {
 	return &types.NextDeviceInfo{
@@ -111,7 +122,7 @@ func (d *driver) Volumes(
 	iid, iidOK := context.InstanceID(ctx)
 	if iidOK {
 		if iid.ID == "" {
-			return nil, goof.New("missing instance ID")
+			return nil, utils.NewMissingInstanceIDError(d.Name())
 		}
 	}
 
@@ -263,6 +274,32 @@ func (d *driver) VolumeCopy(
 	return newVol, nil
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	v, err := d.getVolumeByID(volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Size < v.Size {
+		return nil, utils.NewVolumeShrinkErr(volumeID, v.Size, opts.Size)
+	}
+
+	if len(v.Attachments) > 0 && !opts.Force {
+		return nil, utils.NewVolumeAttachedErr(volumeID)
+	}
+
+	v.Size = opts.Size
+	if err := d.writeVolume(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
 	volumeID, snapshotName string,
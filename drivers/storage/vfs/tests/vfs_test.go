@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
 	"strings"
@@ -21,9 +22,11 @@ import (
 
 	"github.com/emccode/libstorage/api/context"
 	"github.com/emccode/libstorage/api/server"
+	"github.com/emccode/libstorage/api/server/router/volume"
 	apitests "github.com/emccode/libstorage/api/tests"
 	"github.com/emccode/libstorage/api/types"
 	"github.com/emccode/libstorage/api/utils"
+	"github.com/emccode/libstorage/api/utils/signing"
 
 	// load the vfs driver packages
 
@@ -83,6 +86,29 @@ func TestServiceInpspect(t *testing.T) {
 		assert.Equal(t, vfs.Name, reply.Name)
 		assert.Equal(t, vfs.Name, reply.Driver.Name)
 		assert.True(t, reply.Driver.NextDevice.Ignore)
+		assert.NotNil(t, reply.TaskQueue)
+		assert.Equal(t, 0, reply.TaskQueue.Workers)
+		assert.Equal(t, 0, reply.TaskQueue.Depth)
+		if assert.NotNil(t, reply.Driver.Capabilities) {
+			assert.True(t, reply.Driver.Capabilities.Snapshots)
+			assert.True(t, reply.Driver.Capabilities.Resize)
+			assert.False(t, reply.Driver.Capabilities.MultiAttach)
+			assert.Equal(t, types.Object, reply.Driver.Capabilities.Type)
+		}
+	}
+	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
+}
+
+func TestTasks(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		_, err := client.API().Tasks(nil)
+		assert.NoError(t, err)
+
+		_, err = client.API().TaskInspect(nil, 1000000)
+		assert.Error(t, err)
+
+		_, err = client.API().TaskWait(nil, 1000000, time.Second)
+		assert.Error(t, err)
 	}
 	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
 }
@@ -202,6 +228,45 @@ func TestVolumesByServiceWithAttachments(t *testing.T) {
 	apitests.Run(t, vfs.Name, tc, tf)
 }
 
+func TestVolumesIterator(t *testing.T) {
+	tc, _, vols, _ := newTestConfigAll(t)
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		iter, err := client.API().VolumesIterator(
+			nil, false, &types.PageOpts{Limit: 1, Sort: "id:desc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumeIDs := []string{}
+		for iter.Next() {
+			assert.Equal(t, vfs.Name, iter.Service())
+			assert.EqualValues(t, vols[iter.Volume().ID], iter.Volume())
+			volumeIDs = append(volumeIDs, iter.Volume().ID)
+		}
+		assert.NoError(t, iter.Err())
+		assert.Equal(t, []string{"vfs-002", "vfs-001", "vfs-000"}, volumeIDs)
+	}
+	apitests.Run(t, vfs.Name, tc, tf)
+	apitests.RunWithClientType(t, types.ControllerClient, vfs.Name, tc, tf)
+}
+
+func TestVolumesByServiceIteratorWithOffset(t *testing.T) {
+	tc, _, _, _ := newTestConfigAll(t)
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		iter, err := client.API().VolumesByServiceIterator(
+			nil, vfs.Name, false, &types.PageOpts{Limit: 2, Offset: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		volumeIDs := []string{}
+		for iter.Next() {
+			volumeIDs = append(volumeIDs, iter.Volume().ID)
+		}
+		assert.NoError(t, iter.Err())
+		assert.Equal(t, []string{"vfs-001", "vfs-002"}, volumeIDs)
+	}
+	apitests.Run(t, vfs.Name, tc, tf)
+}
+
 func TestVolumeInspect(t *testing.T) {
 	tc, _, vols, _ := newTestConfigAll(t)
 	tf := func(config gofig.Config, client types.Client, t *testing.T) {
@@ -258,6 +323,28 @@ func TestSnapshotsByService(t *testing.T) {
 	apitests.Run(t, vfs.Name, tc, tf)
 }
 
+func TestSnapshotsIterator(t *testing.T) {
+	tc, _, _, snaps := newTestConfigAll(t)
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		iter, err := client.API().SnapshotsIterator(
+			nil, &types.PageOpts{Limit: 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lastID := ""
+		count := 0
+		for iter.Next() {
+			assert.EqualValues(t, snaps[iter.Snapshot().ID], iter.Snapshot())
+			assert.True(t, lastID < iter.Snapshot().ID)
+			lastID = iter.Snapshot().ID
+			count++
+		}
+		assert.NoError(t, iter.Err())
+		assert.Equal(t, len(snaps), count)
+	}
+	apitests.Run(t, vfs.Name, tc, tf)
+}
+
 func TestVolumeCreate(t *testing.T) {
 	tf := func(config gofig.Config, client types.Client, t *testing.T) {
 		volumeName := "Volume 003"
@@ -328,6 +415,122 @@ func TestVolumeCopy(t *testing.T) {
 	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
 }
 
+func TestEvents(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := client.API().Events(ctx, &types.EventsOpts{
+			Services: []string{vfs.Name},
+			Types:    []types.EventType{types.EventVolumeCreate},
+		})
+		assert.NoError(t, err)
+		if err != nil {
+			t.FailNow()
+		}
+
+		request := &types.VolumeCreateRequest{Name: "Volume 010"}
+		reply, err := client.API().VolumeCreate(nil, vfs.Name, request)
+		assert.NoError(t, err)
+		if err != nil {
+			t.FailNow()
+		}
+
+		select {
+		case e := <-events:
+			assert.EqualValues(t, types.EventVolumeCreate, e.Type)
+			assert.Equal(t, vfs.Name, e.Service)
+			assert.Equal(t, reply.ID, e.VolumeID)
+			assert.EqualValues(t, types.EventOutcomeSuccess, e.Outcome)
+			assert.NotEmpty(t, e.TransactionID)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
+}
+
+func TestVolumeResize(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		request := &types.VolumeResizeRequest{Size: 20480}
+
+		reply, err := client.API().VolumeResize(
+			nil, vfs.Name, "vfs-002", request)
+		assert.NoError(t, err)
+		if err != nil {
+			t.FailNow()
+		}
+
+		assert.NotNil(t, reply)
+		assert.Equal(t, "vfs-002", reply.ID)
+		assert.Equal(t, request.Size, reply.Size)
+
+		vol, err := client.API().VolumeInspect(nil, vfs.Name, "vfs-002", false)
+		assert.NoError(t, err)
+		assert.Equal(t, request.Size, vol.Size)
+	}
+	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
+}
+
+func TestVolumeResizeAttached(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		request := &types.VolumeResizeRequest{Size: 20480}
+
+		_, err := client.API().VolumeResize(
+			nil, vfs.Name, "vfs-000", request)
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrVolumeInUse{}, err)
+		assert.Equal(t, 409, types.ErrorCodeOf(err).Status())
+
+		vol, err := client.API().VolumeInspect(nil, vfs.Name, "vfs-000", false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10240), vol.Size)
+
+		// the integration driver resizes a volume attached to this instance
+		// without the caller forcing it
+		ctx := context.Background().WithValue(context.ServiceKey, vfs.Name)
+		vol, err = client.Integration().Resize(
+			ctx, "Volume 000", &types.VolumeResizeOpts{
+				Size: 20480,
+				Opts: utils.NewStore(),
+			})
+		assert.NoError(t, err)
+		if err != nil {
+			t.FailNow()
+		}
+		assert.Equal(t, int64(20480), vol.Size)
+		assert.Len(t, vol.Attachments, 1)
+
+		request.Size = 30720
+		request.Force = true
+		reply, err := client.API().VolumeResize(
+			nil, vfs.Name, "vfs-000", request)
+		assert.NoError(t, err)
+		if err != nil {
+			t.FailNow()
+		}
+		assert.Equal(t, request.Size, reply.Size)
+		assert.Len(t, reply.Attachments, 1)
+	}
+	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
+}
+
+func TestVolumeResizeShrink(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		request := &types.VolumeResizeRequest{Size: 1024}
+
+		_, err := client.API().VolumeResize(
+			nil, vfs.Name, "vfs-000", request)
+		assert.Error(t, err)
+		assert.Equal(t, "volume cannot be shrunk", err.Error())
+
+		vol, err := client.API().VolumeInspect(nil, vfs.Name, "vfs-000", false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10240), vol.Size)
+	}
+	apitests.Run(t, vfs.Name, newTestConfig(t), tf)
+}
+
 func TestVolumeRemove(t *testing.T) {
 
 	tf1 := func(config gofig.Config, client types.Client, t *testing.T) {
@@ -350,6 +553,677 @@ func TestVolumeRemove(t *testing.T) {
 	apitests.RunGroup(t, vfs.Name, newTestConfig(t), tf1, tf2)
 }
 
+const authConfigYAML = `
+libstorage:
+  client:
+    auth:
+      token: alicetoken
+  server:
+    auth:
+      tokens:
+        alice: alicetoken
+      principals:
+        alice:
+          services: vfs
+          verbs: read
+`
+
+func TestAuth(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		vols, err := client.API().Volumes(nil, false)
+		assert.NoError(t, err)
+		assert.Len(t, vols[vfs.Name], 3)
+
+		err = client.API().VolumeRemove(nil, vfs.Name, "vfs-002")
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrForbidden{}, err)
+		assert.Equal(t, "forbidden", err.Error())
+		assert.Equal(t, 403, types.ErrorCodeOf(err).Status())
+		assertVolDir(t, config, "vfs-002", true)
+
+		client.API().AuthToken("badtoken")
+		_, err = client.API().Volumes(nil, false)
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrUnauthenticated{}, err)
+		assert.Equal(t, 401, types.ErrorCodeOf(err).Status())
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(authConfigYAML)...), tf)
+}
+
+const taskAuthConfigYAML = `
+libstorage:
+  client:
+    auth:
+      token: admintoken
+  server:
+    auth:
+      tokens:
+        admin: admintoken
+        alice: alicetoken
+        bob: bobtoken
+      principals:
+        admin:
+          services: "*"
+          verbs: "*"
+        alice:
+          services: vfs
+          verbs: read
+        bob:
+          services: other
+          verbs: read
+`
+
+func TestTaskCancelForbidden(t *testing.T) {
+
+	// the volume is blocked in the OnVolume handler in order to keep its task
+	// running while it is canceled
+	block := make(chan bool)
+	volume.OnVolume = func(
+		ctx types.Context,
+		req *http.Request,
+		store types.Store,
+		v *types.Volume) (bool, error) {
+
+		if strings.HasPrefix(v.Name, "task-auth-") {
+			block <- true
+			<-block
+		}
+		return true, nil
+	}
+	defer func() { volume.OnVolume = nil }()
+
+	var seq int
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+
+		// the task is created and canceled with a plain HTTP client, and
+		// the client does not support TLS
+		host := config.GetString(types.ConfigHost)
+		if !strings.HasPrefix(host, "tcp://") ||
+			config.IsSet(types.ConfigClient+".tls") {
+			return
+		}
+		_, addr, err := gotil.ParseAddress(host)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		do := func(method, path, token, body string) *http.Response {
+			req, err := http.NewRequest(
+				method,
+				fmt.Sprintf("http://%s%s", addr, path),
+				strings.NewReader(body))
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			req.Header.Set(
+				types.AuthorizationHeader, fmt.Sprintf("Bearer %s", token))
+			res, err := http.DefaultClient.Do(req)
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			return res
+		}
+
+		seq++
+		res := do(
+			"POST",
+			fmt.Sprintf("/volumes/%s?async", vfs.Name),
+			"admintoken",
+			fmt.Sprintf(`{"name":"task-auth-%d"}`, seq))
+		if res == nil {
+			return
+		}
+		task := &types.Task{}
+		err = json.NewDecoder(res.Body).Decode(task)
+		res.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, res.StatusCode)
+
+		select {
+		case <-block:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the blocked task")
+		}
+
+		// a read-only principal may inspect the task but not cancel it
+		client.API().AuthToken("alicetoken")
+		it, err := client.API().TaskInspect(nil, task.ID)
+		assert.NoError(t, err)
+		if assert.NotNil(t, it) {
+			assert.Equal(t, vfs.Name, it.Service)
+		}
+
+		res = do("DELETE", fmt.Sprintf("/tasks/%d", task.ID), "alicetoken", "")
+		if res != nil {
+			res.Body.Close()
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		}
+
+		// a principal that may read another service may not inspect the
+		// task, whether or not it waits for the task
+		for _, q := range []string{"", "?wait=100ms"} {
+			res = do("GET",
+				fmt.Sprintf("/tasks/%d%s", task.ID, q), "bobtoken", "")
+			if res != nil {
+				res.Body.Close()
+				assert.Equal(t, http.StatusForbidden, res.StatusCode, q)
+			}
+		}
+
+		block <- true
+		it, err = client.API().TaskWait(nil, task.ID, 10*time.Second)
+		assert.NoError(t, err)
+		if assert.NotNil(t, it) {
+			assert.EqualValues(t, types.TaskStateSuccess, it.State)
+		}
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(taskAuthConfigYAML)...), tf)
+}
+
+const tenantsConfigYAML = `
+libstorage:
+  server:
+    tenants:
+      enabled: true
+`
+
+func TestTenants(t *testing.T) {
+	var seq int
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		seq++
+		name := fmt.Sprintf("db-%d", seq)
+
+		client.API().Tenant("acme")
+		vol, err := client.API().VolumeCreate(
+			nil, vfs.Name, &types.VolumeCreateRequest{Name: name})
+		assert.NoError(t, err)
+		if !assert.NotNil(t, vol) {
+			return
+		}
+		assert.Equal(t, name, vol.Name)
+		snap, err := client.API().VolumeSnapshot(
+			nil, vfs.Name, vol.ID,
+			&types.VolumeSnapshotRequest{SnapshotName: name})
+		assert.NoError(t, err)
+		if !assert.NotNil(t, snap) {
+			return
+		}
+
+		// another tenant may neither see nor operate on acme's volume or
+		// its snapshot
+		client.API().Tenant("globex")
+		assertNotFound := func(err error) {
+			assert.Error(t, err)
+			assert.IsType(t, &types.ErrNotFound{}, err)
+			assert.Equal(t, 404, types.ErrorCodeOf(err).Status())
+		}
+		_, err = client.API().VolumeInspect(nil, vfs.Name, vol.ID, false)
+		assertNotFound(err)
+		_, err = client.API().VolumeResize(
+			nil, vfs.Name, vol.ID, &types.VolumeResizeRequest{Size: 20})
+		assertNotFound(err)
+		_, err = client.API().VolumeSnapshot(
+			nil, vfs.Name, vol.ID,
+			&types.VolumeSnapshotRequest{SnapshotName: name})
+		assertNotFound(err)
+		assertNotFound(client.API().VolumeRemove(nil, vfs.Name, vol.ID))
+		_, err = client.API().SnapshotInspect(nil, vfs.Name, snap.ID)
+		assertNotFound(err)
+		assertNotFound(client.API().SnapshotRemove(nil, vfs.Name, snap.ID))
+
+		snaps, err := client.API().SnapshotsByService(nil, vfs.Name)
+		assert.NoError(t, err)
+		assert.NotContains(t, snaps, snap.ID)
+		assertVolDir(t, config, vol.ID, true)
+
+		client.API().Tenant("acme")
+		_, err = client.API().SnapshotInspect(nil, vfs.Name, snap.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, client.API().SnapshotRemove(nil, vfs.Name, snap.ID))
+		assert.NoError(t, client.API().VolumeRemove(nil, vfs.Name, vol.ID))
+		assertVolDir(t, config, vol.ID, false)
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(tenantsConfigYAML)...), tf)
+}
+
+const auditConfigYAML = `
+libstorage:
+  client:
+    auth:
+      token: alicetoken
+  server:
+    auth:
+      tokens:
+        alice: alicetoken
+      principals:
+        alice:
+          services: vfs
+          verbs: "*"
+    audit:
+      enabled: true
+      file: %s
+`
+
+func TestAuditAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libstorage-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "audit.log")
+
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		assert.NoError(t, client.API().VolumeRemove(nil, vfs.Name, "vfs-002"))
+
+		client.API().AuthToken("badtoken")
+		err := client.API().VolumeRemove(nil, vfs.Name, "vfs-001")
+		assert.IsType(t, &types.ErrUnauthenticated{}, err)
+		assertVolDir(t, config, "vfs-001", true)
+
+		// the request that fails authentication is recorded as well as the
+		// request that succeeds
+		buf, err := ioutil.ReadFile(file)
+		if !assert.NoError(t, err) {
+			return
+		}
+		records := map[string]*types.AuditRecord{}
+		scanner := bufio.NewScanner(bytes.NewReader(buf))
+		for scanner.Scan() {
+			r := &types.AuditRecord{}
+			if assert.NoError(t, json.Unmarshal(scanner.Bytes(), r)) {
+				records[r.VolumeID] = r
+			}
+		}
+		if r := records["vfs-002"]; assert.NotNil(t, r) {
+			assert.Equal(t, "volumeRemove", r.Operation)
+			assert.Equal(t, "alice", r.Principal)
+			assert.Equal(t, http.StatusNoContent, r.Status)
+			assert.EqualValues(t, types.AuditOutcomeSuccess, r.Outcome)
+		}
+		if r := records["vfs-001"]; assert.NotNil(t, r) {
+			assert.Equal(t, "volumeRemove", r.Operation)
+			assert.Empty(t, r.Principal)
+			assert.Equal(t, http.StatusUnauthorized, r.Status)
+			assert.EqualValues(t, types.AuditOutcomeError, r.Outcome)
+		}
+	}
+	apitests.Run(t, vfs.Name,
+		append(
+			newTestConfig(t),
+			[]byte(fmt.Sprintf(auditConfigYAML, file))...),
+		tf)
+}
+
+const profileConfigYAML = `
+libstorage:
+  server:
+    endpoints:
+      localhost:
+        routes:
+          readOnly: true
+          deny: snapshot*
+`
+
+func TestEndpointProfile(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		vols, err := client.API().Volumes(nil, false)
+		assert.NoError(t, err)
+		assert.Len(t, vols[vfs.Name], 3)
+
+		err = client.API().VolumeRemove(nil, vfs.Name, "vfs-002")
+		assert.Error(t, err)
+		assertVolDir(t, config, "vfs-002", true)
+
+		_, err = client.API().Snapshots(nil)
+		assert.Error(t, err)
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(profileConfigYAML)...), tf)
+}
+
+const rateLimitConfigYAML = `
+libstorage:
+  server:
+    rateLimit:
+      key: remoteAddr
+      mutate:
+        rate: 0.001
+        burst: 1
+`
+
+func TestRateLimit(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		err := client.API().VolumeRemove(nil, vfs.Name, "vfs-002")
+		assert.NoError(t, err)
+		assertVolDir(t, config, "vfs-002", false)
+
+		err = client.API().VolumeRemove(nil, vfs.Name, "vfs-001")
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrTooManyRequests{}, err)
+		assert.Equal(t, "rate limit exceeded", err.Error())
+		assert.Equal(t, 429, types.ErrorCodeOf(err).Status())
+		assertVolDir(t, config, "vfs-001", true)
+
+		_, err = client.API().Volumes(nil, false)
+		assert.NoError(t, err)
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(rateLimitConfigYAML)...), tf)
+}
+
+const quotaConfigYAML = `
+libstorage:
+  server:
+    quotas:
+      service:
+        volumes: 4
+`
+
+func TestQuotas(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		request := &types.VolumeCreateRequest{Name: "Volume 003"}
+		_, err := client.API().VolumeCreate(nil, vfs.Name, request)
+		assert.NoError(t, err)
+
+		request = &types.VolumeCreateRequest{Name: "Volume 004"}
+		_, err = client.API().VolumeCreate(nil, vfs.Name, request)
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrQuotaExceeded{}, err)
+		assert.Equal(t, "quota exceeded", err.Error())
+		assert.Equal(t, 409, types.ErrorCodeOf(err).Status())
+
+		quotas, err := client.API().QuotasByService(nil, vfs.Name)
+		assert.NoError(t, err)
+		if !assert.NotEmpty(t, quotas) {
+			t.FailNow()
+		}
+		assert.Equal(t, types.QuotaScopeService, quotas[0].Scope)
+		assert.EqualValues(t, 4, quotas[0].Limits.Volumes)
+		assert.EqualValues(t, 4, quotas[0].Usage.Volumes)
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(quotaConfigYAML)...), tf)
+}
+
+const taskQueueConfigYAML = `
+libstorage:
+  server:
+    tasks:
+      queue:
+        workers: 1
+        depth: 1
+        retryAfter: 2s
+`
+
+func TestTaskQueueFull(t *testing.T) {
+
+	// volumes are blocked in the OnVolume handler, which is invoked by the
+	// task that creates them, in order to keep the service's worker busy
+	var (
+		blocked    = map[string]chan bool{}
+		blockedRWL = &sync.RWMutex{}
+		blockedSeq int
+	)
+	volume.OnVolume = func(
+		ctx types.Context,
+		req *http.Request,
+		store types.Store,
+		v *types.Volume) (bool, error) {
+
+		blockedRWL.RLock()
+		c, ok := blocked[v.Name]
+		blockedRWL.RUnlock()
+		if ok {
+			c <- true
+			<-c
+		}
+		return true, nil
+	}
+	defer func() { volume.OnVolume = nil }()
+
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		client.API().Retries(0, 0)
+
+		blockedRWL.Lock()
+		blockedSeq++
+		name := fmt.Sprintf("blocked-%d", blockedSeq)
+		c := make(chan bool)
+		blocked[name] = c
+		blockedRWL.Unlock()
+
+		create := func(name string, errs chan error) {
+			request := &types.VolumeCreateRequest{Name: name}
+			_, err := client.API().VolumeCreate(nil, vfs.Name, request)
+			errs <- err
+		}
+
+		errs := make(chan error, 2)
+		go create(name, errs)
+		select {
+		case <-c:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the blocked task")
+		}
+		go create(name+"-queued", errs)
+
+		for i := 0; ; i++ {
+			svc, err := client.API().ServiceInspect(nil, vfs.Name)
+			assert.NoError(t, err)
+			if svc != nil && svc.TaskQueue != nil &&
+				svc.TaskQueue.Queued == 1 {
+				break
+			}
+			if i == 100 {
+				t.Fatal("timed out waiting for the queued task")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		request := &types.VolumeCreateRequest{Name: name + "-rejected"}
+		_, err := client.API().VolumeCreate(nil, vfs.Name, request)
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrQueueFull{}, err)
+		assert.Equal(t, "task queue full", err.Error())
+		assert.Equal(t, 503, types.ErrorCodeOf(err).Status())
+
+		// the Retry-After header is asserted with a plain HTTP client, which
+		// is also used to make an asynchronous request
+		host := config.GetString(types.ConfigHost)
+		if strings.HasPrefix(host, "tcp://") &&
+			!config.IsSet(types.ConfigClient+".tls") {
+
+			res := asyncVolumeCreate(t, host, name+"-async")
+			if res != nil {
+				assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+				assert.Equal(t, "2", res.Header.Get("Retry-After"))
+			}
+		}
+
+		c <- true
+		for i := 0; i < 2; i++ {
+			select {
+			case err := <-errs:
+				assert.NoError(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for the tasks to complete")
+			}
+		}
+
+		if strings.HasPrefix(host, "tcp://") &&
+			!config.IsSet(types.ConfigClient+".tls") {
+
+			res := asyncVolumeCreate(t, host, name+"-async")
+			if res != nil {
+				assert.Equal(t, http.StatusAccepted, res.StatusCode)
+			}
+		}
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(taskQueueConfigYAML)...), tf)
+}
+
+// asyncVolumeCreate makes an asynchronous request to create a volume.
+func asyncVolumeCreate(
+	t *testing.T, host, name string) *http.Response {
+
+	_, addr, err := gotil.ParseAddress(host)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	res, err := http.Post(
+		fmt.Sprintf("http://%s/volumes/%s?async", addr, vfs.Name),
+		"application/json",
+		strings.NewReader(fmt.Sprintf(`{"name":%q}`, name)))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	res.Body.Close()
+	return res
+}
+
+const idempotencyConfigYAML = `
+libstorage:
+  server:
+    tasks:
+      exeTimeout: 1s
+`
+
+func TestIdempotentTimeout(t *testing.T) {
+
+	// the volume is blocked in the OnVolume handler until the requests that
+	// wait for its task have timed out, and the task fails if it is canceled
+	// while it is blocked
+	var (
+		released    = map[string]chan bool{}
+		releasedRWL = &sync.RWMutex{}
+	)
+	volume.OnVolume = func(
+		ctx types.Context,
+		req *http.Request,
+		store types.Store,
+		v *types.Volume) (bool, error) {
+
+		releasedRWL.RLock()
+		c, ok := released[v.Name]
+		releasedRWL.RUnlock()
+		if ok {
+			select {
+			case <-c:
+			case <-ctx.Done():
+				return false, goof.New("task canceled")
+			}
+		}
+		return true, nil
+	}
+	defer func() { volume.OnVolume = nil }()
+
+	var seq int
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+
+		// the requests are made with a plain HTTP client in order to make
+		// them with the same idempotency key, and the client does not
+		// support TLS
+		host := config.GetString(types.ConfigHost)
+		if !strings.HasPrefix(host, "tcp://") ||
+			config.IsSet(types.ConfigClient+".tls") {
+			return
+		}
+		_, addr, err := gotil.ParseAddress(host)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		seq++
+		name := fmt.Sprintf("idempotent-%d", seq)
+		release := make(chan bool)
+		releasedRWL.Lock()
+		released[name] = release
+		releasedRWL.Unlock()
+
+		key := fmt.Sprintf("%s-key", name)
+		create := func() *http.Response {
+			req, err := http.NewRequest(
+				"POST",
+				fmt.Sprintf("http://%s/volumes/%s", addr, vfs.Name),
+				strings.NewReader(fmt.Sprintf(`{"name":%q}`, name)))
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			req.Header.Set(types.IdempotencyKeyHeader, key)
+			res, err := http.DefaultClient.Do(req)
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			return res
+		}
+
+		// neither the original request nor its repeat cancels the task when
+		// it times out
+		for i := 0; i < 2; i++ {
+			res := create()
+			if res == nil {
+				return
+			}
+			res.Body.Close()
+			assert.Equal(t, http.StatusRequestTimeout, res.StatusCode)
+		}
+
+		close(release)
+		res := create()
+		if res == nil {
+			return
+		}
+		vol := &types.Volume{}
+		err = json.NewDecoder(res.Body).Decode(vol)
+		res.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, name, vol.Name)
+
+		v, err := client.API().VolumeInspect(nil, vfs.Name, vol.ID, false)
+		assert.NoError(t, err)
+		if assert.NotNil(t, v) {
+			assert.Equal(t, name, v.Name)
+		}
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(idempotencyConfigYAML)...), tf)
+}
+
+const signingConfigYAML = `
+libstorage:
+  client:
+    signing:
+      key: vfssecret
+  server:
+    signing:
+      enabled: true
+      key: vfssecret
+`
+
+func TestHeaderSigning(t *testing.T) {
+	tf := func(config gofig.Config, client types.Client, t *testing.T) {
+		ctx := context.Background().WithValue(context.ServiceKey, vfs.Name)
+		opts := &types.VolumesOpts{Attachments: true, Opts: utils.NewStore()}
+
+		vols, err := client.Storage().Volumes(ctx, opts)
+		assert.NoError(t, err)
+		assert.Len(t, vols, 2)
+
+		client.API().SignHeaders(&signing.Key{Secret: []byte("badsecret")})
+		_, err = client.Storage().Volumes(ctx, opts)
+		assert.Error(t, err)
+		assert.IsType(t, &types.ErrBadSignature{}, err)
+		assert.Equal(t, "invalid header signature", err.Error())
+		assert.Equal(t, 401, types.ErrorCodeOf(err).Status())
+	}
+	apitests.Run(t, vfs.Name,
+		append(newTestConfig(t), []byte(signingConfigYAML)...), tf)
+}
+
 func TestVolumeSnapshot(t *testing.T) {
 	tf := func(config gofig.Config, client types.Client, t *testing.T) {
 		volumeID := "vfs-000"
@@ -81,6 +81,62 @@ func init() {
 	rk(gofig.Bool, false, "", types.ConfigEmbedded)
 	rk(gofig.String, "1m", "", types.ConfigServerTasksExeTimeout)
 	rk(gofig.String, "0s", "", types.ConfigServerTasksLogTimeout)
+	rk(gofig.Int, 0, "", types.ConfigServerTasksQueueWorkers)
+	rk(gofig.Int, 0, "", types.ConfigServerTasksQueueDepth)
+	rk(gofig.Bool, false, "", types.ConfigServerTasksQueuePrioritize)
+	rk(gofig.String, "5s", "", types.ConfigServerTasksQueueRetryAfter)
+	rk(gofig.String, "mem", "", types.ConfigServerTasksStoreType)
+	rk(gofig.String, "", "", types.ConfigServerTasksStorePath)
+	rk(gofig.String, "24h", "", types.ConfigServerTasksStoreRetention)
+	rk(gofig.String, "", "", types.ConfigServerWebhooksSecret)
+	rk(gofig.Int, 3, "", types.ConfigServerWebhooksRetries)
+	rk(gofig.String, "1s", "", types.ConfigServerWebhooksBackoff)
+	rk(gofig.String, "10s", "", types.ConfigServerWebhooksTimeout)
+	rk(gofig.String, "", "", types.ConfigServerWebhooksDeadLetterLog)
+	rk(gofig.String, "", "", types.ConfigServerMetricsToken)
+	rk(gofig.Bool, false, "", types.ConfigServerMetricsAnonymous)
+	rk(gofig.String, "metrics", "", types.ConfigServerAuthAnonymousRoutes)
+	rk(gofig.String, "", "", types.ConfigServerAuthJWTKey)
+	rk(gofig.String, "", "", types.ConfigServerAuthJWTPublicKey)
+	rk(gofig.String, "", "", types.ConfigServerAuthJWTIssuer)
+	rk(gofig.String, "", "", types.ConfigServerAuthJWTAudience)
+	rk(gofig.String, "sub", "", types.ConfigServerAuthJWTPrincipalClaim)
+	rk(gofig.String, "", "", types.ConfigClientAuthToken)
+	rk(gofig.Bool, false, "", types.ConfigServerSigningEnabled)
+	rk(gofig.String, "", "", types.ConfigServerSigningKey)
+	rk(gofig.String, "", "", types.ConfigServerSigningPublicKey)
+	rk(gofig.String, "5m", "", types.ConfigServerSigningMaxAge)
+	rk(gofig.String, "", "", types.ConfigClientSigningKey)
+	rk(gofig.String, "", "", types.ConfigClientSigningPrivateKey)
+	rk(gofig.String, "principal", "", types.ConfigServerRateLimitKey)
+	rk(gofig.String, "0", "", types.ConfigServerRateLimitReadRate)
+	rk(gofig.Int, 0, "", types.ConfigServerRateLimitReadBurst)
+	rk(gofig.String, "0", "", types.ConfigServerRateLimitMutateRate)
+	rk(gofig.Int, 0, "", types.ConfigServerRateLimitMutateBurst)
+	rk(gofig.Int, 0, "", types.ConfigServerDriverCallsMax)
+	rk(gofig.String, "5s", "", types.ConfigServerDriverCallsMaxWait)
+	rk(gofig.String, "30s", "", types.ConfigServerQuotasCacheTTL)
+	rk(gofig.Bool, false, "", types.ConfigServerTenantsEnabled)
+	rk(gofig.String, ".", "", types.ConfigServerTenantsSeparator)
+	rk(gofig.Bool, false, "", types.ConfigServerAuditEnabled)
+	rk(gofig.String, "", "", types.ConfigServerAuditFile)
+	rk(gofig.Int, 100, "", types.ConfigServerAuditMaxSize)
+	rk(gofig.Int, 5, "", types.ConfigServerAuditMaxFiles)
+	rk(gofig.String, "1h", "", types.ConfigServerIdempotencyWindow)
+	rk(gofig.String, "", "", types.ConfigClientTenant)
+	rk(gofig.Int, 3, "", types.ConfigClientRetries)
+	rk(gofig.String, "30s", "", types.ConfigClientRetryMaxWait)
 
 	gofig.Register(r)
+
+	types.RegisterSecretConfigKeys(
+		types.ConfigServerWebhooksSecret,
+		types.ConfigServerMetricsToken,
+		types.ConfigServerAuthTokens,
+		types.ConfigServerAuthJWTKey,
+		types.ConfigServerSigningKey,
+		types.ConfigServerSigningInstances,
+		types.ConfigClientAuthToken,
+		types.ConfigClientSigningKey,
+		types.ConfigClientSigningPrivateKey)
 }
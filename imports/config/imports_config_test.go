@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	apiconfig "github.com/emccode/libstorage/api/utils/config"
+	"github.com/emccode/libstorage/api/utils/schema"
+
+	// load the drivers so that their keys are registered
+	_ "github.com/emccode/libstorage/drivers/integration/docker"
+	_ "github.com/emccode/libstorage/drivers/os/darwin"
+	_ "github.com/emccode/libstorage/drivers/os/linux"
+	_ "github.com/emccode/libstorage/drivers/storage/libstorage"
+	_ "github.com/emccode/libstorage/imports/remote"
+)
+
+// TestRegisteredKeysHaveSchema asserts that each registered key, with its
+// default value, is described by the libStorage configuration JSON schema.
+func TestRegisteredKeysHaveSchema(t *testing.T) {
+
+	var s struct {
+		Definitions map[string]struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"definitions"`
+	}
+	if !assert.NoError(t, json.Unmarshal(
+		[]byte(schema.ConfigJSONSchema), &s)) {
+		t.FailNow()
+	}
+
+	// the root properties that are not described by the schema are not
+	// validated, so the section of each key must be
+	roots := s.Definitions["config"].Properties
+
+	config := gofig.New()
+	keys := config.AllKeys()
+	assert.NotEmpty(t, keys)
+
+	for _, k := range keys {
+		path := strings.Split(k, ".")
+
+		described := false
+		for p := range roots {
+			if strings.EqualFold(p, path[0]) {
+				described = true
+				break
+			}
+		}
+		if !assert.True(t, described, "%s: section not in schema", k) {
+			continue
+		}
+
+		var d interface{} = config.Get(k)
+		for x := len(path) - 1; x >= 0; x-- {
+			d = map[string]interface{}{path[x]: d}
+		}
+		buf, err := json.Marshal(d)
+		if !assert.NoError(t, err, k) {
+			continue
+		}
+		assert.NoError(t, apiconfig.Validate(buf), k)
+	}
+}